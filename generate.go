@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/generate"
+)
+
+const generateUsage = `Usage: terraform-provider-huaweicloud generate [options]
+
+  Finds the existing resources and writes their import blocks and configurations, the provider is configured by the
+  environment variables, e.g. HW_REGION_NAME, HW_ACCESS_KEY and HW_SECRET_KEY.
+
+Options:
+`
+
+// runGenerate runs the sub-command "generate" and returns the exit code.
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), generateUsage)
+		flags.PrintDefaults()
+		fmt.Fprintf(flags.Output(), "\nSupported resource types:\n  %s\n",
+			strings.Join(generate.SupportedTypes(), "\n  "))
+	}
+	types := flags.String("types", "", "the comma-separated resource types to generate, defaults to all supported types")
+	region := flags.String("region", "", "the region of the resources, defaults to HW_REGION_NAME")
+	out := flags.String("out", "", "the file to write, defaults to the standard output")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var opts generate.Options
+	if *types != "" {
+		for _, t := range strings.Split(*types, ",") {
+			opts.ResourceTypes = append(opts.ResourceTypes, strings.TrimSpace(t))
+		}
+	}
+
+	raw := make(map[string]interface{})
+	if *region != "" {
+		raw["region"] = *region
+	}
+	ctx := context.Background()
+	p := huaweicloud.Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		for _, d := range diags {
+			fmt.Fprintf(os.Stderr, "Error configuring the provider: %s %s\n", d.Summary, d.Detail)
+		}
+		return 1
+	}
+
+	if *out == "" {
+		if err := generate.Generate(ctx, p, os.Stdout, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating the configurations: %s\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating %s: %s\n", *out, err)
+		return 1
+	}
+	genErr := generate.Generate(ctx, p, f, opts)
+	// the buffered data may fail to be written when closing, e.g. the disk is full
+	closeErr := f.Close()
+	if genErr != nil {
+		fmt.Fprintf(os.Stderr, "Error generating the configurations: %s\n", genErr)
+		return 1
+	}
+	if closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Error closing %s: %s\n", *out, closeErr)
+		return 1
+	}
+	return 0
+}
@@ -136,20 +136,34 @@ func UnsubscribePrePaidResource(d *schema.ResourceData, config *config.Config, r
 	return err
 }
 
-func CheckForRetryableError(err error) *resource.RetryError {
+// CheckForRetryableError returns a retryable error if the request is conflicted, the server fails, or the response
+// has a status code or an error code which is retryable in the retry policy of the provider configuration.
+// The default retry policy is used if cfg is nil.
+func CheckForRetryableError(cfg *config.Config, err error) *resource.RetryError {
+	var respErr golangsdk.ErrUnexpectedResponseCode
 	switch errCode := err.(type) {
 	case golangsdk.ErrDefault500:
 		return resource.RetryableError(err)
+	case golangsdk.ErrDefault400:
+		respErr = errCode.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault429:
+		respErr = errCode.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault503:
+		respErr = errCode.ErrUnexpectedResponseCode
 	case golangsdk.ErrUnexpectedResponseCode:
-		switch errCode.Actual {
-		case 409, 503:
-			return resource.RetryableError(err)
-		default:
-			return resource.NonRetryableError(err)
-		}
+		respErr = errCode
 	default:
 		return resource.NonRetryableError(err)
 	}
+
+	policy := config.NewRetryPolicy(0)
+	if cfg != nil {
+		policy = cfg.GetRetryPolicy()
+	}
+	if respErr.Actual == 409 || policy.IsRetryableStatusCode(respErr.Actual) || policy.IsRetryableErrorCode(respErr.Body) {
+		return resource.RetryableError(err)
+	}
+	return resource.NonRetryableError(err)
 }
 
 func WaitOrderComplete(ctx context.Context, client *golangsdk.ServiceClient, orderId string,
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestCheckForRetryableError(t *testing.T) {
+	policy := config.NewRetryPolicy(3)
+	policy.RetryableStatusCodes = []int{502}
+	policy.RetryableErrorCodes = []string{"VPC.0001"}
+	cfg := &config.Config{RetryPolicy: policy}
+
+	cases := []struct {
+		name      string
+		cfg       *config.Config
+		err       error
+		retryable bool
+	}{
+		{"server error", cfg, golangsdk.ErrDefault500{}, true},
+		{"conflict", cfg, golangsdk.ErrUnexpectedResponseCode{Actual: 409}, true},
+		{"configured status code", cfg, golangsdk.ErrUnexpectedResponseCode{Actual: 502}, true},
+		{"status code not configured", cfg, golangsdk.ErrDefault503{
+			ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{Actual: 503},
+		}, false},
+		{"configured error code", cfg, golangsdk.ErrDefault400{
+			ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: 400,
+				Body:   []byte(`{"error_code": "VPC.0001"}`),
+			},
+		}, true},
+		{"default error code not configured", cfg, golangsdk.ErrDefault400{
+			ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: 400,
+				Body:   []byte(`{"error_code": "APIGW.0308"}`),
+			},
+		}, false},
+		{"default status code", nil, golangsdk.ErrDefault429{
+			ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{Actual: 429},
+		}, true},
+		{"not found", nil, golangsdk.ErrDefault404{}, false},
+	}
+
+	for _, tc := range cases {
+		if got := CheckForRetryableError(tc.cfg, tc.err).Retryable; got != tc.retryable {
+			t.Fatalf("the result of %s is not as expected, want retryable %v, but %v", tc.name, tc.retryable, got)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DeletionProtectedResources are the stateful resources which support the `deletion_protection` argument.
+var DeletionProtectedResources = []string{
+	"huaweicloud_cce_cluster",
+	"huaweicloud_dcs_instance",
+	"huaweicloud_dds_instance",
+	"huaweicloud_dws_cluster",
+	"huaweicloud_evs_volume",
+	"huaweicloud_gaussdb_cassandra_instance",
+	"huaweicloud_gaussdb_influx_instance",
+	"huaweicloud_gaussdb_mongo_instance",
+	"huaweicloud_gaussdb_mysql_instance",
+	"huaweicloud_gaussdb_opengauss_instance",
+	"huaweicloud_gaussdb_redis_instance",
+	"huaweicloud_obs_bucket",
+	"huaweicloud_rds_instance",
+}
+
+// DeletionProtectionSchema returns the schema of the provider-side deletion protection.
+func DeletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+}
+
+// InjectDeletionProtection adds the `deletion_protection` argument to the resources, the protected resources can not
+// be deleted, and the plan fails if they would be replaced because of the ForceNew arguments. Unlike the
+// `lifecycle.prevent_destroy`, the protection is saved in the state, so it still takes effect after the resource block
+// is removed from the configuration. The SDK has no deletion lock APIs of these services, so nothing is sent to the
+// cloud.
+func InjectDeletionProtection(resources map[string]*schema.Resource, names ...string) {
+	for _, name := range names {
+		r, ok := resources[name]
+		if !ok || r.Schema["deletion_protection"] != nil {
+			continue
+		}
+
+		r.Schema["deletion_protection"] = DeletionProtectionSchema()
+		r.CustomizeDiff = withDeletionProtectionDiff(name, r.Schema, r.CustomizeDiff)
+		wrapProtectedRead(r)
+		wrapProtectedDelete(name, r)
+	}
+}
+
+func deletionProtectedDiag(name, id, reason string) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s (%s) is protected from deletion", name, id),
+			Detail: fmt.Sprintf("The resource can not be %s because deletion_protection is enabled. Set "+
+				"deletion_protection to false and apply the change first if you really want to do that.", reason),
+		},
+	}
+}
+
+// withDeletionProtectionDiff fails the plan if the protected resource would be replaced. The protection saved in the
+// state takes effect, so disabling the protection and replacing the resource can not be done in the same apply.
+func withDeletionProtectionDiff(name string, sm map[string]*schema.Schema,
+	origin schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if origin != nil {
+			if err := origin(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+		if protected, _ := d.GetChange("deletion_protection"); protected != true {
+			return nil
+		}
+
+		if keys := changedForceNewKeys(d, sm); len(keys) > 0 {
+			summary := deletionProtectedDiag(name, d.Id(), "replaced")[0]
+			return fmt.Errorf("%s: %s The arguments which require the replacement are: %v", summary.Summary,
+				summary.Detail, keys)
+		}
+		return nil
+	}
+}
+
+// changedForceNewKeys returns the changed ForceNew arguments, including the ones in the nested blocks.
+func changedForceNewKeys(d *schema.ResourceDiff, sm map[string]*schema.Schema) []string {
+	result := make([]string, 0)
+	for k, s := range sm {
+		if !d.HasChange(k) {
+			continue
+		}
+		oldValue, newValue := d.GetChange(k)
+		if s.ForceNew || nestedForceNewChanged(s, oldValue, newValue) {
+			result = append(result, k)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// nestedForceNewChanged compares the nested ForceNew arguments of the blocks. A changed element of a set is removed
+// and added, so any change of a set whose elements have ForceNew arguments requires the replacement.
+func nestedForceNewChanged(s *schema.Schema, oldValue, newValue interface{}) bool {
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok || !hasForceNew(elem.Schema) {
+		return false
+	}
+	if s.Type == schema.TypeSet {
+		return true
+	}
+
+	oldList, _ := oldValue.([]interface{})
+	newList, _ := newValue.([]interface{})
+	for i := 0; i < len(oldList) || i < len(newList); i++ {
+		oldItem, newItem := listItem(oldList, i), listItem(newList, i)
+		for k, nested := range elem.Schema {
+			if nested.ForceNew && !isEquivalentValue(oldItem[k], newItem[k]) {
+				return true
+			}
+			if nestedForceNewChanged(nested, oldItem[k], newItem[k]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasForceNew(sm map[string]*schema.Schema) bool {
+	for _, s := range sm {
+		if s.ForceNew {
+			return true
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok && hasForceNew(elem.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func listItem(list []interface{}, index int) map[string]interface{} {
+	if index >= len(list) {
+		return nil
+	}
+	item, _ := list[index].(map[string]interface{})
+	return item
+}
+
+// isEquivalentValue returns true if the values are equal, the missing values are equal to the zero values.
+func isEquivalentValue(a, b interface{}) bool {
+	isZero := func(v interface{}) bool {
+		if v == nil {
+			return true
+		}
+		if set, ok := v.(*schema.Set); ok {
+			return set.Len() == 0
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {
+			return rv.Len() == 0
+		}
+		return rv.IsZero()
+	}
+	if isZero(a) && isZero(b) {
+		return true
+	}
+	if setA, ok := a.(*schema.Set); ok {
+		if setB, ok := b.(*schema.Set); ok {
+			return setA.Equal(setB)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// wrapProtectedRead saves the protection to the state, so that the imported resources are not different from the
+// created ones.
+func wrapProtectedRead(r *schema.Resource) {
+	origin := r.ReadContext
+	if origin == nil && r.Read != nil {
+		legacy := r.Read
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Read = nil
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := origin(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		if err := d.Set("deletion_protection", d.Get("deletion_protection")); err != nil {
+			return append(diags, diag.Errorf("error setting deletion_protection: %s", err)...)
+		}
+		return diags
+	}
+}
+
+func wrapProtectedDelete(name string, r *schema.Resource) {
+	origin := r.DeleteContext
+	if origin == nil && r.Delete != nil {
+		legacy := r.Delete
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Delete = nil
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if d.Get("deletion_protection").(bool) {
+			return deletionProtectedDiag(name, d.Id(), "deleted")
+		}
+		return origin(ctx, d, meta)
+	}
+}
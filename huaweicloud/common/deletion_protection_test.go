@@ -0,0 +1,54 @@
+package common_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestDeletionProtection(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+	res := huaweicloud.Provider().ResourcesMap["huaweicloud_evs_volume"]
+
+	raw := map[string]interface{}{
+		"name":                "volume-fake",
+		"availability_zone":   fakecloud.Region + "a",
+		"volume_type":         "SSD",
+		"size":                20,
+		"deletion_protection": true,
+	}
+	d := schema.TestResourceDataRaw(t, res.Schema, raw)
+	th.AssertEquals(t, false, res.CreateContext(ctx, d, cfg).HasError())
+
+	// the replacement is refused by the plan
+	raw["volume_type"] = "SAS"
+	_, err := res.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "[volume_type]"))
+
+	// the deletion fails and the volume is kept
+	diags := res.DeleteContext(ctx, d, cfg)
+	th.AssertEquals(t, true, diags.HasError())
+	th.AssertEquals(t, true, strings.Contains(diags[0].Summary, "is protected from deletion"))
+	th.AssertEquals(t, 1, server.Count(fakecloud.KindVolume))
+
+	// the protection can be disabled together with the changes which don't require the replacement
+	raw["volume_type"] = "SSD"
+	raw["deletion_protection"] = false
+	diff, err := res.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, diff.RequiresNew())
+
+	th.AssertNoErr(t, d.Set("deletion_protection", false))
+	th.AssertEquals(t, false, res.DeleteContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, 0, server.Count(fakecloud.KindVolume))
+}
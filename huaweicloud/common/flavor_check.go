@@ -0,0 +1,246 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/flavors"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// maxFlavorAlternatives is the maximum number of the alternatives listed in the error message.
+const maxFlavorAlternatives = 30
+
+// FlavorChecker checks whether the flavor is available in the availability zones when planning, so that the
+// unavailable flavors are reported before any resource is created.
+type FlavorChecker struct {
+	// FlavorKey is the argument of the flavor, e.g. flavor_id
+	FlavorKey string
+	// AvailabilityZoneKey is the argument of the availability zones, whose type is a string or a list of strings
+	AvailabilityZoneKey string
+	// ListFlavors returns the flavors which are available in all the zones, or in the region if the zones are empty
+	ListFlavors func(ctx context.Context, d *schema.ResourceDiff, cfg *config.Config, zones []string) ([]string, error)
+	// CheckQuota checks whether the quotas are enough to create the resource of the available flavor, it's optional
+	// and only called when the resource is created
+	CheckQuota func(ctx context.Context, d *schema.ResourceDiff, cfg *config.Config, flavor string) error
+}
+
+// CheckFlavorAvailability returns the CustomizeDiff function which checks the flavor when it's created or changed.
+// The check is skipped if the values are unknown until applying, or the flavors fail to be listed, e.g. the account
+// has no permission to list them, and the apply reports the error in this case.
+func CheckFlavorAvailability(checker FlavorChecker) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		cfg, ok := meta.(*config.Config)
+		if !ok || !d.NewValueKnown(checker.FlavorKey) || !d.NewValueKnown(checker.AvailabilityZoneKey) {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChanges(checker.FlavorKey, checker.AvailabilityZoneKey) {
+			return nil
+		}
+
+		flavor, _ := d.Get(checker.FlavorKey).(string)
+		if flavor == "" {
+			return nil
+		}
+		zones := getAvailabilityZones(d.Get(checker.AvailabilityZoneKey))
+
+		available, err := checker.ListFlavors(ctx, d, cfg, zones)
+		if err != nil {
+			log.Printf("[WARN] skip checking the %s %q, failed to list the flavors: %s", checker.FlavorKey, flavor, err)
+			return nil
+		}
+		if utils.StrSliceContains(available, flavor) {
+			if d.Id() == "" && checker.CheckQuota != nil {
+				return checker.CheckQuota(ctx, d, cfg, flavor)
+			}
+			return nil
+		}
+
+		location := fmt.Sprintf("the region %s", GetDiffRegion(d, cfg))
+		if len(zones) > 0 {
+			location = fmt.Sprintf("the availability zone %s", strings.Join(zones, ", "))
+		}
+		if len(available) == 0 {
+			return fmt.Errorf("the %s %q is not available in %s, and there is no available flavor, please check the %s",
+				checker.FlavorKey, flavor, location, checker.AvailabilityZoneKey)
+		}
+		return fmt.Errorf("the %s %q is not available in %s, the valid alternatives are: %s", checker.FlavorKey, flavor,
+			location, formatAlternatives(available))
+	}
+}
+
+// GetDiffRegion returns the region of the resource when planning, the provider-level region is used if it's not set.
+func GetDiffRegion(d *schema.ResourceDiff, cfg *config.Config) string {
+	if v, ok := d.GetOk("region"); ok {
+		return v.(string)
+	}
+	return cfg.Region
+}
+
+// getAvailabilityZones returns the specified zones, the zone "random" is ignored.
+func getAvailabilityZones(v interface{}) []string {
+	var zones []string
+	switch val := v.(type) {
+	case string:
+		zones = []string{val}
+	case []interface{}:
+		zones = utils.ExpandToStringList(val)
+	}
+
+	result := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if zone != "" && zone != "random" {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
+
+func formatAlternatives(alternatives []string) string {
+	sorted := append([]string{}, alternatives...)
+	sort.Strings(sorted)
+	if len(sorted) <= maxFlavorAlternatives {
+		return strings.Join(sorted, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(sorted[:maxFlavorAlternatives], ", "),
+		len(sorted)-maxFlavorAlternatives)
+}
+
+// ListComputeFlavors lists the ECS flavors which are available in all the zones, the same as the data source
+// huaweicloud_compute_flavors. It's used by the resources which create the ECS instances, e.g. the CCE nodes.
+func ListComputeFlavors(_ context.Context, d *schema.ResourceDiff, cfg *config.Config, zones []string) ([]string, error) {
+	if len(zones) == 0 {
+		zones = []string{""}
+	}
+	var result []string
+	for i, zone := range zones {
+		allFlavors, err := listComputeFlavorsInZone(cfg, GetDiffRegion(d, cfg), zone)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(allFlavors))
+		for _, flavor := range allFlavors {
+			if i == 0 || utils.StrSliceContains(result, flavor.ID) {
+				ids = append(ids, flavor.ID)
+			}
+		}
+		result = ids
+	}
+	return result, nil
+}
+
+// listComputeFlavorsInZone lists the ECS flavors which are on sale in the zone, or in any zone if it's empty. The
+// flavors are cached by the region and the zone, so they're listed once for all the resources in a plan.
+func listComputeFlavorsInZone(cfg *config.Config, region, zone string) ([]flavors.Flavor, error) {
+	result, err := cfg.LoadOrStoreList(fmt.Sprintf("ecs_flavors/%s/%s", region, zone), func() (interface{}, error) {
+		client, err := cfg.ComputeV1Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ECS client: %s", err)
+		}
+
+		pages, err := flavors.List(client, flavors.ListOpts{AvailabilityZone: zone}).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		return flavors.ExtractFlavors(pages)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]flavors.Flavor), nil
+}
+
+// computeLimits is the response of the API which queries the ECS quotas, the negative max values are unlimited.
+type computeLimits struct {
+	Absolute struct {
+		MaxTotalInstances  int `json:"maxTotalInstances"`
+		TotalInstancesUsed int `json:"totalInstancesUsed"`
+		MaxTotalCores      int `json:"maxTotalCores"`
+		TotalCoresUsed     int `json:"totalCoresUsed"`
+		MaxTotalRAMSize    int `json:"maxTotalRAMSize"`
+		TotalRAMUsed       int `json:"totalRAMUsed"`
+	} `json:"absolute"`
+}
+
+// CheckComputeQuota returns the function which checks the ECS quotas of the instances, the cores and the RAM before
+// creating the instances of the flavor. The number of the instances is the value of the countKey, or 1 if it's empty.
+// The check is skipped if the quotas or the flavor fail to be queried, and each resource is checked separately, so
+// the resources created in the same apply may still exceed the quotas together.
+func CheckComputeQuota(countKey string) func(context.Context, *schema.ResourceDiff, *config.Config, string) error {
+	return func(_ context.Context, d *schema.ResourceDiff, cfg *config.Config, flavor string) error {
+		count := 1
+		if countKey != "" {
+			if !d.NewValueKnown(countKey) {
+				return nil
+			}
+			count = d.Get(countKey).(int)
+		}
+		if count <= 0 {
+			return nil
+		}
+
+		region := GetDiffRegion(d, cfg)
+		vcpus, ram, err := getComputeFlavorSize(cfg, region, flavor)
+		if err != nil {
+			log.Printf("[WARN] skip checking the quotas of the flavor %q: %s", flavor, err)
+			return nil
+		}
+		client, err := cfg.ComputeV1Client(region)
+		if err != nil {
+			log.Printf("[WARN] skip checking the quotas of the flavor %q, error creating ECS client: %s", flavor, err)
+			return nil
+		}
+		var limits computeLimits
+		if _, err := client.Get(client.ServiceURL("cloudservers", "limits"), &limits, nil); err != nil {
+			log.Printf("[WARN] skip checking the quotas of the flavor %q, failed to query the quotas: %s", flavor, err)
+			return nil
+		}
+
+		quota := limits.Absolute
+		exceeded := make([]string, 0)
+		for _, q := range []struct {
+			name           string
+			max, used, add int
+		}{
+			{"instances", quota.MaxTotalInstances, quota.TotalInstancesUsed, count},
+			{"cores", quota.MaxTotalCores, quota.TotalCoresUsed, vcpus * count},
+			{"RAM (MB)", quota.MaxTotalRAMSize, quota.TotalRAMUsed, ram * count},
+		} {
+			if q.max >= 0 && q.used+q.add > q.max {
+				exceeded = append(exceeded, fmt.Sprintf("%s: %d required, %d of %d used", q.name, q.add, q.used, q.max))
+			}
+		}
+		if len(exceeded) > 0 {
+			return fmt.Errorf("the ECS quotas are not enough to create %d instance(s) of the flavor %q (%s), please "+
+				"release the unused resources or apply for higher quotas", count, flavor, strings.Join(exceeded, "; "))
+		}
+		return nil
+	}
+}
+
+// getComputeFlavorSize returns the number of the vCPUs and the RAM size (MB) of the ECS flavor.
+func getComputeFlavorSize(cfg *config.Config, region, flavorID string) (vcpus, ram int, err error) {
+	allFlavors, err := listComputeFlavorsInZone(cfg, region, "")
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, flavor := range allFlavors {
+		if flavor.ID == flavorID {
+			vcpus, err = strconv.Atoi(flavor.Vcpus)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid vCPUs of the flavor: %s", flavor.Vcpus)
+			}
+			return vcpus, int(flavor.Ram), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("the flavor is not found in the region %s", region)
+}
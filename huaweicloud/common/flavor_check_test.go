@@ -0,0 +1,57 @@
+package common_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestFlavorCheck(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+	res := huaweicloud.Provider().ResourcesMap["huaweicloud_compute_instance"]
+
+	raw := map[string]interface{}{
+		"name":              "ecs-fake",
+		"image_id":          "image-fake",
+		"flavor_id":         "m6.xlarge.8",
+		"availability_zone": fakecloud.Region + "a",
+		"network": []interface{}{
+			map[string]interface{}{"uuid": "subnet-fake"},
+		},
+	}
+	_, err := res.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertEquals(t, true, err != nil)
+	th.AssertEquals(t, true, strings.Contains(err.Error(), `the flavor_id "m6.xlarge.8" is not available in the `+
+		`availability zone cn-north-4a, the valid alternatives are: c7.large.2, s6.medium.2, s6.small.1`))
+
+	raw["flavor_id"] = "s6.small.1"
+	_, err = res.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertNoErr(t, err)
+
+	// the flavors on sale in any zone are valid if the zone is not specified
+	raw["flavor_id"] = "m6.xlarge.8"
+	delete(raw, "availability_zone")
+	_, err = res.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertNoErr(t, err)
+
+	// the quotas are checked after the flavor is available
+	raw["availability_zone"] = fakecloud.Region + "b"
+	server.SetObject(fakecloud.KindQuota, "ecs", map[string]interface{}{"maxTotalCores": 2, "maxTotalRAMSize": 8192})
+	_, err = res.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertEquals(t, true, err != nil)
+	th.AssertEquals(t, true, strings.Contains(err.Error(), `the ECS quotas are not enough to create 1 instance(s) of `+
+		`the flavor "m6.xlarge.8" (cores: 4 required, 0 of 2 used; RAM (MB): 32768 required, 0 of 8192 used)`))
+
+	raw["flavor_id"] = "s6.small.1"
+	_, err = res.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), cfg)
+	th.AssertNoErr(t, err)
+}
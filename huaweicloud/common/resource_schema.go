@@ -35,6 +35,15 @@ func TagsComputedSchema() *schema.Schema {
 	}
 }
 
+// TagsAllSchema returns the schema to use for all tags of the resource, including the provider-level default tags
+func TagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
 func SchemaChargingMode(conflicts []string) *schema.Schema {
 	resourceSchema := schema.Schema{
 		Type:     schema.TypeString,
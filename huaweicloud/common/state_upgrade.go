@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ChainStateUpgrades returns the upgrade function which applies the functions in order.
+func ChainStateUpgrades(upgrades ...schema.StateUpgradeFunc) schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		var err error
+		for _, upgrade := range upgrades {
+			if rawState == nil {
+				return nil, nil
+			}
+			if rawState, err = upgrade(ctx, rawState, meta); err != nil {
+				return nil, err
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// RenameStateAttributes returns the upgrade function which renames the top-level attributes, the key of the renames
+// is the old name and the value is the new name.
+func RenameStateAttributes(renames map[string]string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		for oldName, newName := range renames {
+			if v, ok := rawState[oldName]; ok {
+				delete(rawState, oldName)
+				rawState[newName] = v
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// RemoveStateAttributes returns the upgrade function which removes the top-level attributes.
+func RemoveStateAttributes(keys ...string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		for _, key := range keys {
+			delete(rawState, key)
+		}
+		return rawState, nil
+	}
+}
+
+// SetStateAttributeDefaults returns the upgrade function which sets the values of the top-level attributes if they are
+// missing or null, e.g. the new attributes with default values.
+func SetStateAttributeDefaults(defaults map[string]interface{}) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		for key, value := range defaults {
+			if rawState[key] == nil {
+				rawState[key] = value
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// UpgradeJSONState applies the state upgraders of the resource to the raw state saved with the schema version, the
+// result is the state of the current schema version.
+func UpgradeJSONState(ctx context.Context, r *schema.Resource, version int, rawState map[string]interface{},
+	meta interface{}) (map[string]interface{}, error) {
+	var err error
+	for _, upgrader := range r.StateUpgraders {
+		if upgrader.Version < version {
+			continue
+		}
+		if rawState, err = upgrader.Upgrade(ctx, rawState, meta); err != nil {
+			return nil, err
+		}
+		if rawState == nil {
+			break
+		}
+	}
+	return rawState, nil
+}
@@ -0,0 +1,325 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// InjectProviderTags wraps the CRUD functions of all resources which have a `tags` map, so that the provider-level
+// tag settings take effect on these resources:
+//   - the tags matching `ignore_tags` are never saved to the state, so they will not be deleted by terraform.
+//   - for the resources whose tags can be configured, the computed `tags_all` attribute is added and the
+//     `default_tags` are merged into the tags sent to the cloud. The `tags` attribute keeps only the tags set in the
+//     resource, and `tags_all` saves all tags of the resource, including the default ones.
+//   - if the tags of the resource can not be updated (ForceNew), the `default_tags` only take effect when the resource
+//     is created, the changes of `default_tags` do not replace the existing resources.
+func InjectProviderTags(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		if !hasTagsMap(r) {
+			continue
+		}
+
+		withTagsAll := isTaggableResource(r)
+		updatable := withTagsAll && !r.Schema["tags"].ForceNew
+		if withTagsAll {
+			r.Schema["tags_all"] = TagsAllSchema()
+			r.CustomizeDiff = withTagsAllDiff(r.CustomizeDiff, updatable)
+		}
+		wrapTaggableCreate(r, withTagsAll)
+		wrapTaggableRead(r, withTagsAll)
+		if updatable {
+			wrapTaggableUpdate(r)
+		}
+	}
+}
+
+// InjectIgnoreTags wraps the Read functions of the data sources which return the tags, so that the tags matching
+// `ignore_tags` are not returned, including the tags of the nested objects, such as the instances of a list.
+func InjectIgnoreTags(dataSources map[string]*schema.Resource) {
+	for _, r := range dataSources {
+		if hasIgnorableTags(r.Schema) {
+			wrapIgnoreTagsRead(r)
+		}
+	}
+}
+
+func hasTagsMap(r *schema.Resource) bool {
+	s, ok := r.Schema["tags"]
+	if !ok || !isTagsMap(s) {
+		return false
+	}
+	_, ok = r.Schema["tags_all"]
+	return !ok
+}
+
+func isTagsMap(s *schema.Schema) bool {
+	if s.Type != schema.TypeMap {
+		return false
+	}
+	elem, ok := s.Elem.(*schema.Schema)
+	return ok && elem.Type == schema.TypeString
+}
+
+// hasIgnorableTags returns whether the schema has a `tags` map, either at the top level or in the nested blocks.
+func hasIgnorableTags(schemaMap map[string]*schema.Schema) bool {
+	for k, s := range schemaMap {
+		if k == "tags" && isTagsMap(s) {
+			return true
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok && hasIgnorableTags(elem.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTaggableResource(r *schema.Resource) bool {
+	s := r.Schema["tags"]
+	return s.Optional || s.Required
+}
+
+func getDefaultTags(meta interface{}) map[string]interface{} {
+	if cfg, ok := meta.(*config.Config); ok {
+		return cfg.DefaultTags
+	}
+	return nil
+}
+
+func getIgnoreTags(meta interface{}) *utils.IgnoreTagsConfig {
+	if cfg, ok := meta.(*config.Config); ok {
+		return cfg.IgnoreTags
+	}
+	return nil
+}
+
+func withTagsAllDiff(origin schema.CustomizeDiffFunc, updatable bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if origin != nil {
+			if err := origin(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		// the tags of the existing resource are kept unless the resource is replaced
+		if !updatable && d.Id() != "" && !d.HasChange("tags") {
+			return nil
+		}
+
+		if !d.NewValueKnown("tags") {
+			return d.SetNewComputed("tags_all")
+		}
+
+		tagsAll := utils.MergeDefaultTags(getDefaultTags(meta), d.Get("tags").(map[string]interface{}))
+		return d.SetNew("tags_all", getIgnoreTags(meta).FilterTags(tagsAll))
+	}
+}
+
+// setTagsWithDefaults replaces the `tags` of the resource with the result of merging the provider-level default tags
+// and the tags set in the resource, so that the origin CRUD functions send all tags to the cloud.
+func setTagsWithDefaults(d *schema.ResourceData, defaultTags map[string]interface{}) error {
+	if len(defaultTags) == 0 {
+		return nil
+	}
+	return d.Set("tags", utils.MergeDefaultTags(defaultTags, d.Get("tags").(map[string]interface{})))
+}
+
+// refreshTags removes the ignored tags and the default tags which are not set in the resource from `tags`,
+// the resource-level tags are specified by the configured argument. If withTagsAll is true, all tags of the resource
+// except the ignored ones are saved to `tags_all`.
+// The default tags include the ones removed from the provider configuration but still saved in `tags_all`, so they
+// are deleted by the change of `tags_all` instead of being reported as the resource-level tags.
+func refreshTags(d *schema.ResourceData, meta interface{}, configured map[string]interface{},
+	withTagsAll bool) diag.Diagnostics {
+	if d.Id() == "" {
+		return nil
+	}
+
+	defaultTags := getDefaultTags(meta)
+	var savedTags map[string]interface{}
+	if withTagsAll {
+		savedTags, _ = d.Get("tags_all").(map[string]interface{})
+	}
+
+	allTags, _ := d.Get("tags").(map[string]interface{})
+	allTags = getIgnoreTags(meta).FilterTags(allTags)
+	resourceTags := make(map[string]interface{}, len(allTags))
+	for k, v := range allTags {
+		if _, isConfigured := configured[k]; !isConfigured && withTagsAll {
+			_, isDefault := defaultTags[k]
+			_, isSaved := savedTags[k]
+			if isDefault || isSaved {
+				continue
+			}
+		}
+		resourceTags[k] = v
+	}
+
+	if err := d.Set("tags", resourceTags); err != nil {
+		return diag.Errorf("error saving tags to state: %s", err)
+	}
+	if !withTagsAll {
+		return nil
+	}
+	if err := d.Set("tags_all", allTags); err != nil {
+		return diag.Errorf("error saving tags_all to state: %s", err)
+	}
+	return nil
+}
+
+func wrapTaggableCreate(r *schema.Resource, withTagsAll bool) {
+	origin := r.CreateContext
+	if origin == nil && r.Create != nil {
+		legacy := r.Create
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Create = nil
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		configured := d.Get("tags").(map[string]interface{})
+		if withTagsAll {
+			if err := setTagsWithDefaults(d, getDefaultTags(meta)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		diags := origin(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, refreshTags(d, meta, configured, withTagsAll)...)
+	}
+}
+
+func wrapTaggableRead(r *schema.Resource, withTagsAll bool) {
+	origin := r.ReadContext
+	if origin == nil && r.Read != nil {
+		legacy := r.Read
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Read = nil
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		// the tags saved in the state are the ones set in the resource
+		configured := d.Get("tags").(map[string]interface{})
+		diags := origin(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, refreshTags(d, meta, configured, withTagsAll)...)
+	}
+}
+
+func wrapIgnoreTagsRead(r *schema.Resource) {
+	origin := r.ReadContext
+	if origin == nil && r.Read != nil {
+		legacy := r.Read
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Read = nil
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := origin(ctx, d, meta)
+		ignored := getIgnoreTags(meta)
+		if diags.HasError() || ignored == nil || d.Id() == "" {
+			return diags
+		}
+
+		for k, s := range r.Schema {
+			if k == "tags" && isTagsMap(s) {
+				tagmap, _ := d.Get(k).(map[string]interface{})
+				if err := d.Set(k, ignored.FilterTags(tagmap)); err != nil {
+					return append(diags, diag.Errorf("error saving %s to state: %s", k, err)...)
+				}
+				continue
+			}
+
+			elem, ok := s.Elem.(*schema.Resource)
+			if !ok || !hasIgnorableTags(elem.Schema) {
+				continue
+			}
+			items := filterNestedTags(elem.Schema, d.Get(k), ignored)
+			if err := d.Set(k, items); err != nil {
+				return append(diags, diag.Errorf("error saving %s to state: %s", k, err)...)
+			}
+		}
+		return diags
+	}
+}
+
+// filterNestedTags removes the ignored tags from the objects of a nested block, the value of the block is either a
+// list or a set, and the result is always a list.
+func filterNestedTags(schemaMap map[string]*schema.Schema, value interface{},
+	ignored *utils.IgnoreTagsConfig) []interface{} {
+	var items []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		items = v
+	case *schema.Set:
+		items = v.List()
+	}
+
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, s := range schemaMap {
+			if k == "tags" && isTagsMap(s) {
+				tagmap, _ := obj[k].(map[string]interface{})
+				obj[k] = ignored.FilterTags(tagmap)
+			} else if elem, ok := s.Elem.(*schema.Resource); ok && hasIgnorableTags(elem.Schema) {
+				obj[k] = filterNestedTags(elem.Schema, obj[k], ignored)
+			}
+		}
+	}
+	return items
+}
+
+func wrapTaggableUpdate(r *schema.Resource) {
+	origin := r.UpdateContext
+	if origin == nil && r.Update != nil {
+		legacy := r.Update
+		origin = func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(legacy(d, meta))
+		}
+	}
+	if origin == nil {
+		return
+	}
+
+	r.Update = nil
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		configured := d.Get("tags").(map[string]interface{})
+		if d.HasChanges("tags", "tags_all") {
+			if err := setTagsWithDefaults(d, getDefaultTags(meta)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		diags := origin(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, refreshTags(d, meta, configured, true)...)
+	}
+}
@@ -0,0 +1,263 @@
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// fakeTaggableResource returns a resource whose tags are saved in the cloudTags map, the update function diffs the
+// tags in the same way as the resources which update the tags by themselves.
+func fakeTaggableResource(cloudTags map[string]interface{}, forceNew bool) *schema.Resource {
+	tagsSchema := TagsSchema()
+	tagsSchema.ForceNew = forceNew
+
+	read := func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		tagsCopy := make(map[string]interface{}, len(cloudTags))
+		for k, v := range cloudTags {
+			tagsCopy[k] = v
+		}
+		return diag.FromErr(d.Set("tags", tagsCopy))
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": tagsSchema,
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			for k, v := range d.Get("tags").(map[string]interface{}) {
+				cloudTags[k] = v
+			}
+			d.SetId("fake-id")
+			return read(ctx, d, meta)
+		},
+		ReadContext: read,
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+
+	if !forceNew {
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if utils.HasTagsChange(d) {
+				oRaw, nRaw := utils.GetTagsChange(d)
+				for k := range oRaw.(map[string]interface{}) {
+					delete(cloudTags, k)
+				}
+				for k, v := range nRaw.(map[string]interface{}) {
+					cloudTags[k] = v
+				}
+			}
+			return read(ctx, d, meta)
+		}
+	}
+	return r
+}
+
+func applyFakeResource(t *testing.T, r *schema.Resource, state *terraform.InstanceState, cfg map[string]interface{},
+	meta interface{}) *terraform.InstanceState {
+	ctx := context.Background()
+	if state != nil {
+		var diags diag.Diagnostics
+		state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+		if diags.HasError() {
+			t.Fatalf("error refreshing the resource: %v", diags)
+		}
+	}
+
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("error planning the resource: %s", err)
+	}
+	if diff == nil || diff.Empty() {
+		return state
+	}
+
+	newState, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error applying the resource: %v", diags)
+	}
+	return newState
+}
+
+func stateTags(state *terraform.InstanceState, key string) map[string]interface{} {
+	result := make(map[string]interface{})
+	prefix := key + "."
+	for k, v := range state.Attributes {
+		if len(k) > len(prefix) && k[:len(prefix)] == prefix && k != prefix+"%" {
+			result[k[len(prefix):]] = v
+		}
+	}
+	return result
+}
+
+func checkTags(t *testing.T, name string, got, want map[string]interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("the %s is not as expected, want %v, but %v", name, want, got)
+	}
+}
+
+func TestInjectProviderTags_lifecycle(t *testing.T) {
+	cloudTags := make(map[string]interface{})
+	r := fakeTaggableResource(cloudTags, false)
+	InjectProviderTags(map[string]*schema.Resource{"fake": r})
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("the wrapped resource is invalid: %s", err)
+	}
+
+	meta := &config.Config{
+		DefaultTags: map[string]interface{}{"owner": "platform", "env": "dev"},
+		IgnoreTags:  &utils.IgnoreTagsConfig{Keys: []string{"CreatedBy"}},
+	}
+	cfg := map[string]interface{}{
+		"tags": map[string]interface{}{"env": "prod", "name": "demo"},
+	}
+
+	// the create wrapper sends the default tags to the cloud, but only the configured tags are saved to `tags`
+	state := applyFakeResource(t, r, nil, cfg, meta)
+	checkTags(t, "cloud tags after creation", cloudTags,
+		map[string]interface{}{"owner": "platform", "env": "prod", "name": "demo"})
+	checkTags(t, "tags after creation", stateTags(state, "tags"), map[string]interface{}{"env": "prod", "name": "demo"})
+	checkTags(t, "tags_all after creation", stateTags(state, "tags_all"),
+		map[string]interface{}{"owner": "platform", "env": "prod", "name": "demo"})
+
+	// the read wrapper drops the ignored tags, and the plan is empty
+	cloudTags["CreatedBy"] = "ops"
+	state = applyFakeResource(t, r, state, cfg, meta)
+	checkTags(t, "tags after refreshing", stateTags(state, "tags"), map[string]interface{}{"env": "prod", "name": "demo"})
+	if _, ok := stateTags(state, "tags_all")["CreatedBy"]; ok {
+		t.Fatalf("the ignored tag is saved to tags_all: %v", state.Attributes)
+	}
+
+	// the update wrapper deletes the default tag which is removed from the provider configuration
+	meta.DefaultTags = map[string]interface{}{"env": "dev"}
+	state = applyFakeResource(t, r, state, cfg, meta)
+	checkTags(t, "cloud tags after removing the default tag", cloudTags,
+		map[string]interface{}{"env": "prod", "name": "demo", "CreatedBy": "ops"})
+	checkTags(t, "tags_all after removing the default tag", stateTags(state, "tags_all"),
+		map[string]interface{}{"env": "prod", "name": "demo"})
+}
+
+func TestInjectProviderTags_removedDefaultWithoutRefresh(t *testing.T) {
+	cloudTags := make(map[string]interface{})
+	r := fakeTaggableResource(cloudTags, false)
+	InjectProviderTags(map[string]*schema.Resource{"fake": r})
+
+	meta := &config.Config{DefaultTags: map[string]interface{}{"owner": "platform"}}
+	cfg := map[string]interface{}{
+		"tags": map[string]interface{}{"name": "demo"},
+	}
+	state := applyFakeResource(t, r, nil, cfg, meta)
+
+	// plan and apply without refreshing, the updater has to diff against the old `tags_all`
+	meta.DefaultTags = nil
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("error planning the resource: %s", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatalf("the removal of the default tag is not planned")
+	}
+	if _, diags := r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatalf("error applying the resource: %v", diags)
+	}
+	checkTags(t, "cloud tags", cloudTags, map[string]interface{}{"name": "demo"})
+}
+
+func TestInjectProviderTags_forceNew(t *testing.T) {
+	cloudTags := make(map[string]interface{})
+	r := fakeTaggableResource(cloudTags, true)
+	InjectProviderTags(map[string]*schema.Resource{"fake": r})
+	if _, ok := r.Schema["tags_all"]; !ok {
+		t.Fatalf("the tags_all is not added to the resource whose tags are ForceNew")
+	}
+	if r.UpdateContext != nil {
+		t.Fatalf("the update function is added to the resource whose tags are ForceNew")
+	}
+
+	meta := &config.Config{DefaultTags: map[string]interface{}{"owner": "platform"}}
+	cfg := map[string]interface{}{
+		"tags": map[string]interface{}{"name": "demo"},
+	}
+	state := applyFakeResource(t, r, nil, cfg, meta)
+	checkTags(t, "cloud tags after creation", cloudTags, map[string]interface{}{"owner": "platform", "name": "demo"})
+	checkTags(t, "tags after creation", stateTags(state, "tags"), map[string]interface{}{"name": "demo"})
+
+	// the changes of the default tags do not replace the existing resource
+	meta.DefaultTags = map[string]interface{}{"env": "dev"}
+	ctx := context.Background()
+	state, diags := r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing the resource: %v", diags)
+	}
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("error planning the resource: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("the changes of the default tags are planned for the resource whose tags are ForceNew: %v", diff)
+	}
+}
+
+func TestInjectIgnoreTags(t *testing.T) {
+	cloudTags := map[string]interface{}{"CreatedBy": "ops", "finops:cost_unit": "1001", "owner": "platform"}
+	instanceSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id":   {Type: schema.TypeString, Computed: true},
+			"tags": TagsComputedSchema(),
+		},
+	}
+	ds := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": TagsComputedSchema(),
+			"instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     instanceSchema,
+			},
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			d.SetId("fake-id")
+			instances := []interface{}{
+				map[string]interface{}{"id": "instance-1", "tags": cloudTags},
+			}
+			return diag.FromErr(multierror.Append(nil,
+				d.Set("tags", cloudTags),
+				d.Set("instances", instances),
+			).ErrorOrNil())
+		},
+	}
+	InjectIgnoreTags(map[string]*schema.Resource{"fake": ds})
+
+	meta := &config.Config{
+		IgnoreTags: &utils.IgnoreTagsConfig{Keys: []string{"CreatedBy"}, KeyPrefixes: []string{"finops:"}},
+	}
+	d := ds.TestResourceData()
+	if diags := ds.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("error reading the data source: %v", diags)
+	}
+	checkTags(t, "tags", d.Get("tags").(map[string]interface{}), map[string]interface{}{"owner": "platform"})
+	checkTags(t, "tags of the instances", d.Get("instances.0.tags").(map[string]interface{}),
+		map[string]interface{}{"owner": "platform"})
+
+	// the tags are returned as they are without the ignore_tags configuration
+	d = ds.TestResourceData()
+	if diags := ds.ReadContext(context.Background(), d, &config.Config{}); diags.HasError() {
+		t.Fatalf("error reading the data source: %v", diags)
+	}
+	checkTags(t, "tags without ignore_tags", d.Get("tags").(map[string]interface{}), cloudTags)
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// InjectTracing records each create, read, update and delete operation of the resources and each read operation of
+// the data sources as a span, the API requests sent by the operation are recorded as its children.
+func InjectTracing(resources, dataSources map[string]*schema.Resource) {
+	for name, r := range resources {
+		r.CreateContext = traceOperation(name, "create", r.CreateContext, r.Create)
+		r.CreateWithoutTimeout = traceOperation(name, "create", r.CreateWithoutTimeout, nil)
+		r.ReadContext = traceOperation(name, "read", r.ReadContext, r.Read)
+		r.ReadWithoutTimeout = traceOperation(name, "read", r.ReadWithoutTimeout, nil)
+		r.UpdateContext = traceOperation(name, "update", r.UpdateContext, r.Update)
+		r.UpdateWithoutTimeout = traceOperation(name, "update", r.UpdateWithoutTimeout, nil)
+		r.DeleteContext = traceOperation(name, "delete", r.DeleteContext, r.Delete)
+		r.DeleteWithoutTimeout = traceOperation(name, "delete", r.DeleteWithoutTimeout, nil)
+		r.Create, r.Read, r.Update, r.Delete = nil, nil, nil, nil
+	}
+
+	for name, r := range dataSources {
+		r.ReadContext = traceOperation("data."+name, "read", r.ReadContext, r.Read)
+		r.ReadWithoutTimeout = traceOperation("data."+name, "read", r.ReadWithoutTimeout, nil)
+		r.Read = nil
+	}
+}
+
+// traceOperation wraps the context-aware or the legacy operation function, nil is returned if both are nil.
+// The operation is called with a copy of the config, which records the API requests as the children of the span.
+func traceOperation[T ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](name, operation string,
+	origin T, legacy func(*schema.ResourceData, interface{}) error) T {
+	if origin == nil && legacy == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		ctx, span := config.Tracer().Start(ctx, fmt.Sprintf("%s.%s", name, operation),
+			trace.WithAttributes(
+				attribute.String("terraform.resource_type", name),
+				attribute.String("terraform.operation", operation),
+				attribute.String("terraform.resource_id", d.Id()),
+			),
+		)
+		defer func() {
+			span.SetAttributes(attribute.String("terraform.resource_id", d.Id()))
+			if diags.HasError() {
+				for _, diagnostic := range diags {
+					if diagnostic.Severity == diag.Error {
+						span.SetStatus(codes.Error, diagnostic.Summary)
+						break
+					}
+				}
+			}
+			span.End()
+		}()
+
+		if cfg, ok := meta.(*config.Config); ok {
+			span.SetAttributes(config.TraceAttrRegion.String(cfg.GetRegion(d)))
+			meta = cfg.WithTraceContext(ctx)
+		}
+
+		if origin != nil {
+			return origin(ctx, d, meta)
+		}
+		return diag.FromErr(legacy(d, meta))
+	}
+}
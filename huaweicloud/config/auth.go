@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -9,6 +10,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/jmespath/go-jmespath"
@@ -18,6 +23,7 @@ import (
 	"github.com/chnsz/golangsdk/auth"
 	huaweisdk "github.com/chnsz/golangsdk/openstack"
 
+	iamv3 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3"
 	iam_model "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3/model"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/pathorcontents"
@@ -27,6 +33,11 @@ const (
 	securityKeyURL     string = "http://169.254.169.254/openstack/latest/securitykey"
 	keyExpiresDuration int64  = 600
 	assumeRoleDuration int32  = 24 * 60 * 60
+
+	// profileModeSSO is the mode of the profiles which sign in by the SSO of the CLI
+	profileModeSSO = "SSO"
+	// defaultSSOCacheDir is the directory of the credentials cached by the CLI after signing in by SSO
+	defaultSSOCacheDir = "~/.hcloud/sso/cache"
 )
 
 // CLI Shared Config
@@ -35,6 +46,8 @@ type SharedConfig struct {
 	Profiles []Profile `json:"profiles"`
 }
 
+// Profile is a profile of the CLI shared config. The credentials of the SSO profiles are read from the files cached
+// by the CLI after signing in, see getAuthConfigBySSOCache.
 type Profile struct {
 	Name             string `json:"name"`
 	Mode             string `json:"mode"`
@@ -47,10 +60,28 @@ type Profile struct {
 	AgencyDomainId   string `json:"agencyDomainId"`
 	AgencyDomainName string `json:"agencyDomainName"`
 	AgencyName       string `json:"agencyName"`
+	// CredentialProcess is the command which prints the credential in JSON, see processCredential
+	CredentialProcess string `json:"credentialProcess"`
+	// SourceProfile is the name of the profile which the unset fields are inherited from
+	SourceProfile string `json:"sourceProfile"`
+
+	// ssoProfile is the name of the SSO profile whose cached credential is used by the profile
+	ssoProfile string
+}
+
+// processCredential is the output of the credential process, the credential is fetched again before it expires
+// if the expiresAt (RFC3339) is specified.
+type processCredential struct {
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SecurityToken   string `json:"securityToken"`
+	ExpiresAt       string `json:"expiresAt"`
 }
 
 func buildClient(c *Config) error {
-	if c.Token != "" {
+	if c.AssumeRoleWithOIDC != nil {
+		return buildClientByOIDC(c)
+	} else if c.Token != "" {
 		return buildClientByToken(c)
 	} else if c.AccessKey != "" && c.SecretKey != "" {
 		return buildClientByAKSK(c)
@@ -126,8 +157,9 @@ func genClient(c *Config, ao golangsdk.AuthOptionsProvider) (*golangsdk.Provider
 
 	client.HTTPClient = http.Client{
 		Transport: &LogRoundTripper{
-			Rt:         transport,
-			MaxRetries: c.MaxRetries,
+			Rt:       transport,
+			Retry:    c.GetRetryPolicy(),
+			Cassette: c.Cassette,
 		},
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if client.AKSKAuthOptions.AccessKey != "" {
@@ -140,11 +172,6 @@ func genClient(c *Config, ao golangsdk.AuthOptionsProvider) (*golangsdk.Provider
 		},
 	}
 
-	if c.MaxRetries > 0 {
-		client.MaxBackoffRetries = uint(c.MaxRetries)
-		client.RetryBackoffFunc = retryBackoffFunc
-	}
-
 	// Validate authentication normally.
 	err = huaweisdk.Authenticate(client, ao)
 	if err != nil {
@@ -279,18 +306,33 @@ func buildClientByConfig(c *Config) error {
 	}
 
 	// fetch the current profile config
-	for _, v := range sharedConfig.Profiles {
-		if current == v.Name {
-			providerConfig = v
-			break
-		}
-	}
-	if (providerConfig == Profile{}) {
-		return fmt.Errorf("Error finding profile %s from shared config file", current)
+	providerConfig, err = resolveProfile(sharedConfig.Profiles, current, nil)
+	if err != nil {
+		return err
 	}
 
-	c.AccessKey = providerConfig.AccessKeyId
-	c.SecretKey = providerConfig.SecretAccessKey
+	switch {
+	case providerConfig.CredentialProcess != "":
+		c.credentialProcess = providerConfig.CredentialProcess
+		if err := getAuthConfigByProcess(c); err != nil {
+			return fmt.Errorf("Error fetching Auth credentials by the credential process: %s", err)
+		}
+	case providerConfig.ssoProfile != "":
+		c.ssoCacheFile, err = getSSOCacheFile(providerConfig.ssoProfile)
+		if err != nil {
+			return err
+		}
+		if err := getAuthConfigBySSOCache(c); err != nil {
+			return fmt.Errorf("Error fetching Auth credentials of the SSO profile %s: %s", providerConfig.ssoProfile,
+				err)
+		}
+	default:
+		c.AccessKey = providerConfig.AccessKeyId
+		c.SecretKey = providerConfig.SecretAccessKey
+		if providerConfig.SecurityToken != "" {
+			c.SecurityToken = providerConfig.SecurityToken
+		}
+	}
 	// non required fields
 	if providerConfig.Region != "" {
 		c.Region = providerConfig.Region
@@ -312,6 +354,132 @@ func buildClientByConfig(c *Config) error {
 	return buildClientByAKSK(c)
 }
 
+// resolveProfile returns the profile whose unset fields are inherited from the source profiles. The credential,
+// which is either the AK/SK or the credential process, is inherited as a whole.
+func resolveProfile(profiles []Profile, name string, visited []string) (Profile, error) {
+	for _, v := range visited {
+		if v == name {
+			return Profile{}, fmt.Errorf("Error resolving profile %s: source profiles are circular: %s",
+				visited[0], strings.Join(append(visited, name), " -> "))
+		}
+	}
+
+	var profile Profile
+	for _, v := range profiles {
+		if name == v.Name {
+			profile = v
+			break
+		}
+	}
+	if (profile == Profile{}) {
+		return Profile{}, fmt.Errorf("Error finding profile %s from shared config file", name)
+	}
+	if strings.EqualFold(profile.Mode, profileModeSSO) && profile.AccessKeyId == "" && profile.CredentialProcess == "" {
+		profile.ssoProfile = name
+	}
+	if profile.SourceProfile == "" {
+		return profile, nil
+	}
+
+	source, err := resolveProfile(profiles, profile.SourceProfile, append(visited, name))
+	if err != nil {
+		return Profile{}, err
+	}
+	if profile.AccessKeyId == "" && profile.CredentialProcess == "" && profile.ssoProfile == "" {
+		profile.AccessKeyId = source.AccessKeyId
+		profile.SecretAccessKey = source.SecretAccessKey
+		profile.SecurityToken = source.SecurityToken
+		profile.CredentialProcess = source.CredentialProcess
+		profile.ssoProfile = source.ssoProfile
+	}
+	if profile.Mode == "" {
+		profile.Mode = source.Mode
+	}
+	if profile.Region == "" {
+		profile.Region = source.Region
+	}
+	if profile.ProjectId == "" {
+		profile.ProjectId = source.ProjectId
+	}
+	if profile.DomainId == "" {
+		profile.DomainId = source.DomainId
+	}
+	return profile, nil
+}
+
+// getAuthConfigByProcess runs the credential process of the shared config file and parses the credential.
+func getAuthConfigByProcess(c *Config) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", c.credentialProcess)
+	} else {
+		cmd = exec.Command("sh", "-c", c.credentialProcess)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Error running the credential process: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return setProcessCredential(c, stdout.Bytes(), "the output of the credential process")
+}
+
+// getSSOCacheFile returns the file of the credential cached by the CLI after the SSO profile signs in, the directory
+// can be specified by the HW_SSO_CACHE_DIR environment variable.
+func getSSOCacheFile(profile string) (string, error) {
+	dir := os.Getenv("HW_SSO_CACHE_DIR")
+	if dir == "" {
+		dir = defaultSSOCacheDir
+	}
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, profile+".json"), nil
+}
+
+// getAuthConfigBySSOCache reads the credential of the SSO profile from the cache file of the CLI, the content is in
+// the same format as the output of the credential process. The file is read again before the credential expires, so
+// the credential refreshed by signing in again is picked up.
+func getAuthConfigBySSOCache(c *Config) error {
+	data, err := os.ReadFile(c.ssoCacheFile)
+	if err != nil {
+		return fmt.Errorf("Error reading the SSO cache, please sign in by the CLI first: %s", err)
+	}
+	if err := setProcessCredential(c, data, "the SSO cache "+c.ssoCacheFile); err != nil {
+		return err
+	}
+	if !c.SecurityKeyExpiresAt.IsZero() && time.Now().After(c.SecurityKeyExpiresAt) {
+		return fmt.Errorf("the credential cached in %s expired at %s, please sign in by the CLI again",
+			c.ssoCacheFile, c.SecurityKeyExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// setProcessCredential parses the credential in the format of processCredential and saves it to the config.
+func setProcessCredential(c *Config, data []byte, source string) error {
+	var credential processCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return fmt.Errorf("Error parsing %s: %s", source, err)
+	}
+	if credential.AccessKeyId == "" || credential.SecretAccessKey == "" {
+		return fmt.Errorf("accessKeyId and secretAccessKey are missing in %s", source)
+	}
+
+	var expiresAt time.Time
+	if credential.ExpiresAt != "" {
+		parsed, err := time.Parse(time.RFC3339, credential.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("Error parsing the expiresAt of %s: %s", source, err)
+		}
+		expiresAt = parsed
+	}
+	c.AccessKey, c.SecretKey, c.SecurityToken, c.SecurityKeyExpiresAt = credential.AccessKeyId,
+		credential.SecretAccessKey, credential.SecurityToken, expiresAt
+
+	return nil
+}
+
 func buildClientByPassword(c *Config) error {
 	var projectAuthOptions, domainAuthOptions golangsdk.AuthOptions
 
@@ -353,49 +521,211 @@ func buildClientByPassword(c *Config) error {
 	return genClients(c, projectAuthOptions, domainAuthOptions)
 }
 
+// AssumeRole is an agency which is assumed to get the temporary credential.
+type AssumeRole struct {
+	AgencyName string
+	DomainName string
+	// Duration is the validity period of the temporary credential, in seconds, defaults to 24 hours
+	Duration int32
+	// Policy is the JSON of an inline policy which scopes down the permissions of the temporary credential
+	Policy string
+	// PolicyIDs are the IDs of the custom policies which scope down the permissions of the temporary credential
+	PolicyIDs       []string
+	SessionUserName string
+}
+
+// sourceCredential is the credential which assumes the first agency.
+type sourceCredential struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+	// ExpiresAt is not zero if the credential is short-lived, e.g. a metadata security key or an OIDC credential
+	ExpiresAt time.Time
+}
+
+type agencyAuthRequest struct {
+	Body *agencyAuthRequestBody `json:"body,omitempty"`
+}
+
+type agencyAuthRequestBody struct {
+	Auth *agencyAuth `json:"auth"`
+}
+
+type agencyAuth struct {
+	Identity *agencyAuthIdentity `json:"identity"`
+}
+
+type agencyAuthIdentity struct {
+	Methods    []iam_model.AgencyAuthIdentityMethods `json:"methods"`
+	AssumeRole *iam_model.IdentityAssumerole         `json:"assume_role"`
+	Policy     interface{}                           `json:"policy,omitempty"`
+	PolicyIDs  []string                              `json:"policy_ids,omitempty"`
+}
+
+// getAssumeRoles returns the agencies to be assumed in order, the agency and domain specified by the legacy
+// fields or the shared config file is assumed if no assume_role blocks are specified.
+func (c *Config) getAssumeRoles() []AssumeRole {
+	if len(c.AssumeRoles) > 0 {
+		return c.AssumeRoles
+	}
+	if c.AssumeRoleAgency != "" {
+		return []AssumeRole{{AgencyName: c.AssumeRoleAgency, DomainName: c.AssumeRoleDomain}}
+	}
+	return nil
+}
+
+// buildClientByAgency assumes the agencies in order, each agency is assumed with the temporary credential of the
+// previous one. The source credential is saved, so that the agencies can be assumed again when the temporary
+// credential is about to expire.
 func buildClientByAgency(c *Config) error {
-	client, err := c.HcIamV3Client(c.Region)
+	c.assumeRoleSource = &sourceCredential{
+		AccessKey:     c.AccessKey,
+		SecretKey:     c.SecretKey,
+		SecurityToken: c.SecurityToken,
+		ExpiresAt:     c.SecurityKeyExpiresAt,
+	}
+
+	for i, role := range c.getAssumeRoles() {
+		credential, err := assumeAgency(c, role, i == 0)
+		if err != nil {
+			return err
+		}
+
+		c.AccessKey, c.SecretKey, c.SecurityToken = credential.Access, credential.Secret, credential.Securitytoken
+		expiresAt, err := time.Parse(time.RFC3339, credential.ExpiresAt)
+		if err != nil {
+			log.Printf("[WARN] failed to parse the expiration time of the agency credential %q: %s", credential.ExpiresAt, err)
+			expiresAt = time.Time{}
+		}
+		c.SecurityKeyExpiresAt = expiresAt
+		log.Printf("[DEBUG] Successfully assumed agency %s of domain %s, the credential will expire at: %s",
+			role.AgencyName, role.DomainName, c.SecurityKeyExpiresAt)
+	}
+
+	return buildClientByAKSK(c)
+}
+
+// assumeAgency gets the temporary credential of the agency with the current credential of the config.
+// The domain ID of the config only belongs to the source credential, so it's ignored by the subsequent agencies.
+func assumeAgency(c *Config, role AssumeRole, isFirst bool) (*iam_model.Credential, error) {
+	iamConfig := *c
+	if !isFirst {
+		iamConfig.DomainID = ""
+	}
+	client, err := iamConfig.HcIamV3Client(c.Region)
 	if err != nil {
-		return fmt.Errorf("Error creating Huaweicloud IAM client: %s", err)
+		return nil, fmt.Errorf("Error creating Huaweicloud IAM client: %s", err)
 	}
 
-	request := &iam_model.CreateTemporaryAccessKeyByAgencyRequest{}
-	domainNameAssumeRoleIdentityAssumerole := c.AssumeRoleDomain
-	durationSecondsAssumeRoleIdentityAssumerole := assumeRoleDuration
-	assumeRoleIdentity := &iam_model.IdentityAssumerole{
-		AgencyName:      c.AssumeRoleAgency,
-		DomainName:      &domainNameAssumeRoleIdentityAssumerole,
-		DurationSeconds: &durationSecondsAssumeRoleIdentityAssumerole,
+	duration := assumeRoleDuration
+	if role.Duration > 0 {
+		duration = role.Duration
 	}
-	var listMethodsIdentity = []iam_model.AgencyAuthIdentityMethods{
-		iam_model.GetAgencyAuthIdentityMethodsEnum().ASSUME_ROLE,
+	domainName := role.DomainName
+	assumeRoleIdentity := &iam_model.IdentityAssumerole{
+		AgencyName:      role.AgencyName,
+		DomainName:      &domainName,
+		DurationSeconds: &duration,
+	}
+	if role.SessionUserName != "" {
+		sessionUserName := role.SessionUserName
+		assumeRoleIdentity.SessionUser = &iam_model.AssumeroleSessionuser{
+			Name: &sessionUserName,
+		}
 	}
-	identityAuth := &iam_model.AgencyAuthIdentity{
-		Methods:    listMethodsIdentity,
+
+	identity := &agencyAuthIdentity{
+		Methods: []iam_model.AgencyAuthIdentityMethods{
+			iam_model.GetAgencyAuthIdentityMethodsEnum().ASSUME_ROLE,
+		},
 		AssumeRole: assumeRoleIdentity,
+		PolicyIDs:  role.PolicyIDs,
 	}
-	authbody := &iam_model.AgencyAuth{
-		Identity: identityAuth,
+	if role.Policy != "" {
+		if err := json.Unmarshal([]byte(role.Policy), &identity.Policy); err != nil {
+			return nil, fmt.Errorf("Error parsing the policy of agency %s: %s", role.AgencyName, err)
+		}
 	}
-	request.Body = &iam_model.CreateTemporaryAccessKeyByAgencyRequestBody{
-		Auth: authbody,
+
+	// the SDK model has no policy_ids, so the request is sent with the request definition of the SDK
+	request := &agencyAuthRequest{
+		Body: &agencyAuthRequestBody{
+			Auth: &agencyAuth{
+				Identity: identity,
+			},
+		},
 	}
-	response, err := client.CreateTemporaryAccessKeyByAgency(request)
+	resp, err := client.HcClient.Sync(request, iamv3.GenReqDefForCreateTemporaryAccessKeyByAgency())
 	if err != nil {
-		return fmt.Errorf("Error Creating temporary accesskey by agency: %s", err)
+		return nil, fmt.Errorf("Error Creating temporary accesskey by agency %s: %s", role.AgencyName, err)
+	}
+	response := resp.(*iam_model.CreateTemporaryAccessKeyByAgencyResponse)
+	if response.Credential == nil {
+		return nil, fmt.Errorf("Error Creating temporary accesskey by agency %s: the credential is not found", role.AgencyName)
+	}
+	return response.Credential, nil
+}
+
+func (c *Config) reloadSecurityKey() error {
+	if c.assumeRoleSource != nil {
+		return c.reloadAgencySecurityKey()
 	}
-	c.AccessKey, c.SecretKey, c.SecurityToken = response.Credential.Access, response.Credential.Secret, response.Credential.Securitytoken
 
+	if err := c.reloadSourceCredential(); err != nil {
+		return err
+	}
+	log.Printf("Successfully reload security key, which will expire at: %s", c.SecurityKeyExpiresAt)
 	return buildClientByAKSK(c)
 }
 
-func (c *Config) reloadSecurityKey() error {
-	err := getAuthConfigByMeta(c)
-	if err != nil {
+// reloadSourceCredential fetches the short-lived credential again by the OIDC token, the credential process, the SSO
+// cache or the ECS metadata API.
+func (c *Config) reloadSourceCredential() error {
+	if c.AssumeRoleWithOIDC != nil {
+		if err := getAuthConfigByOIDC(c); err != nil {
+			return fmt.Errorf("Error reloading Auth credentials by the OIDC token: %s", err)
+		}
+		return nil
+	}
+
+	if c.credentialProcess != "" {
+		if err := getAuthConfigByProcess(c); err != nil {
+			return fmt.Errorf("Error reloading Auth credentials by the credential process: %s", err)
+		}
+		return nil
+	}
+
+	if c.ssoCacheFile != "" {
+		if err := getAuthConfigBySSOCache(c); err != nil {
+			return fmt.Errorf("Error reloading Auth credentials from the SSO cache: %s", err)
+		}
+		return nil
+	}
+
+	if err := getAuthConfigByMeta(c); err != nil {
 		return fmt.Errorf("Error reloading Auth credentials from ECS Metadata API: %s", err)
 	}
-	log.Printf("Successfully reload metadata security key, which will expire at: %s", c.SecurityKeyExpiresAt)
-	return buildClientByAKSK(c)
+	return nil
+}
+
+// reloadAgencySecurityKey assumes the agencies again with the source credential, which is reloaded first if it's
+// short-lived and is about to expire.
+func (c *Config) reloadAgencySecurityKey() error {
+	source := c.assumeRoleSource
+	c.AccessKey, c.SecretKey, c.SecurityToken = source.AccessKey, source.SecretKey, source.SecurityToken
+	c.SecurityKeyExpiresAt = source.ExpiresAt
+
+	if !source.ExpiresAt.IsZero() && time.Now().Unix()+keyExpiresDuration > source.ExpiresAt.Unix() {
+		if err := c.reloadSourceCredential(); err != nil {
+			return err
+		}
+	}
+
+	if err := buildClientByAgency(c); err != nil {
+		return fmt.Errorf("Error reloading Auth credentials by agency: %s", err)
+	}
+	log.Printf("Successfully reload agency security key, which will expire at: %s", c.SecurityKeyExpiresAt)
+	return nil
 }
 
 func getAuthConfigByMeta(c *Config) error {
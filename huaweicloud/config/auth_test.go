@@ -0,0 +1,171 @@
+package config_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestAssumeRoleChain(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t, func(c *config.Config) {
+		c.AssumeRoles = []config.AssumeRole{
+			{AgencyName: "hub", DomainName: "hub-account"},
+			{
+				AgencyName:      "workload",
+				DomainName:      "workload-account",
+				Duration:        3600,
+				Policy:          `{"Version":"1.1","Statement":[{"Effect":"Allow","Action":["obs:*:*"]}]}`,
+				PolicyIDs:       []string{"policy-id"},
+				SessionUserName: "ci-session",
+			},
+		}
+	})
+
+	// the workload agency is assumed with the credential of the hub agency
+	hub, ok := server.Object(fakecloud.KindSecurityToken, "ak-hub-1")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, fakecloud.AccessKey, hub["signed_by"])
+	th.AssertEquals(t, float64(86400), hub["duration_seconds"])
+
+	workload, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "workload-account", workload["domain_name"])
+	th.AssertEquals(t, "ak-hub-1", workload["signed_by"])
+	th.AssertEquals(t, float64(3600), workload["duration_seconds"])
+	th.AssertEquals(t, "ci-session", workload["session_user_name"])
+	th.AssertEquals(t, "policy-id", workload["policy_ids"].([]interface{})[0])
+	th.AssertEquals(t, "1.1", workload["policy"].(map[string]interface{})["Version"])
+	th.AssertEquals(t, false, cfg.SecurityKeyExpiresAt.IsZero())
+
+	// the agencies are assumed again with the source credential when the credential is about to expire
+	cfg.SecurityKeyExpiresAt = time.Now().Add(time.Minute)
+	_, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 4, server.Count(fakecloud.KindSecurityToken))
+	th.AssertEquals(t, true, cfg.SecurityKeyExpiresAt.After(time.Now().Add(30*time.Minute)))
+
+	refreshed, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "workload", refreshed["agency_name"])
+	hub, ok = server.Object(fakecloud.KindSecurityToken, refreshed["signed_by"].(string))
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, fakecloud.AccessKey, hub["signed_by"])
+}
+
+func TestSharedConfigCredentialProcess(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	dir := t.TempDir()
+	credentialFile := filepath.Join(dir, "credential.json")
+	writeCredential := func(accessKey string, expiresIn time.Duration) {
+		content := fmt.Sprintf(`{"accessKeyId":"%s","secretAccessKey":"secret","securityToken":"token","expiresAt":"%s"}`,
+			accessKey, time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+		th.AssertNoErr(t, os.WriteFile(credentialFile, []byte(content), 0600))
+	}
+	writeCredential("PROCESSACCESSKEY1", 5*time.Minute)
+
+	sharedConfig := fmt.Sprintf(`{
+  "current": "workload",
+  "profiles": [
+    {"name": "vault", "mode": "AKSK", "credentialProcess": "cat %s"},
+    {"name": "workload", "sourceProfile": "vault", "agencyName": "workload", "agencyDomainName": "workload-account"},
+    {"name": "loop-a", "sourceProfile": "loop-b"},
+    {"name": "loop-b", "sourceProfile": "loop-a"}
+  ]
+}`, credentialFile)
+	sharedConfigFile := filepath.Join(dir, "config.json")
+	th.AssertNoErr(t, os.WriteFile(sharedConfigFile, []byte(sharedConfig), 0600))
+
+	// the agency of the workload profile is assumed with the credential of the source profile
+	cfg := server.Config(t, func(c *config.Config) {
+		c.AccessKey, c.SecretKey = "", ""
+		c.SharedConfigFile = sharedConfigFile
+	})
+	workload, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "workload", workload["agency_name"])
+	th.AssertEquals(t, "PROCESSACCESSKEY1", workload["signed_by"])
+
+	// the credential process is run again since the source credential is about to expire
+	writeCredential("PROCESSACCESSKEY2", time.Hour)
+	cfg.SecurityKeyExpiresAt = time.Now()
+	_, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	workload, ok = server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "PROCESSACCESSKEY2", workload["signed_by"])
+
+	circular := &config.Config{SharedConfigFile: sharedConfigFile, Profile: "loop-a", Region: fakecloud.Region}
+	err = circular.LoadAndValidate()
+	th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "loop-a -> loop-b -> loop-a"))
+}
+
+func TestSharedConfigSSOProfile(t *testing.T) {
+	t.Setenv("HW_SSO_CACHE_DIR", t.TempDir())
+	sharedConfigFile := filepath.Join(t.TempDir(), "config.json")
+	th.AssertNoErr(t, os.WriteFile(sharedConfigFile, []byte(`{
+  "current": "sso",
+  "profiles": [
+    {"name": "sso", "mode": "SSO", "region": "cn-north-4"}
+  ]
+}`), 0600))
+
+	cfg := &config.Config{SharedConfigFile: sharedConfigFile, Region: fakecloud.Region}
+	err := cfg.LoadAndValidate()
+	// the profile has not signed in by the CLI
+	th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "please sign in by the CLI first"))
+}
+
+func TestSharedConfigSSOCache(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cacheDir := t.TempDir()
+	t.Setenv("HW_SSO_CACHE_DIR", cacheDir)
+	writeCache := func(accessKey string, expiresIn time.Duration) {
+		content := fmt.Sprintf(`{"accessKeyId":"%s","secretAccessKey":"secret","securityToken":"token","expiresAt":"%s"}`,
+			accessKey, time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+		th.AssertNoErr(t, os.WriteFile(filepath.Join(cacheDir, "sso-login.json"), []byte(content), 0600))
+	}
+	writeCache("SSOACCESSKEY1", 5*time.Minute)
+
+	sharedConfig := `{
+  "current": "workload",
+  "profiles": [
+    {"name": "sso-login", "mode": "SSO"},
+    {"name": "workload", "sourceProfile": "sso-login", "agencyName": "workload", "agencyDomainName": "workload-account"}
+  ]
+}`
+	sharedConfigFile := filepath.Join(t.TempDir(), "config.json")
+	th.AssertNoErr(t, os.WriteFile(sharedConfigFile, []byte(sharedConfig), 0600))
+
+	// the agency of the workload profile is assumed with the credential cached for the SSO profile
+	cfg := server.Config(t, func(c *config.Config) {
+		c.AccessKey, c.SecretKey = "", ""
+		c.SharedConfigFile = sharedConfigFile
+	})
+	workload, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "SSOACCESSKEY1", workload["signed_by"])
+
+	// the cache is read again since the SSO credential is about to expire
+	writeCache("SSOACCESSKEY2", time.Hour)
+	cfg.SecurityKeyExpiresAt = time.Now()
+	_, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	workload, ok = server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "SSOACCESSKEY2", workload["signed_by"])
+
+	// the expired credential must be refreshed by signing in by the CLI again
+	writeCache("SSOACCESSKEY3", -time.Minute)
+	expired := &config.Config{SharedConfigFile: sharedConfigFile, Profile: "sso-login", Region: fakecloud.Region}
+	err = expired.LoadAndValidate()
+	th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "please sign in by the CLI again"))
+}
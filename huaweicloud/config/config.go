@@ -1,10 +1,8 @@
 package config
 
 import (
-	"context"
 	"fmt"
 	"log"
-	"math"
 	"os"
 	"strings"
 	"sync"
@@ -12,6 +10,7 @@ import (
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/identity/v3/domains"
@@ -20,6 +19,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/obs"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/mutexkv"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const (
@@ -48,15 +48,37 @@ type Config struct {
 	SecurityToken       string
 	AssumeRoleAgency    string
 	AssumeRoleDomain    string
+	AssumeRoles         []AssumeRole
+	AssumeRoleWithOIDC  *AssumeRoleWithOIDC
 	Cloud               string
 	MaxRetries          int
+	RetryPolicy         *RetryPolicy
+	RateLimits          map[string]int
+	CassetteName        string
+	Cassette            *Cassette
 	TerraformVersion    string
 	RegionClient        bool
 	EnterpriseProjectID string
 	SharedConfigFile    string
 	Profile             string
 
-	// metadata security key expires at
+	// rateLimiters saves the token-bucket limiters of the services, it's initialized by LoadAndValidate
+	rateLimiters *sync.Map
+	// listCache saves the lists which are queried repeatedly when planning, e.g. the flavors, it's initialized by
+	// LoadAndValidate
+	listCache *sync.Map
+	// traceParent is the span of the operation which uses the config, it's set by WithTraceContext
+	traceParent trace.SpanContext
+	// origin is the config which WithTraceContext derives from, it owns the security key and reloads it
+	origin *Config
+	// credentialProcess is the command of the shared config file which prints the credential
+	credentialProcess string
+	// ssoCacheFile is the file which caches the credential of the SSO profile of the shared config file
+	ssoCacheFile string
+	// assumeRoleSource is the credential which assumes the first agency, it's used to refresh the agency credential
+	assumeRoleSource *sourceCredential
+
+	// metadata, OIDC or agency security key expires at
 	SecurityKeyExpiresAt time.Time
 
 	HwClient     *golangsdk.ProviderClient
@@ -65,6 +87,12 @@ type Config struct {
 	// the custom endpoints used to override the default endpoint URL
 	Endpoints map[string]string
 
+	// DefaultTags is the provider-level tags which will be merged into the tags of all taggable resources
+	DefaultTags map[string]interface{}
+
+	// IgnoreTags is the provider-level configuration of the tags which will not be saved to the state
+	IgnoreTags *utils.IgnoreTagsConfig
+
 	// RegionProjectIDMap is a map which stores the region-projectId pairs,
 	// and region name will be the key and projectID will be the value in this map.
 	RegionProjectIDMap map[string]string
@@ -93,8 +121,22 @@ func (c *Config) LoadAndValidate() error {
 	if c.MaxRetries < 0 {
 		return fmt.Errorf("max_retries should be a positive value")
 	}
+	if err := c.RetryPolicy.Validate(); err != nil {
+		return err
+	}
+	if err := validateRateLimits(c.RateLimits); err != nil {
+		return err
+	}
+	c.rateLimiters = new(sync.Map)
+	c.listCache = new(sync.Map)
 
-	err := buildClient(c)
+	cassette, err := LoadCassette(c.CassetteName)
+	if err != nil {
+		return err
+	}
+	c.Cassette = cassette
+
+	err = buildClient(c)
 	if err != nil {
 		return err
 	}
@@ -104,7 +146,7 @@ func (c *Config) LoadAndValidate() error {
 	}
 
 	// Assume role
-	if c.AssumeRoleAgency != "" {
+	if c.AssumeRoleAgency != "" || len(c.AssumeRoles) > 0 {
 		err = buildClientByAgency(c)
 		if err != nil {
 			return err
@@ -141,27 +183,22 @@ func (c *Config) LoadAndValidate() error {
 	return nil
 }
 
-func retryBackoffFunc(ctx context.Context, respErr *golangsdk.ErrUnexpectedResponseCode, e error, retries uint) error {
-	minutes := int(math.Pow(2, float64(retries)))
-	if minutes > 30 { // won't wait more than 30 minutes
-		minutes = 30
+// LoadOrStoreList returns the cached list of the key, or queries and caches it by the list function. The lists live
+// as long as the provider, so only the lists which hardly change are cached, and the errors are not cached.
+func (c *Config) LoadOrStoreList(key string, list func() (interface{}, error)) (interface{}, error) {
+	if c.listCache == nil {
+		return list()
 	}
-
-	log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %d minutes", minutes)
-	sleep := time.Duration(minutes) * time.Minute
-
-	if ctx != nil {
-		select {
-		case <-time.After(sleep):
-		case <-ctx.Done():
-			return e
-		}
-	} else {
-		//lintignore:R018
-		time.Sleep(sleep)
+	if v, ok := c.listCache.Load(key); ok {
+		return v, nil
 	}
 
-	return nil
+	v, err := list()
+	if err != nil {
+		return nil, err
+	}
+	c.listCache.Store(key, v)
+	return v, nil
 }
 
 func getObsEndpoint(c *Config, region string) string {
@@ -203,13 +240,8 @@ func (c *Config) ObjectStorageClient(region string) (*obs.ObsClient, error) {
 	if !c.SecurityKeyExpiresAt.IsZero() {
 		c.SecurityKeyLock.Lock()
 		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			err := c.reloadSecurityKey()
-			if err != nil {
-				return nil, err
-			}
+		if err := c.refreshSecurityKey(); err != nil {
+			return nil, err
 		}
 	}
 
@@ -224,6 +256,26 @@ func (c *Config) ObjectStorageClient(region string) (*obs.ObsClient, error) {
 	return obs.New(c.AccessKey, c.SecretKey, obsEndpoint, clientConfigure, userAgentConfigure, envProxyConfigure)
 }
 
+// refreshSecurityKey reloads the security key if it's about to expire, the caller must hold the SecurityKeyLock.
+// The configs derived by WithTraceContext take over the key reloaded by the origin config, so that the key is reloaded
+// only once and shared by all operations.
+func (c *Config) refreshSecurityKey() error {
+	if c.origin == nil {
+		if time.Now().Unix()+keyExpiresDuration > c.SecurityKeyExpiresAt.Unix() {
+			return c.reloadSecurityKey()
+		}
+		return nil
+	}
+
+	if err := c.origin.refreshSecurityKey(); err != nil {
+		return err
+	}
+	c.AccessKey, c.SecretKey, c.SecurityToken = c.origin.AccessKey, c.origin.SecretKey, c.origin.SecurityToken
+	c.SecurityKeyExpiresAt = c.origin.SecurityKeyExpiresAt
+	c.HwClient, c.DomainClient = c.origin.HwClient, c.origin.DomainClient
+	return nil
+}
+
 func buildObsUserAgent() string {
 	var agent string = providerUserAgent
 	if customUserAgent := os.Getenv("HW_TF_CUSTOM_UA"); customUserAgent != "" {
@@ -245,13 +297,8 @@ func (c *Config) NewServiceClient(srv, region string) (*golangsdk.ServiceClient,
 	if !c.SecurityKeyExpiresAt.IsZero() {
 		c.SecurityKeyLock.Lock()
 		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			err := c.reloadSecurityKey()
-			if err != nil {
-				return nil, err
-			}
+		if err := c.refreshSecurityKey(); err != nil {
+			return nil, err
 		}
 	}
 
@@ -298,6 +345,7 @@ func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalo
 	clone.ProjectID = projectID
 	clone.AKSKAuthOptions.ProjectId = projectID
 	clone.AKSKAuthOptions.Region = region
+	clone.HTTPClient.Transport = c.withTracing(c.withRateLimit(clone.HTTPClient.Transport, catalog, region), catalog.Name, region)
 
 	sc := &golangsdk.ServiceClient{
 		ProviderClient: clone,
@@ -331,8 +379,12 @@ func (c *Config) newServiceClientByEndpoint(client *golangsdk.ProviderClient, sr
 		return nil, fmt.Errorf("service type %s is invalid or not supportted", srv)
 	}
 
+	clone := new(golangsdk.ProviderClient)
+	*clone = *client
+	clone.HTTPClient.Transport = c.withTracing(c.withRateLimit(clone.HTTPClient.Transport, catalog, c.Region), catalog.Name, c.Region)
+
 	sc := &golangsdk.ServiceClient{
-		ProviderClient: client,
+		ProviderClient: clone,
 		Endpoint:       endpoint,
 	}
 
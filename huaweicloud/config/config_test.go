@@ -1,13 +1,27 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
 	th "github.com/chnsz/golangsdk/testhelper"
 )
 
@@ -80,3 +94,425 @@ func TestCheckObsEndpoint(t *testing.T) {
 	expected = "https://oss.region-1.myhuaweicloud.com/"
 	th.AssertEquals(t, expected, getObsEndpoint(cfg, "region-1"))
 }
+
+func TestRequestRetryWithPolicy(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var attempts int
+	th.Mux.HandleFunc("/throttled", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"error_code": "APIGW.0308", "error_msg": "The throttling threshold has been reached"}`)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}
+	})
+
+	policy := NewRetryPolicy(3)
+	policy.MinBackoff = 0
+	client := http.Client{
+		Transport: &LogRoundTripper{
+			Rt:    http.DefaultTransport,
+			Retry: policy,
+		},
+	}
+
+	resp, err := client.Post(th.Endpoint()+"throttled", "text/plain", strings.NewReader("retried"))
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	th.AssertEquals(t, "retried", string(body))
+	th.AssertEquals(t, 3, attempts)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+
+	th.AssertEquals(t, time.Second, policy.Backoff(1, nil))
+	th.AssertEquals(t, 4*time.Second, policy.Backoff(3, nil))
+	th.AssertEquals(t, 5*time.Second, policy.Backoff(10, nil))
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "20")
+	th.AssertEquals(t, 20*time.Second, policy.Backoff(1, resp))
+
+	resp.Header.Set("Retry-After", "invalid")
+	th.AssertEquals(t, 2*time.Second, policy.Backoff(2, resp))
+
+	policy.Jitter = true
+	for i := 0; i < 10; i++ {
+		wait := policy.Backoff(3, nil)
+		th.AssertEquals(t, true, wait >= 2*time.Second && wait <= 4*time.Second)
+	}
+}
+
+func TestParseErrorCode(t *testing.T) {
+	th.AssertEquals(t, "CBC.0150", ParseErrorCode([]byte(`{"error_code": "CBC.0150"}`)))
+	th.AssertEquals(t, "APIGW.0308", ParseErrorCode([]byte(`{"error": {"code": "APIGW.0308"}}`)))
+	th.AssertEquals(t, "", ParseErrorCode([]byte(`<html></html>`)))
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	bucket := newTokenBucket(10)
+	start := time.Now()
+	// the first 10 tokens are available immediately, and the next 5 tokens are refilled in about 0.5 seconds
+	for i := 0; i < 15; i++ {
+		th.AssertNoErr(t, bucket.Wait(context.Background()))
+	}
+	elapsed := time.Since(start)
+	th.AssertEquals(t, true, elapsed >= 400*time.Millisecond && elapsed < 2*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bucket.tokens, bucket.last = 0, time.Now()
+	th.AssertEquals(t, context.Canceled, bucket.Wait(ctx))
+	// the token is returned if the waiting is canceled
+	th.AssertEquals(t, true, bucket.tokens > -1)
+}
+
+func TestLoadOrStoreList(t *testing.T) {
+	cfg := &Config{listCache: new(sync.Map)}
+	calls := 0
+	list := func() (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("list failed")
+		}
+		return []string{"s6.small.1"}, nil
+	}
+
+	// the errors are not cached, so the list is queried again
+	_, err := cfg.LoadOrStoreList("ecs_flavors/region-0/", list)
+	th.AssertEquals(t, true, err != nil)
+	for i := 0; i < 2; i++ {
+		v, err := cfg.LoadOrStoreList("ecs_flavors/region-0/", list)
+		th.AssertNoErr(t, err)
+		th.AssertDeepEquals(t, []string{"s6.small.1"}, v)
+	}
+	th.AssertEquals(t, 2, calls)
+
+	_, err = cfg.LoadOrStoreList("ecs_flavors/region-1/", list)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, calls)
+}
+
+func TestServiceClientRateLimit(t *testing.T) {
+	cfg := &Config{
+		Region:       "region-0",
+		Cloud:        "myhuaweicloud.com",
+		RateLimits:   map[string]int{"ecs": 20, "iam": 0},
+		rateLimiters: new(sync.Map),
+		RPLock:       new(sync.Mutex),
+		RegionProjectIDMap: map[string]string{
+			"region-0": "project-0",
+			"region-1": "project-1",
+		},
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+	}
+	client := &golangsdk.ProviderClient{}
+
+	getLimiter := func(srv, region string) *tokenBucket {
+		sc, err := cfg.newServiceClientByName(client, allServiceCatalog[srv], region)
+		th.AssertNoErr(t, err)
+		if rt, ok := sc.HTTPClient.Transport.(*RateLimitRoundTripper); ok {
+			return rt.limiter
+		}
+		return nil
+	}
+
+	// the clients of the same service in the same region share the limiter
+	ecsLimiter := getLimiter("ecs", "region-0")
+	th.AssertEquals(t, true, ecsLimiter != nil)
+	th.AssertEquals(t, float64(20), ecsLimiter.rate)
+	th.AssertEquals(t, ecsLimiter, getLimiter("ecs", "region-0"))
+	th.AssertEquals(t, true, ecsLimiter != getLimiter("ecs", "region-1"))
+	// the default limit is used if not specified
+	th.AssertEquals(t, float64(DefaultRateLimits["evs"]), getLimiter("evs", "region-0").rate)
+	// the limit is disabled by 0
+	th.AssertEquals(t, true, getLimiter("iam", "region-0") == nil)
+	// the origin client is not changed
+	th.AssertEquals(t, nil, client.HTTPClient.Transport)
+
+	// the limiter is placed inside the LogRoundTripper, so that the retries are limited as well
+	client.HTTPClient.Transport = &LogRoundTripper{Rt: http.DefaultTransport}
+	sc, err := cfg.newServiceClientByName(client, allServiceCatalog["ecs"], "region-0")
+	th.AssertNoErr(t, err)
+	lrt, ok := sc.HTTPClient.Transport.(*LogRoundTripper)
+	th.AssertEquals(t, true, ok)
+	rt, ok := lrt.Rt.(*RateLimitRoundTripper)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, ecsLimiter, rt.limiter)
+	th.AssertEquals(t, http.DefaultTransport, client.HTTPClient.Transport.(*LogRoundTripper).Rt)
+
+	// the clients of huaweicloud-sdk-go-v3 share the same limiter
+	retryRt := cfg.newRetryRoundTripper(http.DefaultTransport, "ecs", "region-0")
+	rt, ok = retryRt.Rt.(*RateLimitRoundTripper)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, ecsLimiter, rt.limiter)
+
+	th.AssertEquals(t, true, validateRateLimits(map[string]int{"ecs": 10, "iam": 0}) == nil)
+	th.AssertEquals(t, true, validateRateLimits(map[string]int{"unknown": 10}) != nil)
+	th.AssertEquals(t, true, validateRateLimits(map[string]int{"ecs": -1}) != nil)
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	th.SetupHTTP()
+
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "secret-token")
+		_, _ = fmt.Fprint(w, `{"server": {"name": "test", "adminPass": "Test@123"}}`)
+	})
+
+	t.Setenv("HW_VCR_CASSETTE", filepath.Join(t.TempDir(), "cassette.json"))
+	sendRequest := func() (*http.Response, error) {
+		cassette, err := LoadCassette("TestCassetteRecordAndReplay")
+		th.AssertNoErr(t, err)
+		client := http.Client{
+			Transport: &LogRoundTripper{
+				Rt:       http.DefaultTransport,
+				Cassette: cassette,
+			},
+		}
+		return client.Get(th.Endpoint() + "servers")
+	}
+
+	t.Setenv("HW_VCR_MODE", VcrModeRecord)
+	resp, err := sendRequest()
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.TeardownHTTP()
+
+	// the cassette is loaded from the file in replay mode
+	cassettes = make(map[string]*Cassette)
+	t.Setenv("HW_VCR_MODE", VcrModeReplay)
+	resp, err = sendRequest()
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	th.AssertEquals(t, "***", resp.Header.Get("X-Subject-Token"))
+	th.AssertEquals(t, `{"server":{"adminPass":"***","name":"test"}}`, string(body))
+
+	// each interaction is replayed only once
+	_, err = sendRequest()
+	th.AssertEquals(t, true, errors.Is(err, ErrInteractionNotFound))
+}
+
+func TestCassetteName(t *testing.T) {
+	t.Setenv("HW_VCR_MODE", VcrModeRecord)
+	t.Setenv("HW_VCR_CASSETTE", "")
+
+	ctx := ContextWithCassetteName(context.Background(), "TestAccVpc_basic")
+	th.AssertEquals(t, "TestAccVpc_basic", CassetteNameFromContext(ctx))
+	th.AssertEquals(t, "", CassetteNameFromContext(context.Background()))
+
+	// the provider configurations of the parallel tests use their own cassettes
+	first, err := LoadCassette("TestAccVpc_basic")
+	th.AssertNoErr(t, err)
+	second, err := LoadCassette("TestAccVpc_update/sub")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, filepath.Join(defaultCassetteDir, "TestAccVpc_basic.json"), first.path)
+	th.AssertEquals(t, filepath.Join(defaultCassetteDir, "TestAccVpc_update", "sub.json"), second.path)
+
+	again, err := LoadCassette("TestAccVpc_basic")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, first, again)
+}
+
+func TestCassetteObsSignedURL(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprint(w, `<ListAllMyBucketsResult><Owner><ID>owner</ID></Owner><Buckets><Bucket>`+
+			`<Name>test-bucket</Name></Bucket></Buckets></ListAllMyBucketsResult>`)
+	})
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	t.Setenv("HW_VCR_CASSETTE", cassettePath)
+	listBuckets := func(expires int) (*obs.ListBucketsOutput, error) {
+		cassette, err := LoadCassette("TestCassetteObsSignedURL")
+		th.AssertNoErr(t, err)
+
+		// the OBS clients share the HTTP client of the domain client, which records the requests with the cassette
+		cfg := &Config{
+			AccessKey: "access-key",
+			SecretKey: "secret-key",
+			Endpoints: map[string]string{"obs": strings.Replace(th.Endpoint(), "127.0.0.1", "localhost", 1)},
+			DomainClient: &golangsdk.ProviderClient{
+				HTTPClient: http.Client{
+					Transport: &LogRoundTripper{
+						Rt:       http.DefaultTransport,
+						Cassette: cassette,
+					},
+				},
+			},
+		}
+		obsClient, err := cfg.ObjectStorageClient("cn-north-4")
+		th.AssertNoErr(t, err)
+
+		signedURL, err := obsClient.CreateSignedUrl(&obs.CreateSignedUrlInput{
+			Method:  obs.HttpMethodGet,
+			Expires: expires,
+		})
+		th.AssertNoErr(t, err)
+		return obsClient.ListBucketsWithSignedUrl(signedURL.SignedUrl, signedURL.ActualSignedRequestHeaders)
+	}
+
+	t.Setenv("HW_VCR_MODE", VcrModeRecord)
+	_, err := listBuckets(300)
+	th.AssertNoErr(t, err)
+
+	content, err := os.ReadFile(cassettePath)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, strings.Contains(string(content), "access-key"))
+
+	// the signed URL of the replay has different expiration and signature
+	cassettes = make(map[string]*Cassette)
+	t.Setenv("HW_VCR_MODE", VcrModeReplay)
+	output, err := listBuckets(600)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(output.Buckets))
+	th.AssertEquals(t, "test-bucket", output.Buckets[0].Name)
+}
+
+func TestTraceRoundTripper(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerOnce.Do(func() {})
+	tracer = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer(tracerName)
+	tracing = true
+	t.Cleanup(func() {
+		tracer = noop.NewTracerProvider().Tracer(tracerName)
+		tracing = false
+	})
+
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	attempts := 0
+	th.Mux.HandleFunc("/vpcs", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-Id", "request-id")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, parent := Tracer().Start(context.Background(), "huaweicloud_vpc.create")
+	cfg := (&Config{}).WithTraceContext(ctx)
+	client := http.Client{
+		Transport: cfg.withTracing(&LogRoundTripper{
+			Rt: http.DefaultTransport,
+			Retry: &RetryPolicy{
+				MaxAttempts:          3,
+				MinBackoff:           time.Millisecond,
+				MaxBackoff:           time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+		}, "vpc", "cn-north-4"),
+	}
+	resp, err := client.Get(th.Endpoint() + "vpcs")
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	th.AssertEquals(t, 2, len(spans))
+	span := spans[0]
+	th.AssertEquals(t, "GET vpc", span.Name)
+	th.AssertEquals(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	th.AssertEquals(t, "vpc", attrs[TraceAttrService].AsString())
+	th.AssertEquals(t, "cn-north-4", attrs[TraceAttrRegion].AsString())
+	th.AssertEquals(t, int64(http.StatusOK), attrs[TraceAttrStatusCode].AsInt64())
+	th.AssertEquals(t, "request-id", attrs[TraceAttrRequestID].AsString())
+	th.AssertEquals(t, int64(1), attrs[TraceAttrRetryCount].AsInt64())
+
+	// the stdout exporter writes to the standard error since the standard output is used by the plugin protocol
+	for _, name := range []string{"stdout", "stderr"} {
+		_, err := newTraceExporter(name)
+		th.AssertNoErr(t, err)
+	}
+	_, err = newTraceExporter("otlp")
+	th.AssertEquals(t, true, err != nil)
+
+	// the spans are written as the OTLP JSON lines
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	t.Setenv(TraceFileEnv, path)
+	fileExporter, err := newTraceExporter("otlp-file")
+	th.AssertNoErr(t, err)
+	th.AssertNoErr(t, fileExporter.ExportSpans(context.Background(), exporter.GetSpans().Snapshots()))
+	th.AssertNoErr(t, fileExporter.Shutdown(context.Background()))
+
+	content, err := os.ReadFile(path)
+	th.AssertNoErr(t, err)
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	th.AssertNoErr(t, json.Unmarshal(content, &request))
+	exported := request.ResourceSpans[0].ScopeSpans[0].Spans
+	th.AssertEquals(t, 2, len(exported))
+	th.AssertEquals(t, exported[1].SpanID, exported[0].ParentSpanID)
+	th.AssertEquals(t, int(trace.SpanKindClient), exported[0].Kind)
+}
+
+func TestWithTraceContext(t *testing.T) {
+	tracerOnce.Do(func() {})
+	tracing = true
+	t.Cleanup(func() {
+		tracing = false
+	})
+
+	expiresAt := time.Now().Add(time.Hour)
+	origin := &Config{
+		AccessKey:            "access-key-1",
+		SecretKey:            "secret-key-1",
+		SecurityToken:        "token-1",
+		SecurityKeyExpiresAt: expiresAt,
+		SecurityKeyLock:      new(sync.Mutex),
+	}
+
+	ctx, span := noop.NewTracerProvider().Tracer(tracerName).Start(context.Background(), "huaweicloud_vpc.create")
+	defer span.End()
+	derived := origin.WithTraceContext(ctx)
+	th.AssertEquals(t, origin, derived.origin)
+	th.AssertEquals(t, origin, derived.WithTraceContext(ctx).origin)
+
+	// the key reloaded by the origin config, e.g. by another operation, is taken over by the derived config
+	origin.AccessKey, origin.SecretKey, origin.SecurityToken = "access-key-2", "secret-key-2", "token-2"
+	origin.SecurityKeyExpiresAt = expiresAt.Add(time.Hour)
+	th.AssertNoErr(t, derived.refreshSecurityKey())
+	th.AssertEquals(t, "access-key-2", derived.AccessKey)
+	th.AssertEquals(t, "secret-key-2", derived.SecretKey)
+	th.AssertEquals(t, "token-2", derived.SecurityToken)
+	th.AssertEquals(t, origin.SecurityKeyExpiresAt, derived.SecurityKeyExpiresAt)
+}
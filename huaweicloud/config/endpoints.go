@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"sort"
 )
 
 // ServiceCatalog defines a struct which was used to generate a service client for huaweicloud.
@@ -939,3 +940,13 @@ func GetServiceCatalog(service string) *ServiceCatalog {
 	}
 	return nil
 }
+
+// GetServiceCatalogKeys returns the sorted keys of all services in the catalog
+func GetServiceCatalogKeys() []string {
+	keys := make([]string, 0, len(allServiceCatalog))
+	for key := range allServiceCatalog {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,9 +2,11 @@ package config
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -88,13 +90,9 @@ func buildGlobalAuthCredentials(c *Config, region string) (*global.Credentials,
 	return &credentials, nil
 }
 
-func buildHTTPConfig(c *Config) *hcconfig.HttpConfig {
+func buildHTTPConfig(c *Config, product, region string) *hcconfig.HttpConfig {
 	httpConfig := hcconfig.DefaultHttpConfig()
 
-	if c.MaxRetries > 0 {
-		httpConfig = httpConfig.WithRetries(c.MaxRetries)
-	}
-
 	if c.Insecure {
 		httpConfig = httpConfig.WithIgnoreSSLVerification(true)
 	}
@@ -123,7 +121,54 @@ func buildHTTPConfig(c *Config) *hcconfig.HttpConfig {
 		}
 	}
 
-	return httpConfig
+	return httpConfig.WithHttpTransport(c.buildRetryTransport(httpConfig, product, region))
+}
+
+// buildRetryTransport builds the transport which retries the requests with the retry policy, records or replays the
+// requests with the cassette if it's not nil, and traces the requests of the product if the tracing is enabled.
+// The SDK only accepts an *http.Transport, so the RetryRoundTripper is registered as the handler of both HTTP and
+// HTTPS protocols, and it sends the requests through an inner transport built with the TLS and proxy settings.
+func (c *Config) buildRetryTransport(httpConfig *hcconfig.HttpConfig, product, region string) *http.Transport {
+	inner := &http.Transport{
+		// the same settings as http.DefaultTransport
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{InsecureSkipVerify: httpConfig.IgnoreSSLVerification}, //nolint:gosec
+	}
+	if httpConfig.HttpProxy != nil {
+		if proxy, err := url.Parse(httpConfig.HttpProxy.GetProxyUrl()); err == nil {
+			inner.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	rt := c.withTracing(c.newRetryRoundTripper(inner, product, region), product, region)
+	transport := &http.Transport{
+		// the requests are never sent by this transport, disable its HTTP/2 support
+		TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
+	}
+	transport.RegisterProtocol("https", rt)
+	transport.RegisterProtocol("http", rt)
+	return transport
+}
+
+// newRetryRoundTripper returns the RetryRoundTripper of the product, each attempt waits for the rate limiter of the
+// product before it's sent.
+func (c *Config) newRetryRoundTripper(inner http.RoundTripper, product, region string) *RetryRoundTripper {
+	if catalog, ok := allServiceCatalog[product]; ok {
+		inner = c.withRateLimit(inner, catalog, region)
+	}
+	return &RetryRoundTripper{
+		Rt:     c.Cassette.Transport(inner),
+		Policy: c.GetRetryPolicy(),
+	}
 }
 
 // HcVpcV3Client is the VPC service client using huaweicloud-sdk-go-v3 package
@@ -305,7 +350,7 @@ func NewHcClient(c *Config, region, product string, globalFlag bool) (*core.HcHt
 		return nil, fmt.Errorf("failed to get the endpoint of %q service in region %s", product, region)
 	}
 
-	builder := core.NewHcHttpClientBuilder().WithEndpoint(endpoint).WithHttpConfig(buildHTTPConfig(c))
+	builder := core.NewHcHttpClientBuilder().WithEndpoint(endpoint).WithHttpConfig(buildHTTPConfig(c, product, region))
 
 	if globalFlag {
 		credentials, err := buildGlobalAuthCredentials(c, region)
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net/http"
 	"sort"
 	"strings"
@@ -20,22 +19,15 @@ import (
 const MAXFieldLength int = 1024
 
 var logAtomicId int64
-var maxTimeout = 10 * time.Minute
 
 // LogRoundTripper satisfies the http.RoundTripper interface and is used to
 // customize the default http client RoundTripper to allow for logging.
+// The request is retried with the Retry policy, and it's sent only once if the policy is nil.
+// The API interactions are recorded to or replayed from the Cassette if it's not nil.
 type LogRoundTripper struct {
-	Rt         http.RoundTripper
-	MaxRetries int
-}
-
-func retryTimeout(count int) time.Duration {
-	seconds := math.Pow(2, float64(count))
-	timeout := time.Duration(seconds) * time.Second
-	if timeout > maxTimeout { // won't wait more than maxTimeout
-		timeout = maxTimeout
-	}
-	return timeout
+	Rt       http.RoundTripper
+	Retry    *RetryPolicy
+	Cassette *Cassette
 }
 
 // RoundTrip performs a round-trip HTTP request and logs relevant information about it.
@@ -85,33 +77,8 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 		}
 	}
 
-	// executes a single HTTP transaction
-	response, err = lrt.Rt.RoundTrip(request)
-	if response == nil {
-		errMessage := err.Error()
-		if strings.Contains(errMessage, "no such host") {
-			return nil, err
-		}
-	}
-
-	// Retrying connection
-	retry := 1
-	for response == nil {
-		responseAt := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), logId)
-		if retry > lrt.MaxRetries {
-			log.Printf("[DEBUG] [%s] connection error, retries exhausted. Aborting", responseAt)
-			err = fmt.Errorf("connection error, retries exhausted. Aborting. Last error was: %s", err)
-			return nil, err
-		}
-
-		log.Printf("[DEBUG] [%s] connection error, retry number %d: %s", responseAt, retry, err)
-
-		//lintignore:R018
-		time.Sleep(retryTimeout(retry))
-		response, err = lrt.Rt.RoundTrip(request)
-		retry++
-	}
-
+	// executes the HTTP transaction, and retries it if necessary
+	response, err = lrt.Retry.roundTrip(lrt.Cassette.Transport(lrt.Rt), request)
 	return response, err
 }
 
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// the environment variables of the OIDC tokens in the CI pipelines
+	githubTokenRequestURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubTokenRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// AssumeRoleWithOIDC exchanges an OIDC token for the temporary credential through the identity provider of IAM.
+type AssumeRoleWithOIDC struct {
+	// IdpID is the ID of the OIDC identity provider registered in IAM
+	IdpID string
+	// Token is the OIDC token, it takes precedence over the TokenFile
+	Token string
+	// TokenFile is the path of the file which contains the OIDC token, it's read again when refreshing
+	TokenFile string
+	// TokenEnv is the name of the environment variable which contains the OIDC token, such as the ID token declared
+	// in the `id_tokens` of a GitLab CI job
+	TokenEnv string
+	// Audience is the audience of the token requested from GitHub Actions
+	Audience string
+	// Duration is the validity period of the temporary credential, in seconds, defaults to 24 hours
+	Duration int32
+}
+
+// getOIDCToken returns the OIDC token from the config, the token file, the token environment variable or GitHub Actions
+// in order.
+func (c *Config) getOIDCToken() (string, error) {
+	oidc := c.AssumeRoleWithOIDC
+	if oidc.Token != "" {
+		return oidc.Token, nil
+	}
+
+	if oidc.TokenFile != "" {
+		path, err := homedir.Expand(oidc.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("Error reading the OIDC token file: %s", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	if oidc.TokenEnv != "" {
+		token := strings.TrimSpace(os.Getenv(oidc.TokenEnv))
+		if token == "" {
+			return "", fmt.Errorf("the OIDC token is missing, the environment variable %s is empty", oidc.TokenEnv)
+		}
+		return token, nil
+	}
+
+	if requestURL := os.Getenv(githubTokenRequestURLEnv); requestURL != "" {
+		return c.requestGithubOIDCToken(requestURL, os.Getenv(githubTokenRequestTokenEnv))
+	}
+	return "", fmt.Errorf("the OIDC token is missing, it can be specified by token, token_file, token_env or the " +
+		"GitHub Actions environment variables")
+}
+
+// requestGithubOIDCToken requests the OIDC token of the GitHub Actions job, the job requires the permission
+// `id-token: write`.
+func (c *Config) requestGithubOIDCToken(requestURL, requestToken string) (string, error) {
+	if c.AssumeRoleWithOIDC.Audience != "" {
+		parsed, err := url.Parse(requestURL)
+		if err != nil {
+			return "", fmt.Errorf("Error parsing %s: %s", githubTokenRequestURLEnv, err)
+		}
+		query := parsed.Query()
+		query.Set("audience", c.AssumeRoleWithOIDC.Audience)
+		parsed.RawQuery = query.Encode()
+		requestURL = parsed.String()
+	}
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("Error building GitHub OIDC token request: %s", err)
+	}
+	req.Header.Set("Authorization", "bearer "+requestToken)
+
+	var parsedBody struct {
+		Value string `json:"value"`
+	}
+	if err := doOIDCRequest(&http.Client{}, req, &parsedBody); err != nil {
+		return "", fmt.Errorf("Error requesting GitHub OIDC token: %s", err)
+	}
+	if parsedBody.Value == "" {
+		return "", fmt.Errorf("Error requesting GitHub OIDC token: the token is empty")
+	}
+	return parsedBody.Value, nil
+}
+
+// getAuthConfigByOIDC exchanges the OIDC token for a federated token, and then gets the temporary credential with
+// the federated token.
+func getAuthConfigByOIDC(c *Config) error {
+	oidcToken, err := c.getOIDCToken()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := newIdentityHTTPClient(c)
+	if err != nil {
+		return err
+	}
+	iamEndpoint := strings.TrimSuffix(strings.TrimSuffix(c.IdentityEndpoint, "/"), "/v3")
+
+	scope := map[string]interface{}{
+		"project": map[string]interface{}{"name": c.Region},
+	}
+	if c.DomainID != "" {
+		scope = map[string]interface{}{"domain": map[string]interface{}{"id": c.DomainID}}
+	} else if c.DomainName != "" {
+		scope = map[string]interface{}{"domain": map[string]interface{}{"name": c.DomainName}}
+	}
+	tokenBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"id_token": map[string]interface{}{"id": oidcToken},
+			"scope":    scope,
+		},
+	}
+	req, err := newIdentityRequest(iamEndpoint+"/v3.0/OS-AUTH/id-token/tokens", tokenBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Idp-Id", c.AssumeRoleWithOIDC.IdpID)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error exchanging the OIDC token: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		rawBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Error exchanging the OIDC token: status code = %d, %s", resp.StatusCode, rawBody)
+	}
+	federatedToken := resp.Header.Get("X-Subject-Token")
+	if federatedToken == "" {
+		return fmt.Errorf("Error exchanging the OIDC token: the X-Subject-Token header is missing")
+	}
+
+	duration := assumeRoleDuration
+	if c.AssumeRoleWithOIDC.Duration > 0 {
+		duration = c.AssumeRoleWithOIDC.Duration
+	}
+	credentialBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"token"},
+				"token": map[string]interface{}{
+					"id":               federatedToken,
+					"duration_seconds": duration,
+				},
+			},
+		},
+	}
+	req, err = newIdentityRequest(iamEndpoint+"/v3.0/OS-CREDENTIAL/securitytokens", credentialBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Auth-Token", federatedToken)
+
+	var parsedBody struct {
+		Credential struct {
+			Access        string `json:"access"`
+			Secret        string `json:"secret"`
+			SecurityToken string `json:"securitytoken"`
+			ExpiresAt     string `json:"expires_at"`
+		} `json:"credential"`
+	}
+	if err := doOIDCRequest(httpClient, req, &parsedBody); err != nil {
+		return fmt.Errorf("Error creating temporary accesskey by the federated token: %s", err)
+	}
+
+	credential := parsedBody.Credential
+	if credential.Access == "" || credential.Secret == "" || credential.SecurityToken == "" {
+		return fmt.Errorf("Error creating temporary accesskey by the federated token: the credential is not found")
+	}
+	expiresAt, err := time.Parse(time.RFC3339, credential.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("Error parsing the expiration time of the temporary accesskey: %s", err)
+	}
+	c.AccessKey, c.SecretKey, c.SecurityToken, c.SecurityKeyExpiresAt = credential.Access, credential.Secret,
+		credential.SecurityToken, expiresAt
+
+	return nil
+}
+
+func buildClientByOIDC(c *Config) error {
+	err := getAuthConfigByOIDC(c)
+	if err != nil {
+		return fmt.Errorf("Error fetching Auth credentials by the OIDC token: %s", err)
+	}
+	log.Printf("[DEBUG] Successfully got security key by the OIDC token, which will expire at: %s", c.SecurityKeyExpiresAt)
+	return buildClientByAKSK(c)
+}
+
+// newIdentityHTTPClient returns the HTTP client which sends the IAM requests without the AK/SK signature.
+func newIdentityHTTPClient(c *Config) (*http.Client, error) {
+	tlsConfig, err := generateTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+
+	return &http.Client{
+		Transport: c.withTracing(&LogRoundTripper{
+			Rt:       transport,
+			Retry:    c.GetRetryPolicy(),
+			Cassette: c.Cassette,
+		}, "iam", c.Region),
+	}, nil
+}
+
+func newIdentityRequest(requestURL string, body interface{}) (*http.Request, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", requestURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("Error building IAM request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+	return req, nil
+}
+
+// doOIDCRequest sends the request and parses the JSON response body.
+func doOIDCRequest(httpClient *http.Client, req *http.Request, parsedBody interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status code = %d, %s", resp.StatusCode, rawBody)
+	}
+	return json.Unmarshal(rawBody, parsedBody)
+}
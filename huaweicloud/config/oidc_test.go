@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestAssumeRoleWithOIDC(t *testing.T) {
+	server := fakecloud.NewServer(t)
+
+	t.Run("GitHub Actions", func(t *testing.T) {
+		github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			th.AssertEquals(t, "bearer request-token", r.Header.Get("Authorization"))
+			th.AssertEquals(t, "huaweicloud", r.URL.Query().Get("audience"))
+			th.AssertEquals(t, "2.0", r.URL.Query().Get("api-version"))
+			_, _ = w.Write([]byte(`{"value":"github-jwt"}`))
+		}))
+		defer github.Close()
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", github.URL+"?api-version=2.0")
+		t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+
+		cfg := server.Config(t, func(c *config.Config) {
+			c.AssumeRoleWithOIDC = &config.AssumeRoleWithOIDC{IdpID: "github", Audience: "huaweicloud", Duration: 3600}
+		})
+		th.AssertEquals(t, true, strings.HasPrefix(cfg.AccessKey, "ak-federated-"))
+		th.AssertEquals(t, "token-"+cfg.AccessKey, cfg.SecurityToken)
+
+		credential, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, float64(3600), credential["duration_seconds"])
+		federated, ok := server.Object(fakecloud.KindFederatedToken, credential["federated_token"].(string))
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "github", federated["idp_id"])
+		th.AssertEquals(t, "github-jwt", federated["id_token"])
+	})
+
+	t.Run("token file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		th.AssertNoErr(t, os.WriteFile(tokenFile, []byte("jwt-1\n"), 0600))
+
+		cfg := server.Config(t, func(c *config.Config) {
+			c.AssumeRoleWithOIDC = &config.AssumeRoleWithOIDC{IdpID: "kubernetes", TokenFile: tokenFile}
+		})
+		credential, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+		th.AssertEquals(t, true, ok)
+		federated, ok := server.Object(fakecloud.KindFederatedToken, credential["federated_token"].(string))
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "jwt-1", federated["id_token"])
+
+		// the token file is read again when the credential is about to expire
+		th.AssertNoErr(t, os.WriteFile(tokenFile, []byte("jwt-2\n"), 0600))
+		cfg.SecurityKeyExpiresAt = time.Now()
+		_, err := cfg.NetworkingV1Client(fakecloud.Region)
+		th.AssertNoErr(t, err)
+
+		credential, ok = server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+		th.AssertEquals(t, true, ok)
+		federated, ok = server.Object(fakecloud.KindFederatedToken, credential["federated_token"].(string))
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "jwt-2", federated["id_token"])
+	})
+
+	t.Run("token environment variable", func(t *testing.T) {
+		t.Setenv("HW_GITLAB_ID_TOKEN", "gitlab-jwt\n")
+
+		cfg := server.Config(t, func(c *config.Config) {
+			c.AssumeRoleWithOIDC = &config.AssumeRoleWithOIDC{IdpID: "gitlab", TokenEnv: "HW_GITLAB_ID_TOKEN"}
+		})
+		credential, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+		th.AssertEquals(t, true, ok)
+		federated, ok := server.Object(fakecloud.KindFederatedToken, credential["federated_token"].(string))
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "gitlab-jwt", federated["id_token"])
+
+		// the empty variable is reported instead of falling back to the other sources
+		t.Setenv("HW_GITLAB_ID_TOKEN", "")
+		empty := &config.Config{
+			Region:             fakecloud.Region,
+			AssumeRoleWithOIDC: &config.AssumeRoleWithOIDC{IdpID: "gitlab", TokenEnv: "HW_GITLAB_ID_TOKEN"},
+		}
+		err := empty.LoadAndValidate()
+		th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "HW_GITLAB_ID_TOKEN is empty"))
+	})
+
+	t.Run("assume role", func(t *testing.T) {
+		cfg := server.Config(t, func(c *config.Config) {
+			c.AssumeRoleWithOIDC = &config.AssumeRoleWithOIDC{IdpID: "gitlab", Token: "gitlab-jwt"}
+			c.AssumeRoles = []config.AssumeRole{{AgencyName: "workload", DomainName: "workload-account"}}
+		})
+
+		// the agency is assumed with the credential exchanged by the OIDC token
+		workload, ok := server.Object(fakecloud.KindSecurityToken, cfg.AccessKey)
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "workload", workload["agency_name"])
+		th.AssertEquals(t, true, strings.HasPrefix(workload["signed_by"].(string), "ak-federated-"))
+	})
+}
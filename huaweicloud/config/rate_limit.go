@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimits are the default maximum requests per second of the services which are easily throttled
+// account-wide, the key is the name of ServiceCatalog. They can be overridden by the `rate_limits` of the provider,
+// and a value of 0 disables the limit.
+var DefaultRateLimits = map[string]int{
+	"iam": 10,
+	"evs": 20,
+	"dns": 20,
+}
+
+// tokenBucket is a token-bucket limiter, the bucket is refilled with rate tokens per second and holds burst tokens
+// at most.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns the time to wait before the token is available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns the token which is reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	wait := b.reserve()
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// RateLimitRoundTripper satisfies the http.RoundTripper interface and limits the rate of the HTTP requests.
+type RateLimitRoundTripper struct {
+	Rt      http.RoundTripper
+	limiter *tokenBucket
+}
+
+// RoundTrip waits for the rate limiter and then performs a round-trip HTTP request.
+func (rrt *RateLimitRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := rrt.limiter.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+	return rrt.Rt.RoundTrip(request)
+}
+
+// getRateLimit returns the maximum requests per second of the service, 0 means no limit.
+func (c *Config) getRateLimit(serviceName string) int {
+	if limit, ok := c.RateLimits[serviceName]; ok {
+		return limit
+	}
+	return DefaultRateLimits[serviceName]
+}
+
+// withRateLimit returns a round tripper which shares the rate limiter of the service in the region with all
+// clients of the same service, or returns the origin one if the service is not limited.
+// The limiter of a global service is shared among all regions.
+// If the round tripper is a LogRoundTripper, the limiter is placed inside it, so that each retry waits for the
+// limiter as well.
+func (c *Config) withRateLimit(rt http.RoundTripper, catalog ServiceCatalog, region string) http.RoundTripper {
+	limit := c.getRateLimit(catalog.Name)
+	if c.rateLimiters == nil || limit <= 0 {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	key := fmt.Sprintf("%s/%s", catalog.Name, region)
+	if catalog.Scope == "global" && !c.RegionClient {
+		key = catalog.Name
+	}
+	limiter, _ := c.rateLimiters.LoadOrStore(key, newTokenBucket(limit))
+
+	if lrt, ok := rt.(*LogRoundTripper); ok {
+		inner := lrt.Rt
+		if inner == nil {
+			inner = http.DefaultTransport
+		}
+		clone := *lrt
+		clone.Rt = &RateLimitRoundTripper{
+			Rt:      inner,
+			limiter: limiter.(*tokenBucket),
+		}
+		return &clone
+	}
+	return &RateLimitRoundTripper{
+		Rt:      rt,
+		limiter: limiter.(*tokenBucket),
+	}
+}
+
+// validateRateLimits checks whether the services of the rate limits are defined in the service catalog and the
+// limits are not negative.
+func validateRateLimits(rateLimits map[string]int) error {
+	services := make(map[string]bool, len(allServiceCatalog))
+	for _, catalog := range allServiceCatalog {
+		services[catalog.Name] = true
+	}
+
+	for name, limit := range rateLimits {
+		if !services[name] {
+			return fmt.Errorf("the service %s of rate_limits is not found in the service catalog", name)
+		}
+		if limit < 0 {
+			return fmt.Errorf("the rate limit of %s should not be negative", name)
+		}
+	}
+	return nil
+}
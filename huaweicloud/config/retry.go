@@ -0,0 +1,298 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetryMinBackoff is the default wait time before the first retry.
+	DefaultRetryMinBackoff = 1 * time.Second
+	// DefaultRetryMaxBackoff is the default maximum wait time between two attempts.
+	DefaultRetryMaxBackoff = 30 * time.Second
+	// maxRetryAfter is the maximum wait time when honoring the Retry-After header.
+	maxRetryAfter = 10 * time.Minute
+)
+
+var (
+	// DefaultRetryableStatusCodes are the HTTP status codes which are retried when the `retry` block does not
+	// specify them: the requests are throttled or the service is temporarily unavailable.
+	DefaultRetryableStatusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+	// DefaultRetryableErrorCodes are the error codes in the response body which are retried when the `retry` block
+	// does not specify them.
+	DefaultRetryableErrorCodes = []string{
+		"APIGW.0308", // the throttling threshold has been reached
+		"CBC.0150",   // too many concurrent requests
+	}
+)
+
+// RetryPolicy describes how the HTTP requests are retried when the connection fails, or the response is throttled
+// or temporarily unavailable.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a request, including the first one.
+	MaxAttempts int
+	// MinBackoff is the wait time before the first retry, it doubles for each subsequent retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait time between two attempts.
+	MaxBackoff time.Duration
+	// Jitter randomizes the wait time between a half and the whole back-off to spread the retries.
+	Jitter bool
+	// RetryableStatusCodes are the HTTP status codes which are retried.
+	RetryableStatusCodes []int
+	// RetryableErrorCodes are the error codes in the response body which are retried.
+	RetryableErrorCodes []string
+}
+
+// NewRetryPolicy returns a retry policy with the default back-off and retryable codes, a request is retried
+// for maxRetries times at most.
+func NewRetryPolicy(maxRetries int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          maxRetries + 1,
+		MinBackoff:           DefaultRetryMinBackoff,
+		MaxBackoff:           DefaultRetryMaxBackoff,
+		Jitter:               true,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+		RetryableErrorCodes:  DefaultRetryableErrorCodes,
+	}
+}
+
+// Validate checks whether the retry policy is valid.
+func (p *RetryPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 1 {
+		return fmt.Errorf("max_attempts of retry should be at least 1")
+	}
+	if p.MinBackoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("the back-off of retry should not be negative")
+	}
+	if p.MinBackoff > p.MaxBackoff {
+		return fmt.Errorf("min_backoff of retry should not be greater than max_backoff")
+	}
+	return nil
+}
+
+// GetRetryPolicy returns the retry policy specified in the provider, or the default one built from max_retries.
+func (c *Config) GetRetryPolicy() *RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+	return NewRetryPolicy(c.MaxRetries)
+}
+
+// IsRetryableStatusCode returns true if the status code is retryable in the policy.
+func (p *RetryPolicy) IsRetryableStatusCode(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryableErrorCode returns true if the response body contains an error code which is retryable in the policy.
+func (p *RetryPolicy) IsRetryableErrorCode(body []byte) bool {
+	if len(p.RetryableErrorCodes) == 0 {
+		return false
+	}
+
+	errorCode := ParseErrorCode(body)
+	if errorCode == "" {
+		return false
+	}
+	for _, code := range p.RetryableErrorCodes {
+		if code == errorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseErrorCode returns the error code in the JSON response body, the services use different field names for it,
+// e.g. `error_code`, `errorCode`, `code` and `error.code`.
+func ParseErrorCode(body []byte) string {
+	var respBody map[string]interface{}
+	if err := json.Unmarshal(body, &respBody); err != nil {
+		return ""
+	}
+
+	if errObj, ok := respBody["error"].(map[string]interface{}); ok {
+		respBody = errObj
+	}
+	for _, key := range []string{"error_code", "errorCode", "code"} {
+		if code, ok := respBody[key].(string); ok && code != "" {
+			return code
+		}
+	}
+	return ""
+}
+
+// Backoff returns the wait time before the next attempt, the Retry-After header of the response is preferred.
+// The attempt starts from 1.
+func (p *RetryPolicy) Backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	backoff := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	wait := p.MaxBackoff
+	if backoff < float64(p.MaxBackoff) {
+		wait = time.Duration(backoff)
+	}
+	if p.Jitter && wait > 0 {
+		//nolint:gosec
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+	return wait
+}
+
+// parseRetryAfter parses the Retry-After header, which is either the seconds to wait or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter { // won't wait more than maxRetryAfter
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
+// RetryRoundTripper satisfies the http.RoundTripper interface and retries the HTTP requests with the retry policy.
+type RetryRoundTripper struct {
+	Rt     http.RoundTripper
+	Policy *RetryPolicy
+}
+
+// RoundTrip performs a round-trip HTTP request and retries it according to the retry policy.
+func (rrt *RetryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return rrt.Policy.roundTrip(rrt.Rt, request)
+}
+
+func (p *RetryPolicy) roundTrip(rt http.RoundTripper, request *http.Request) (*http.Response, error) {
+	if p == nil || p.MaxAttempts <= 1 {
+		return rt.RoundTrip(request)
+	}
+
+	if err := makeRequestRewindable(request); err != nil {
+		return nil, err
+	}
+
+	retries := 0
+	defer func() {
+		recordRetryCount(request, retries)
+	}()
+
+	for attempt := 1; ; attempt++ {
+		retries = attempt - 1
+		response, err := rt.RoundTrip(request)
+		if response == nil && err != nil &&
+			(strings.Contains(err.Error(), "no such host") || errors.Is(err, ErrInteractionNotFound)) {
+			return nil, err
+		}
+
+		reason, retryable := p.shouldRetry(response, err)
+		if !retryable {
+			return response, err
+		}
+		if attempt >= p.MaxAttempts {
+			if response == nil {
+				return nil, fmt.Errorf("connection error, retries exhausted. Aborting. Last error was: %s", err)
+			}
+			log.Printf("[DEBUG] %s, retries exhausted", reason)
+			return response, err
+		}
+
+		wait := p.Backoff(attempt, response)
+		log.Printf("[DEBUG] %s, retry number %d after %s: %s %s", reason, attempt, wait, request.Method, request.URL)
+		if response != nil {
+			// read till EOF, so that the connection can be reused
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+	}
+}
+
+// shouldRetry checks whether the request should be retried and returns the reason.
+func (p *RetryPolicy) shouldRetry(response *http.Response, err error) (string, bool) {
+	if response == nil {
+		return fmt.Sprintf("connection error (%v)", err), err != nil
+	}
+
+	if p.IsRetryableStatusCode(response.StatusCode) {
+		return fmt.Sprintf("received retryable status code %d", response.StatusCode), true
+	}
+	if response.StatusCode < 400 || len(p.RetryableErrorCodes) == 0 || response.Body == nil {
+		return "", false
+	}
+
+	body, readErr := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return "", false
+	}
+	if p.IsRetryableErrorCode(body) {
+		return fmt.Sprintf("received retryable error code %s", ParseErrorCode(body)), true
+	}
+	return "", false
+}
+
+// makeRequestRewindable buffers the request body so that it can be sent again when retrying.
+func makeRequestRewindable(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	request.Body, _ = request.GetBody()
+	return nil
+}
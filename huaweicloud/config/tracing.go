@@ -0,0 +1,354 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// TraceExporterEnv is the environment variable which enables the tracing of the provider operations and the API
+	// requests, the value is the exporter of the spans: stdout (or stderr) or otlp-file.
+	TraceExporterEnv = "HW_OTEL_EXPORTER"
+	// TraceFileEnv is the environment variable of the file which the otlp-file exporter appends the spans to.
+	TraceFileEnv = "HW_OTEL_FILE"
+
+	// DefaultTraceFile is the default file of the otlp-file exporter, it's relative to the working directory.
+	DefaultTraceFile = "terraform-provider-huaweicloud-traces.jsonl"
+
+	tracerName = "github.com/huaweicloud/terraform-provider-huaweicloud"
+)
+
+// The attributes of the spans.
+const (
+	TraceAttrService    = attribute.Key("huaweicloud.service")
+	TraceAttrRegion     = attribute.Key("huaweicloud.region")
+	TraceAttrRequestID  = attribute.Key("huaweicloud.request_id")
+	TraceAttrRetryCount = attribute.Key("huaweicloud.retry_count")
+	TraceAttrStatusCode = attribute.Key("http.response.status_code")
+)
+
+var (
+	tracerOnce sync.Once
+	tracer     trace.Tracer
+	tracing    bool
+)
+
+// TracingEnabled returns whether the operations and the API requests are traced, it's enabled by HW_OTEL_EXPORTER.
+func TracingEnabled() bool {
+	initTracer()
+	return tracing
+}
+
+// Tracer returns the tracer of the provider, a no-op tracer is returned if the tracing is disabled.
+func Tracer() trace.Tracer {
+	initTracer()
+	return tracer
+}
+
+func initTracer() {
+	tracerOnce.Do(func() {
+		tracer = noop.NewTracerProvider().Tracer(tracerName)
+
+		name := os.Getenv(TraceExporterEnv)
+		if name == "" {
+			return
+		}
+		exporter, err := newTraceExporter(name)
+		if err != nil {
+			log.Printf("[WARN] failed to initialize the trace exporter, the tracing is disabled: %s", err)
+			return
+		}
+
+		// the spans are exported synchronously, so that they are not lost when the provider process is killed
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithSyncer(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(
+				attribute.String("service.name", "terraform-provider-huaweicloud"),
+			)),
+		)
+		tracer = provider.Tracer(tracerName)
+		tracing = true
+		log.Printf("[DEBUG] the tracing is enabled with the %s exporter", name)
+	})
+}
+
+func newTraceExporter(name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "stdout", "stderr":
+		// the standard output of the provider is reserved for the plugin protocol, so the stdout exporter writes to
+		// the standard error, which is shown in the Terraform logs
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case "otlp-file":
+		path := os.Getenv(TraceFileEnv)
+		if path == "" {
+			path = DefaultTraceFile
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening the trace file: %s", err)
+		}
+		return &otlpFileExporter{file: f}, nil
+	default:
+		return nil, fmt.Errorf("invalid value of %s: %q, it should be stdout, stderr or otlp-file",
+			TraceExporterEnv, name)
+	}
+}
+
+// WithTraceContext returns a config derived from c whose service clients record the API requests as the child spans
+// of the span in the context. The service clients don't take a context, so the span is saved in the derived config,
+// which takes over the security key reloaded by c instead of reloading its own. The origin config is returned if the
+// tracing is disabled.
+func (c *Config) WithTraceContext(ctx context.Context) *Config {
+	if !TracingEnabled() {
+		return c
+	}
+
+	origin := c
+	if c.origin != nil {
+		origin = c.origin
+	}
+	if c.SecurityKeyLock != nil {
+		c.SecurityKeyLock.Lock()
+		defer c.SecurityKeyLock.Unlock()
+	}
+
+	derived := *c
+	derived.origin = origin
+	derived.traceParent = trace.SpanContextFromContext(ctx)
+	return &derived
+}
+
+// withTracing returns a round tripper which records the requests of the service as spans, or returns the origin one
+// if the tracing is disabled.
+func (c *Config) withTracing(rt http.RoundTripper, service, region string) http.RoundTripper {
+	if !TracingEnabled() {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &TraceRoundTripper{
+		Rt:      rt,
+		Service: service,
+		Region:  region,
+		Parent:  c.traceParent,
+	}
+}
+
+// TraceRoundTripper satisfies the http.RoundTripper interface and records each HTTP request as a span.
+// The span is the child of the span in the request context, or the Parent if the request context has no span.
+type TraceRoundTripper struct {
+	Rt      http.RoundTripper
+	Service string
+	Region  string
+	Parent  trace.SpanContext
+}
+
+// RoundTrip performs a round-trip HTTP request and records it as a span.
+func (trt *TraceRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() && trt.Parent.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, trt.Parent)
+	}
+
+	ctx, span := Tracer().Start(ctx, fmt.Sprintf("%s %s", request.Method, trt.Service),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			TraceAttrService.String(trt.Service),
+			TraceAttrRegion.String(trt.Region),
+			TraceAttrRetryCount.Int(0),
+			attribute.String("http.request.method", request.Method),
+			attribute.String("server.address", request.URL.Hostname()),
+			attribute.String("url.path", request.URL.Path),
+		),
+	)
+	defer span.End()
+
+	response, err := trt.Rt.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return response, err
+	}
+
+	span.SetAttributes(
+		TraceAttrStatusCode.Int(response.StatusCode),
+		TraceAttrRequestID.String(response.Header.Get("X-Request-Id")),
+	)
+	if response.StatusCode >= 400 {
+		span.SetStatus(codes.Error, response.Status)
+	}
+	return response, nil
+}
+
+// recordRetryCount saves the retry count of the request to the span in the request context.
+func recordRetryCount(request *http.Request, retries int) {
+	trace.SpanFromContext(request.Context()).SetAttributes(TraceAttrRetryCount.Int(retries))
+}
+
+// otlpFileExporter appends the spans to the file in the OTLP JSON format, each line is an ExportTraceServiceRequest,
+// so that the file can be loaded by the OpenTelemetry Collector or the tracing backends.
+type otlpFileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type otlpAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type otlpEvent struct {
+	Name         string          `json:"name"`
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+// ExportSpans writes the spans as a line of the file.
+func (e *otlpFileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	items := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		items[i] = otlpSpan{
+			TraceID:           s.SpanContext().TraceID().String(),
+			SpanID:            s.SpanContext().SpanID().String(),
+			Name:              s.Name(),
+			Kind:              int(s.SpanKind()),
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+			Attributes:        toOtlpAttributes(s.Attributes()),
+			Status:            toOtlpStatus(s.Status()),
+		}
+		if s.Parent().IsValid() {
+			items[i].ParentSpanID = s.Parent().SpanID().String()
+		}
+		for _, event := range s.Events() {
+			items[i].Events = append(items[i].Events, otlpEvent{
+				Name:         event.Name,
+				TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+				Attributes:   toOtlpAttributes(event.Attributes),
+			})
+		}
+	}
+
+	request := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": toOtlpAttributes(spans[0].Resource().Attributes()),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": tracerName},
+						"spans": items,
+					},
+				},
+			},
+		},
+	}
+	line, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.file.Write(append(line, '\n'))
+	return err
+}
+
+// Shutdown closes the file.
+func (e *otlpFileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file.Close()
+}
+
+func toOtlpAttributes(attrs []attribute.KeyValue) []otlpAttribute {
+	result := make([]otlpAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, otlpAttribute{
+			Key:   string(attr.Key),
+			Value: toOtlpValue(attr.Value),
+		})
+	}
+	return result
+}
+
+// toOtlpValue converts the attribute value to the AnyValue of OTLP, the 64-bit integers are encoded as strings.
+func toOtlpValue(v attribute.Value) map[string]interface{} {
+	switch v.Type() {
+	case attribute.BOOL:
+		return map[string]interface{}{"boolValue": v.AsBool()}
+	case attribute.INT64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v.AsInt64(), 10)}
+	case attribute.FLOAT64:
+		return map[string]interface{}{"doubleValue": v.AsFloat64()}
+	case attribute.BOOLSLICE:
+		return toOtlpArray(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return toOtlpArray(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return toOtlpArray(v.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return toOtlpArray(v.AsStringSlice(), attribute.StringValue)
+	default:
+		return map[string]interface{}{"stringValue": v.Emit()}
+	}
+}
+
+func toOtlpArray[T any](items []T, toValue func(T) attribute.Value) map[string]interface{} {
+	values := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		values[i] = toOtlpValue(toValue(item))
+	}
+	return map[string]interface{}{"arrayValue": map[string]interface{}{"values": values}}
+}
+
+// toOtlpStatus converts the span status, the codes of OTLP are: 0 (unset), 1 (ok) and 2 (error).
+func toOtlpStatus(status sdktrace.Status) otlpStatus {
+	switch status.Code {
+	case codes.Ok:
+		return otlpStatus{Code: 1}
+	case codes.Error:
+		return otlpStatus{Code: 2, Message: status.Description}
+	default:
+		return otlpStatus{}
+	}
+}
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// VcrModeRecord records all API requests and responses to the cassette file.
+	VcrModeRecord = "record"
+	// VcrModeReplay serves the API responses from the cassette file without sending requests to the cloud.
+	VcrModeReplay = "replay"
+
+	defaultCassetteDir  = "testdata/cassettes"
+	defaultCassetteName = "default"
+)
+
+// ErrInteractionNotFound is returned in replay mode when no interaction in the cassette matches the request.
+var ErrInteractionNotFound = errors.New("no matching interaction is recorded in the cassette")
+
+var (
+	cassettes    = make(map[string]*Cassette)
+	cassetteLock sync.Mutex
+
+	// the query parameters of the signed URLs, such as the OBS signed URLs, which change with the time or contain the
+	// credentials, their values are redacted in the cassette and ignored when matching the requests
+	volatileQueryParams = []string{
+		"AccessKeyId", "AWSAccessKeyId", "Expires", "Signature", "x-obs-security-token", "X-Amz-Credential",
+		"X-Amz-Date", "X-Amz-Expires", "X-Amz-Signature", "X-Amz-Security-Token",
+	}
+)
+
+type cassetteNameKey struct{}
+
+// CassetteRequest is the request saved in the cassette, the sensitive headers and fields are redacted.
+type CassetteRequest struct {
+	Method  string   `json:"method"`
+	URL     string   `json:"url"`
+	Headers []string `json:"headers,omitempty"`
+	Body    string   `json:"body,omitempty"`
+}
+
+// CassetteResponse is the response saved in the cassette, the sensitive headers and fields are redacted.
+type CassetteResponse struct {
+	StatusCode int      `json:"status_code"`
+	Headers    []string `json:"headers,omitempty"`
+	Body       string   `json:"body,omitempty"`
+}
+
+// CassetteInteraction is a pair of the API request and response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette saves the API interactions in a JSON file, it records the interactions in record mode and serves
+// the responses from the file in replay mode.
+type Cassette struct {
+	Interactions []*CassetteInteraction `json:"interactions"`
+
+	mode   string
+	path   string
+	mu     sync.Mutex
+	played []bool
+}
+
+// GetVcrMode returns the VCR mode specified by the HW_VCR_MODE environment variable, an empty string means
+// the VCR is disabled.
+func GetVcrMode() string {
+	mode := strings.ToLower(os.Getenv("HW_VCR_MODE"))
+	if mode != VcrModeRecord && mode != VcrModeReplay {
+		return ""
+	}
+	return mode
+}
+
+// ContextWithCassetteName returns a copy of ctx which carries the name of the cassette used by the provider
+// configuration, such as the name of the acceptance test.
+func ContextWithCassetteName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, cassetteNameKey{}, name)
+}
+
+// CassetteNameFromContext returns the name of the cassette carried by ctx, or an empty string if it's not set.
+func CassetteNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(cassetteNameKey{}).(string)
+	return name
+}
+
+// getCassettePath returns the path of the cassette file, the HW_VCR_CASSETTE environment variable takes precedence.
+func getCassettePath(name string) string {
+	if path := os.Getenv("HW_VCR_CASSETTE"); path != "" {
+		return path
+	}
+
+	if name == "" {
+		name = defaultCassetteName
+	}
+	// the sub-tests are saved in the directory of the parent test
+	return filepath.Join(defaultCassetteDir, filepath.FromSlash(name)+".json")
+}
+
+// LoadCassette returns the cassette with the specified name in the current VCR mode, or nil if the VCR is disabled.
+// The provider configurations with the same cassette name share the same cassette.
+func LoadCassette(name string) (*Cassette, error) {
+	mode := GetVcrMode()
+	if mode == "" {
+		return nil, nil
+	}
+
+	cassetteLock.Lock()
+	defer cassetteLock.Unlock()
+
+	path := getCassettePath(name)
+	if c, ok := cassettes[path]; ok {
+		return c, nil
+	}
+
+	c := &Cassette{
+		mode: mode,
+		path: path,
+	}
+	if mode == VcrModeReplay {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cassette in replay mode: %s", err)
+		}
+		if err := json.Unmarshal(content, c); err != nil {
+			return nil, fmt.Errorf("failed to parse the cassette %s: %s", path, err)
+		}
+		c.played = make([]bool, len(c.Interactions))
+	}
+
+	log.Printf("[DEBUG] the VCR is enabled in %s mode, cassette: %s", mode, path)
+	cassettes[path] = c
+	return c, nil
+}
+
+// Transport returns a round tripper which records or replays the requests of rt with the cassette.
+// It returns rt if the cassette is nil.
+func (c *Cassette) Transport(rt http.RoundTripper) http.RoundTripper {
+	if c == nil {
+		return rt
+	}
+	return &cassetteRoundTripper{
+		Rt:       rt,
+		cassette: c,
+	}
+}
+
+type cassetteRoundTripper struct {
+	Rt       http.RoundTripper
+	cassette *Cassette
+}
+
+func (crt *cassetteRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if crt.cassette.mode == VcrModeReplay {
+		return crt.cassette.replay(request)
+	}
+
+	var reqBody []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	response, err := crt.Rt.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	var respBody []byte
+	if response.Body != nil {
+		respBody, err = io.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if recordErr := crt.cassette.record(request, reqBody, response, respBody); recordErr != nil {
+		log.Printf("[WARN] failed to record the API interaction to cassette %s: %s", crt.cassette.path, recordErr)
+	}
+	return response, nil
+}
+
+// record appends the interaction to the cassette and saves the cassette file.
+func (c *Cassette) record(request *http.Request, reqBody []byte, response *http.Response, respBody []byte) error {
+	interaction := &CassetteInteraction{
+		Request: CassetteRequest{
+			Method:  request.Method,
+			URL:     cassetteURL(request.URL),
+			Headers: redactCassetteHeaders(request.Header),
+			Body:    redactCassetteBody(reqBody),
+		},
+		Response: CassetteResponse{
+			StatusCode: response.StatusCode,
+			Headers:    redactCassetteHeaders(response.Header),
+			Body:       redactCassetteBody(respBody),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Interactions = append(c.Interactions, interaction)
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, content, 0o600)
+}
+
+// replay returns the response of the first interaction which is not played and matches the method and URL.
+func (c *Cassette) replay(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		request.Body.Close()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	url := cassetteURL(request.URL)
+	for i, interaction := range c.Interactions {
+		if c.played[i] || interaction.Request.Method != request.Method || interaction.Request.URL != url {
+			continue
+		}
+
+		c.played[i] = true
+		header := make(http.Header)
+		for _, h := range interaction.Response.Headers {
+			if name, value, ok := strings.Cut(h, ": "); ok {
+				header.Add(name, value)
+			}
+		}
+		statusCode := interaction.Response.StatusCode
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			StatusCode:    statusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s in %s", ErrInteractionNotFound, request.Method, url, c.path)
+}
+
+// cassetteURL returns the URL saved in the cassette, the values of the volatile query parameters are redacted.
+func cassetteURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, param := range volatileQueryParams {
+		if query.Has(param) {
+			query.Set(param, "***")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return clone.String()
+}
+
+// redactCassetteHeaders returns the sorted headers with the sensitive values redacted by RedactHeaders.
+func redactCassetteHeaders(headers http.Header) []string {
+	if len(headers) == 0 {
+		return nil
+	}
+	return strings.Split(FormatHeaders(headers, "\n"), "\n")
+}
+
+// redactCassetteBody masks the sensitive fields of the JSON body by maskSecurityFields, the other bodies are
+// saved as they are.
+func redactCassetteBody(body []byte) string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return string(body)
+	}
+
+	maskSecurityFields(data)
+	redacted, err := json.Marshal(data)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
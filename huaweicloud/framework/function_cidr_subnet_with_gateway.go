@@ -0,0 +1,106 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &cidrSubnetWithGatewayFunction{}
+
+	cidrSubnetWithGatewayAttrTypes = map[string]attr.Type{
+		"cidr":       types.StringType,
+		"gateway_ip": types.StringType,
+	}
+)
+
+type cidrSubnetWithGatewayFunction struct{}
+
+// NewCidrSubnetWithGatewayFunction returns the function which calculates a subnet CIDR and its gateway IP.
+func NewCidrSubnetWithGatewayFunction() function.Function {
+	return &cidrSubnetWithGatewayFunction{}
+}
+
+func (f *cidrSubnetWithGatewayFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnet_with_gateway"
+}
+
+func (f *cidrSubnetWithGatewayFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Calculate a subnet CIDR and its gateway IP",
+		Description: "Calculates a subnet CIDR within the given IP network address prefix in the same way as the " +
+			"built-in cidrsubnet function, and returns it together with the first usable IP address as the " +
+			"gateway IP, e.g. 172.16.1.0/24 and 172.16.1.1.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "The IP network address prefix in CIDR notation, e.g. 172.16.0.0/16.",
+			},
+			function.Int64Parameter{
+				Name:        "newbits",
+				Description: "The number of additional bits with which to extend the prefix.",
+			},
+			function.Int64Parameter{
+				Name:        "netnum",
+				Description: "The whole number which is used to populate the additional bits added to the prefix.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: cidrSubnetWithGatewayAttrTypes,
+		},
+	}
+}
+
+func (f *cidrSubnetWithGatewayFunction) Run(ctx context.Context, req function.RunRequest,
+	resp *function.RunResponse) {
+	var (
+		prefix          string
+		newbits, netnum int64
+	)
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &prefix, &newbits, &netnum))
+	if resp.Error != nil {
+		return
+	}
+
+	subnetCidr, gatewayIp, err := cidrSubnetWithGateway(prefix, int(newbits), int(netnum))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(cidrSubnetWithGatewayAttrTypes, map[string]attr.Value{
+		"cidr":       types.StringValue(subnetCidr),
+		"gateway_ip": types.StringValue(gatewayIp),
+	})
+	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+func cidrSubnetWithGateway(prefix string, newbits, netnum int) (subnetCidr, gatewayIp string, err error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid CIDR expression (%s): %s", prefix, err)
+	}
+
+	subnet, err := cidr.Subnet(network, newbits, netnum)
+	if err != nil {
+		return "", "", fmt.Errorf("error calculating the subnet of %s: %s", prefix, err)
+	}
+
+	gateway, err := cidr.Host(subnet, 1)
+	if err != nil {
+		return "", "", fmt.Errorf("error calculating the gateway IP of %s: %s", subnet, err)
+	}
+	return subnet.String(), gateway.String(), nil
+}
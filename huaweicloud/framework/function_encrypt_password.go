@@ -0,0 +1,60 @@
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var _ function.Function = &encryptPasswordFunction{}
+
+type encryptPasswordFunction struct{}
+
+// NewEncryptPasswordFunction returns the function which encrypts a password in the format of the ECS user data.
+func NewEncryptPasswordFunction() function.Function {
+	return &encryptPasswordFunction{}
+}
+
+func (f *encryptPasswordFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "encrypt_password"
+}
+
+func (f *encryptPasswordFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encrypt a password with SHA512 crypt",
+		Description: "Encrypts the password with the given salt using SHA512 crypt and returns the Base64 encoded " +
+			"result, which can be used as the encrypted password of the ECS and BMS instances. " +
+			"The result is always the same for the same password and salt.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "password",
+				Description: "The plaintext password to encrypt.",
+			},
+			function.StringParameter{
+				Name: "salt",
+				Description: "The salt of SHA512 crypt, which contains 1 to 16 characters of letters, digits, " +
+					"dots (.) and slashes (/).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *encryptPasswordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var password, salt string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &password, &salt))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := utils.PasswordEncryptWithSalt(password, salt)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,54 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var _ function.Function = &normalizeJsonFunction{}
+
+type normalizeJsonFunction struct{}
+
+// NewNormalizeJsonFunction returns the function which normalizes a JSON string.
+func NewNormalizeJsonFunction() function.Function {
+	return &normalizeJsonFunction{}
+}
+
+func (f *normalizeJsonFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_json"
+}
+
+func (f *normalizeJsonFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a JSON string",
+		Description: "Removes the insignificant whitespaces and sorts the object keys of a JSON string, so that the " +
+			"semantically equal JSON strings are the same after normalization.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON string to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeJsonFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jsonString string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jsonString))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := utils.NormalizeJsonString(jsonString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid JSON string: %s", err))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,72 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseImportIdFunction{}
+
+type parseImportIdFunction struct{}
+
+// NewParseImportIdFunction returns the function which parses a composite import ID.
+func NewParseImportIdFunction() function.Function {
+	return &parseImportIdFunction{}
+}
+
+func (f *parseImportIdFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+func (f *parseImportIdFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a composite import ID",
+		Description: "Splits a composite import ID, such as '<instance_id>/<database_name>', into its parts. " +
+			"The optional parts count is used to check the format of the ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The composite import ID separated by slashes (/).",
+			},
+		},
+		VariadicParameter: function.Int64Parameter{
+			Name:        "parts_count",
+			Description: "The expected number of the parts, only the first value is used.",
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *parseImportIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		importId   string
+		partsCount []int64
+	)
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &importId, &partsCount))
+	if resp.Error != nil {
+		return
+	}
+
+	parts := strings.Split(importId, "/")
+	if len(partsCount) > 0 && int64(len(parts)) != partsCount[0] {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid format of import ID (%s), want %d "+
+			"parts separated by slashes (/), but got %d", importId, partsCount[0], len(parts)))
+		return
+	}
+	for i, part := range parts {
+		if part == "" {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid format of import ID (%s), "+
+				"the part %d is empty", importId, i+1))
+			return
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parts))
+}
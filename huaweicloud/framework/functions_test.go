@@ -0,0 +1,123 @@
+package framework
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+
+	result, err := definitionResp.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatalf("error creating the result data: %s", err)
+	}
+
+	resp := &function.RunResponse{
+		Result: result,
+	}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestNormalizeJsonFunction(t *testing.T) {
+	result, err := runFunction(t, NewNormalizeJsonFunction(), types.StringValue("{\n\"key2\": \"value2\",\n\"key1\": 1\n}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := types.StringValue(`{"key1":1,"key2":"value2"}`)
+	if !result.Equal(expected) {
+		t.Fatalf("The result of normalize_json is not as expected, want %s, but %s", expected, result)
+	}
+
+	if _, err = runFunction(t, NewNormalizeJsonFunction(), types.StringValue("{invalid")); err == nil {
+		t.Fatal("The invalid JSON string is expected to fail")
+	}
+}
+
+func TestCidrSubnetWithGatewayFunction(t *testing.T) {
+	result, err := runFunction(t, NewCidrSubnetWithGatewayFunction(), types.StringValue("172.16.0.0/16"),
+		types.Int64Value(8), types.Int64Value(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected, _ := types.ObjectValue(cidrSubnetWithGatewayAttrTypes, map[string]attr.Value{
+		"cidr":       types.StringValue("172.16.10.0/24"),
+		"gateway_ip": types.StringValue("172.16.10.1"),
+	})
+	if !result.Equal(expected) {
+		t.Fatalf("The result of cidr_subnet_with_gateway is not as expected, want %s, but %s", expected, result)
+	}
+
+	if _, err = runFunction(t, NewCidrSubnetWithGatewayFunction(), types.StringValue("172.16.0.0/16"),
+		types.Int64Value(8), types.Int64Value(256)); err == nil {
+		t.Fatal("The netnum out of range is expected to fail")
+	}
+}
+
+func TestEncryptPasswordFunction(t *testing.T) {
+	result, err := runFunction(t, NewEncryptPasswordFunction(), types.StringValue("Test@123!"),
+		types.StringValue("Rb3Uq5n.0/hZ"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, ok := result.(types.String)
+	if !ok {
+		t.Fatalf("The result of encrypt_password is not as expected: %s", result)
+	}
+	decoded, decodeErr := base64.StdEncoding.DecodeString(v.ValueString())
+	if decodeErr != nil || !strings.HasPrefix(string(decoded), "$6$Rb3Uq5n.0/hZ$") {
+		t.Fatalf("The result of encrypt_password is not as expected: %s", result)
+	}
+
+	// the result must be the same for the same password and salt
+	again, err := runFunction(t, NewEncryptPasswordFunction(), types.StringValue("Test@123!"),
+		types.StringValue("Rb3Uq5n.0/hZ"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !again.Equal(result) {
+		t.Fatalf("The result of encrypt_password is not stable, first %s, then %s", result, again)
+	}
+
+	for _, salt := range []string{"", "invalid$salt", "the-salt-is-too-long"} {
+		if _, err = runFunction(t, NewEncryptPasswordFunction(), types.StringValue("Test@123!"),
+			types.StringValue(salt)); err == nil {
+			t.Fatalf("The salt (%s) is expected to fail", salt)
+		}
+	}
+}
+
+func TestParseImportIdFunction(t *testing.T) {
+	partsCount, _ := types.TupleValue([]attr.Type{types.Int64Type}, []attr.Value{types.Int64Value(2)})
+	result, err := runFunction(t, NewParseImportIdFunction(), types.StringValue("instance-id/db-name"), partsCount)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var parts []string
+	if diags := result.(types.List).ElementsAs(context.Background(), &parts, false); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if expected := []string{"instance-id", "db-name"}; !reflect.DeepEqual(parts, expected) {
+		t.Fatalf("The result of parse_import_id is not as expected, want %v, but %v", expected, parts)
+	}
+
+	for _, id := range []string{"instance-id", "instance-id/db-name/extra", "instance-id/"} {
+		if _, err = runFunction(t, NewParseImportIdFunction(), types.StringValue(id), partsCount); err == nil {
+			t.Fatalf("The import ID (%s) is expected to fail", id)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Package framework provides the HuaweiCloud provider implemented with terraform-plugin-framework.
+// It is muxed with the SDKv2 provider, and is used to ship the capabilities which are only supported by the
+// framework, such as provider-defined functions and ephemeral resources.
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dew"
+)
+
+var (
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+type frameworkProvider struct {
+	// primary is the SDKv2 provider, the framework provider shares its schema and configuration.
+	primary *sdkschema.Provider
+}
+
+// New returns a framework provider which shares the schema and configuration with the SDKv2 provider.
+func New(primary *sdkschema.Provider) provider.Provider {
+	return &frameworkProvider{
+		primary: primary,
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "huaweicloud"
+}
+
+// Schema returns the same schema as the SDKv2 provider, because the muxed providers must have the identical schema.
+func (p *frameworkProvider) Schema(ctx context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	s, diags := providerSchemaFromSDK(ctx, p.primary)
+	resp.Diagnostics.Append(diags...)
+	resp.Schema = s
+}
+
+// Configure shares the config built by the SDKv2 provider, it's always configured before the framework provider.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta := p.primary.Meta()
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		dew.NewCsmsSecretVersionEphemeral,
+		dew.NewKmsDataKeyEphemeral,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCidrSubnetWithGatewayFunction,
+		NewEncryptPasswordFunction,
+		NewNormalizeJsonFunction,
+		NewParseImportIdFunction,
+	}
+}
@@ -0,0 +1,246 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerSchemaFromSDK builds the framework provider schema from the protocol schema of the SDKv2 provider.
+func providerSchemaFromSDK(ctx context.Context, primary *sdkschema.Provider) (schema.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	resp, err := primary.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		diags.AddError("Error getting the schema of the SDKv2 provider", err.Error())
+		return schema.Schema{}, diags
+	}
+	if resp.Provider == nil || resp.Provider.Block == nil {
+		return schema.Schema{}, diags
+	}
+
+	attributes, blocks, err := convertSchemaBlock(resp.Provider.Block, primary.Schema)
+	if err != nil {
+		diags.AddError("Error converting the schema of the SDKv2 provider", err.Error())
+		return schema.Schema{}, diags
+	}
+
+	return schema.Schema{
+		Attributes:  attributes,
+		Blocks:      blocks,
+		Description: resp.Provider.Block.Description,
+	}, diags
+}
+
+// convertSchemaBlock converts the protocol schema block to the framework schema, the SDKv2 schema of the block is
+// used to carry over the deprecation messages, which are not included in the protocol schema.
+func convertSchemaBlock(block *tfprotov5.SchemaBlock,
+	sdkSchemas map[string]*sdkschema.Schema) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attribute, err := convertSchemaAttribute(a, sdkSchemas[a.Name])
+		if err != nil {
+			return nil, nil, err
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := make(map[string]schema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		sdkBlock := sdkSchemas[b.TypeName]
+		var nestedSchemas map[string]*sdkschema.Schema
+		if sdkBlock != nil {
+			if elem, ok := sdkBlock.Elem.(*sdkschema.Resource); ok {
+				nestedSchemas = elem.Schema
+			}
+		}
+
+		nestedAttributes, nestedBlocks, err := convertSchemaBlock(b.Block, nestedSchemas)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nestedObject := schema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		deprecation := deprecationMessage(b.Block.Deprecated, sdkBlock)
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			listBlock := schema.ListNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        b.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+			if b.MaxItems > 0 {
+				listBlock.Validators = []validator.List{blockSizeAtMost(b.MaxItems)}
+			}
+			blocks[b.TypeName] = listBlock
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			setBlock := schema.SetNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        b.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+			if b.MaxItems > 0 {
+				setBlock.Validators = []validator.Set{blockSizeAtMost(b.MaxItems)}
+			}
+			blocks[b.TypeName] = setBlock
+		default:
+			return nil, nil, fmt.Errorf("unsupported nesting mode %s of block %s", b.Nesting, b.TypeName)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func convertSchemaAttribute(a *tfprotov5.SchemaAttribute, sdkAttribute *sdkschema.Schema) (schema.Attribute, error) {
+	deprecation := deprecationMessage(a.Deprecated, sdkAttribute)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return schema.StringAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	case a.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	case a.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	case a.Type.Is(tftypes.List{}):
+		elemType, err := convertElementType(a.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %s: %s", a.Name, err)
+		}
+		return schema.ListAttribute{
+			ElementType:        elemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	case a.Type.Is(tftypes.Set{}):
+		elemType, err := convertElementType(a.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %s: %s", a.Name, err)
+		}
+		return schema.SetAttribute{
+			ElementType:        elemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	case a.Type.Is(tftypes.Map{}):
+		elemType, err := convertElementType(a.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %s: %s", a.Name, err)
+		}
+		return schema.MapAttribute{
+			ElementType:        elemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecation,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s of attribute %s", a.Type, a.Name)
+}
+
+func convertElementType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+	return nil, fmt.Errorf("unsupported element type %s", t)
+}
+
+// deprecationMessage returns the deprecation message of the attribute or block in the SDKv2 schema, the protocol
+// schema only carries the flag, and the framework marks the schema as deprecated when the message is not empty.
+func deprecationMessage(deprecated bool, sdkSchema *sdkschema.Schema) string {
+	if !deprecated {
+		return ""
+	}
+	if sdkSchema != nil && sdkSchema.Deprecated != "" {
+		return sdkSchema.Deprecated
+	}
+	return "This argument is deprecated."
+}
+
+var (
+	_ validator.List = blockSizeAtMostValidator{}
+	_ validator.Set  = blockSizeAtMostValidator{}
+)
+
+// blockSizeAtMostValidator validates the maximum number of the nested blocks, the MaxItems of the protocol schema is
+// not supported by the framework blocks.
+type blockSizeAtMostValidator struct {
+	maxItems int64
+}
+
+func blockSizeAtMost(maxItems int64) blockSizeAtMostValidator {
+	return blockSizeAtMostValidator{maxItems: maxItems}
+}
+
+func (v blockSizeAtMostValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("at most %d blocks are allowed", v.maxItems)
+}
+
+func (v blockSizeAtMostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v blockSizeAtMostValidator) validate(p path.Path, count int, diags *diag.Diagnostics) {
+	if int64(count) > v.maxItems {
+		diags.AddAttributeError(p, "Too many blocks",
+			fmt.Sprintf("No more than %d %q blocks are allowed, but got %d.", v.maxItems, p, count))
+	}
+}
+
+func (v blockSizeAtMostValidator) ValidateList(_ context.Context, req validator.ListRequest,
+	resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.Path, len(req.ConfigValue.Elements()), &resp.Diagnostics)
+}
+
+func (v blockSizeAtMostValidator) ValidateSet(_ context.Context, req validator.SetRequest,
+	resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.Path, len(req.ConfigValue.Elements()), &resp.Diagnostics)
+}
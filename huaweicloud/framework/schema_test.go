@@ -0,0 +1,55 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderSchemaFromSDK(t *testing.T) {
+	primary := &sdkschema.Provider{
+		Schema: map[string]*sdkschema.Schema{
+			"region": {
+				Type:       sdkschema.TypeString,
+				Optional:   true,
+				Deprecated: "use the region of the resources instead",
+			},
+			"assume_role": {
+				Type:     sdkschema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &sdkschema.Resource{
+					Schema: map[string]*sdkschema.Schema{
+						"agency_name": {
+							Type:       sdkschema.TypeString,
+							Optional:   true,
+							Deprecated: "use agency instead",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s, diags := providerSchemaFromSDK(context.Background(), primary)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if msg := s.Attributes["region"].GetDeprecationMessage(); msg != "use the region of the resources instead" {
+		t.Fatalf("The deprecation message of region is not as expected: %s", msg)
+	}
+
+	block, ok := s.Blocks["assume_role"].(schema.ListNestedBlock)
+	if !ok {
+		t.Fatalf("The assume_role is not converted to a list block: %#v", s.Blocks["assume_role"])
+	}
+	if len(block.Validators) != 1 || block.Validators[0].Description(context.Background()) != "at most 1 blocks are allowed" {
+		t.Fatalf("The MaxItems of assume_role is not carried over: %#v", block.Validators)
+	}
+	if msg := block.NestedObject.Attributes["agency_name"].GetDeprecationMessage(); msg != "use agency instead" {
+		t.Fatalf("The deprecation message of assume_role.agency_name is not as expected: %s", msg)
+	}
+}
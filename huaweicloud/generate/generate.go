@@ -0,0 +1,386 @@
+// Package generate writes the import blocks and the resource configurations of the existing cloud resources, which are
+// found by the plural data sources, so that the resources created outside of Terraform can be imported in bulk.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Options are the options of the generation.
+type Options struct {
+	// ResourceTypes are the resource types to generate, all supported types are generated if it's empty
+	ResourceTypes []string
+}
+
+// foundResource is a resource found by the data source and read by the resource.
+type foundResource struct {
+	ImportID string
+	Data     *schema.ResourceData
+}
+
+type generator struct {
+	provider *schema.Provider
+	meta     interface{}
+	found    map[string][]foundResource
+	labels   map[string]bool
+}
+
+// Generate finds the existing resources of the types and writes their import blocks and configurations to w.
+// The provider should be configured, the resources which fail to be read are logged and skipped.
+func Generate(ctx context.Context, p *schema.Provider, w io.Writer, opts Options) error {
+	resourceTypes := opts.ResourceTypes
+	if len(resourceTypes) == 0 {
+		resourceTypes = SupportedTypes()
+	}
+
+	g := &generator{
+		provider: p,
+		meta:     p.Meta(),
+		found:    make(map[string][]foundResource),
+		labels:   make(map[string]bool),
+	}
+
+	file := hclwrite.NewEmptyFile()
+	for _, resourceType := range resourceTypes {
+		gen := GetGenerator(resourceType)
+		if gen == nil {
+			return fmt.Errorf("the resource type %s is not supported, the supported types are: %s", resourceType,
+				strings.Join(SupportedTypes(), ", "))
+		}
+
+		resources, err := g.list(ctx, gen)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			g.render(file.Body(), gen.ResourceType, r)
+		}
+	}
+
+	_, err := w.Write(hclwrite.Format(file.Bytes()))
+	return err
+}
+
+// SupportedTypes returns the supported resource types.
+func SupportedTypes() []string {
+	result := make([]string, len(Generators))
+	for i, g := range Generators {
+		result[i] = g.ResourceType
+	}
+	return result
+}
+
+// list returns the resources of the generator, the resources of each type are only listed once.
+func (g *generator) list(ctx context.Context, gen *Generator) ([]foundResource, error) {
+	if resources, ok := g.found[gen.ResourceType]; ok {
+		return resources, nil
+	}
+
+	parentIDs := []string{""}
+	if gen.Parent != "" {
+		parents, err := g.list(ctx, GetGenerator(gen.Parent))
+		if err != nil {
+			return nil, err
+		}
+		parentIDs = make([]string, 0, len(parents))
+		for _, parent := range parents {
+			if gen.ParentFilter == nil || gen.ParentFilter(parent.Data) {
+				parentIDs = append(parentIDs, parent.Data.Id())
+			}
+		}
+	}
+
+	resources := make([]foundResource, 0)
+	for _, parentID := range parentIDs {
+		items, err := g.readDataSource(ctx, gen, parentID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			importID := gen.ImportID(parentID, item)
+			d, err := g.importResource(ctx, gen.ResourceType, importID)
+			if err != nil {
+				log.Printf("[WARN] skip %s (%s): %s", gen.ResourceType, importID, err)
+				continue
+			}
+			if d != nil {
+				resources = append(resources, foundResource{ImportID: importID, Data: d})
+			}
+		}
+	}
+
+	g.found[gen.ResourceType] = resources
+	return resources, nil
+}
+
+// readDataSource reads the plural data source and returns the items.
+func (g *generator) readDataSource(ctx context.Context, gen *Generator, parentID string) ([]map[string]interface{}, error) {
+	ds, ok := g.provider.DataSourcesMap[gen.DataSource]
+	if !ok {
+		return nil, fmt.Errorf("the data source %s is not found", gen.DataSource)
+	}
+
+	raw := make(map[string]interface{})
+	if gen.ParentArgument != "" {
+		raw[gen.ParentArgument] = parentID
+	}
+	d, err := newResourceData(ctx, ds, raw)
+	if err != nil {
+		return nil, err
+	}
+	if diags := readResource(ctx, ds, d, g.meta); diags.HasError() {
+		return nil, fmt.Errorf("error reading data source %s: %s", gen.DataSource, diagsToString(diags))
+	}
+
+	rawItems, _ := d.Get(gen.ItemsKey).([]interface{})
+	items := make([]map[string]interface{}, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		if item, ok := rawItem.(map[string]interface{}); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// importResource imports and reads the resource, nil is returned if the resource is not found.
+func (g *generator) importResource(ctx context.Context, resourceType, importID string) (*schema.ResourceData, error) {
+	r, ok := g.provider.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("the resource %s is not found", resourceType)
+	}
+
+	d := r.Data(nil)
+	d.SetId(importID)
+	states := []*schema.ResourceData{d}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		var err error
+		states, err = r.Importer.StateContext(ctx, d, g.meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	d = states[0]
+	if diags := readResource(ctx, r, d, g.meta); diags.HasError() {
+		return nil, fmt.Errorf("%s", diagsToString(diags))
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// newResourceData returns the resource data of the raw configuration, the default values are applied.
+func newResourceData(ctx context.Context, r *schema.Resource, raw map[string]interface{}) (*schema.ResourceData, error) {
+	sm := schema.InternalMap(r.Schema)
+	diff, err := sm.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return sm.Data(nil, diff)
+}
+
+func readResource(ctx context.Context, r *schema.Resource, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	switch {
+	case r.ReadContext != nil:
+		return r.ReadContext(ctx, d, meta)
+	case r.ReadWithoutTimeout != nil:
+		return r.ReadWithoutTimeout(ctx, d, meta)
+	case r.Read != nil:
+		return diag.FromErr(r.Read(d, meta))
+	default:
+		return diag.Errorf("the read function is not found")
+	}
+}
+
+func diagsToString(diags diag.Diagnostics) string {
+	messages := make([]string, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			messages = append(messages, d.Summary)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// render appends the import block and the resource block.
+func (g *generator) render(body *hclwrite.Body, resourceType string, r foundResource) {
+	label := g.newLabel(resourceType, r)
+
+	importBody := body.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(r.ImportID))
+	body.AppendNewline()
+
+	resourceSchema := g.provider.ResourcesMap[resourceType].Schema
+	values := make(map[string]interface{}, len(resourceSchema))
+	for k := range resourceSchema {
+		values[k] = r.Data.Get(k)
+	}
+	resourceBody := body.AppendNewBlock("resource", []string{resourceType, label}).Body()
+	renderSchema(resourceBody, resourceSchema, values, "", make(map[string]bool))
+	body.AppendNewline()
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// newLabel returns a unique label of the resource which is derived from its name, or its ID if it has no name.
+func (g *generator) newLabel(resourceType string, r foundResource) string {
+	name, _ := r.Data.Get("name").(string)
+	if name == "" {
+		name = r.Data.Id()
+	}
+	label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') || label[0] == '-' {
+		label = "r_" + label
+	}
+
+	unique := label
+	for i := 2; g.labels[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	g.labels[resourceType+"."+unique] = true
+	return unique
+}
+
+// renderSchema writes the configurable arguments in the alphabet order. The computed-only, deprecated and sensitive
+// attributes, the empty optional arguments and the arguments which are equal to their defaults are skipped, so do the
+// arguments which conflict with the rendered ones. The required sensitive arguments are rendered as null with a TODO
+// comment, so that they're not missed. The prefix is the path of the nested block, e.g. "block.0.", and the rendered
+// records the full paths of the rendered arguments.
+func renderSchema(body *hclwrite.Body, sm map[string]*schema.Schema, values map[string]interface{}, prefix string,
+	rendered map[string]bool) {
+	keys := make([]string, 0, len(sm))
+	for k := range sm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := sm[k]
+		v := values[k]
+		if s.Required && s.Sensitive {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte(fmt.Sprintf("# TODO: %s is sensitive and not returned by the API, please specify it\n", k)),
+			}})
+			body.SetAttributeValue(k, cty.NullVal(cty.String))
+			rendered[prefix+k] = true
+			continue
+		}
+		if (!s.Required && !s.Optional) || s.Deprecated != "" || s.Sensitive {
+			continue
+		}
+		if !s.Required && ((s.Default != nil && reflect.DeepEqual(v, s.Default)) || (s.Default == nil && isEmpty(v))) {
+			continue
+		}
+		if conflictsWithRendered(s, rendered) {
+			continue
+		}
+		rendered[prefix+k] = true
+
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			for i, rawItem := range toList(v) {
+				item, _ := rawItem.(map[string]interface{})
+				renderSchema(body.AppendNewBlock(k, nil).Body(), elem.Schema, item, fmt.Sprintf("%s%s.%d.", prefix, k, i),
+					rendered)
+			}
+			continue
+		}
+		body.SetAttributeValue(k, toCtyValue(v))
+	}
+}
+
+// conflictsWithRendered checks whether the argument conflicts with the rendered ones, the keys of ConflictsWith and
+// ExactlyOneOf are the full paths, e.g. "block.0.key" for the nested arguments.
+func conflictsWithRendered(s *schema.Schema, rendered map[string]bool) bool {
+	for _, key := range append(append([]string{}, s.ConflictsWith...), s.ExactlyOneOf...) {
+		if rendered[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if set, ok := v.(*schema.Set); ok {
+		return set.Len() == 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+func toList(v interface{}) []interface{} {
+	switch val := v.(type) {
+	case *schema.Set:
+		return val.List()
+	case []interface{}:
+		return val
+	default:
+		return nil
+	}
+}
+
+func toCtyValue(v interface{}) cty.Value {
+	switch val := v.(type) {
+	case string:
+		return cty.StringVal(val)
+	case int:
+		return cty.NumberIntVal(int64(val))
+	case float64:
+		return cty.NumberFloatVal(val)
+	case bool:
+		return cty.BoolVal(val)
+	case *schema.Set, []interface{}:
+		items := toList(val)
+		if len(items) == 0 {
+			return cty.EmptyTupleVal
+		}
+		values := make([]cty.Value, len(items))
+		for i, item := range items {
+			values[i] = toCtyValue(item)
+		}
+		return cty.TupleVal(values)
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return cty.EmptyObjectVal
+		}
+		values := make(map[string]cty.Value, len(val))
+		for k, item := range val {
+			values[k] = toCtyValue(item)
+		}
+		return cty.ObjectVal(values)
+	default:
+		return cty.StringVal(fmt.Sprint(val))
+	}
+}
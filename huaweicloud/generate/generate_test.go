@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestGenerate(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+
+	client, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	for _, name := range []string{"vpc-a", "vpc-a", "1st vpc"} {
+		_, err := vpcs.Create(client, vpcs.CreateOpts{Name: name, CIDR: "192.168.0.0/16"}).Extract()
+		th.AssertNoErr(t, err)
+	}
+
+	p := huaweicloud.Provider()
+	p.SetMeta(cfg)
+	var out strings.Builder
+	th.AssertNoErr(t, Generate(context.Background(), p, &out, Options{
+		ResourceTypes: []string{"huaweicloud_vpc"},
+	}))
+
+	content := out.String()
+	th.AssertEquals(t, 3, strings.Count(content, "import {"))
+	th.AssertEquals(t, true, strings.Contains(content, "to = huaweicloud_vpc.vpc-a\n"))
+	th.AssertEquals(t, true, strings.Contains(content, "to = huaweicloud_vpc.vpc-a_2\n"))
+	th.AssertEquals(t, true, strings.Contains(content, `resource "huaweicloud_vpc" "r_1st_vpc" {`))
+	th.AssertEquals(t, true, strings.Contains(content, `name                  = "1st vpc"`))
+	// the computed-only attributes are not rendered
+	th.AssertEquals(t, false, strings.Contains(content, "status"))
+
+	err = Generate(context.Background(), p, &out, Options{ResourceTypes: []string{"huaweicloud_foo"}})
+	th.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "huaweicloud_foo is not supported"))
+}
+
+func TestRenderSchema(t *testing.T) {
+	sm := map[string]*schema.Schema{
+		"admin_pass": {Type: schema.TypeString, Required: true, Sensitive: true},
+		"name":       {Type: schema.TypeString, Optional: true},
+		"port":       {Type: schema.TypeInt, Optional: true, ConflictsWith: []string{"network.0.port"}},
+		"network": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Optional: true},
+					"port": {Type: schema.TypeInt, Optional: true},
+					// only conflicts with the top-level name, not the name of the same block
+					"vip": {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"name"}},
+				},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"name": "",
+		"port": 8080,
+		"network": []interface{}{
+			map[string]interface{}{"name": "net", "port": 80, "vip": "192.168.0.10"},
+		},
+	}
+
+	file := hclwrite.NewEmptyFile()
+	renderSchema(file.Body(), sm, values, "", make(map[string]bool))
+	content := string(file.Bytes())
+
+	th.AssertEquals(t, true, strings.Contains(content, "# TODO: admin_pass is sensitive"))
+	th.AssertEquals(t, true, strings.Contains(content, "admin_pass = null"))
+	th.AssertEquals(t, true, strings.Contains(content, `vip  = "192.168.0.10"`))
+	th.AssertEquals(t, true, strings.Contains(content, "port = 80"))
+	// the top-level port is skipped as it conflicts with the rendered network.0.port
+	th.AssertEquals(t, false, strings.Contains(content, "8080"))
+}
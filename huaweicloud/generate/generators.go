@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Generator describes how to find the existing resources of a type by its plural data source.
+type Generator struct {
+	// ResourceType is the type of the resources to generate, e.g. huaweicloud_vpc
+	ResourceType string
+	// DataSource is the plural data source which lists the resources, e.g. huaweicloud_vpcs
+	DataSource string
+	// ItemsKey is the attribute of the data source which contains the resources
+	ItemsKey string
+	// Parent is the resource type of the parent resources, the data source is read once for each parent
+	Parent string
+	// ParentArgument is the argument of the data source which is set to the parent ID
+	ParentArgument string
+	// ParentFilter returns false if the parent has no resources of the type, it's optional
+	ParentFilter func(parent *schema.ResourceData) bool
+	// ImportID returns the import ID of the resource, the parent ID is empty if there is no parent
+	ImportID func(parentID string, item map[string]interface{}) string
+}
+
+func importByID(_ string, item map[string]interface{}) string {
+	return fmt.Sprint(item["id"])
+}
+
+// Generators are the supported resource types, the parents are listed before their children.
+var Generators = []Generator{
+	{
+		ResourceType: "huaweicloud_vpc",
+		DataSource:   "huaweicloud_vpcs",
+		ItemsKey:     "vpcs",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_vpc_subnet",
+		DataSource:   "huaweicloud_vpc_subnets",
+		ItemsKey:     "subnets",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_networking_secgroup",
+		DataSource:   "huaweicloud_networking_secgroups",
+		ItemsKey:     "security_groups",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_compute_instance",
+		DataSource:   "huaweicloud_compute_instances",
+		ItemsKey:     "instances",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_evs_volume",
+		DataSource:   "huaweicloud_evs_volumes",
+		ItemsKey:     "volumes",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_elb_loadbalancer",
+		DataSource:   "huaweicloud_elb_loadbalancers",
+		ItemsKey:     "loadbalancers",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType: "huaweicloud_rds_instance",
+		DataSource:   "huaweicloud_rds_instances",
+		ItemsKey:     "instances",
+		ImportID:     importByID,
+	},
+	{
+		ResourceType:   "huaweicloud_rds_mysql_database",
+		DataSource:     "huaweicloud_rds_mysql_databases",
+		ItemsKey:       "databases",
+		Parent:         "huaweicloud_rds_instance",
+		ParentArgument: "instance_id",
+		ParentFilter: func(parent *schema.ResourceData) bool {
+			return strings.EqualFold(parent.Get("db.0.type").(string), "MySQL")
+		},
+		ImportID: func(parentID string, item map[string]interface{}) string {
+			return fmt.Sprintf("%s/%v", parentID, item["name"])
+		},
+	},
+}
+
+// GetGenerator returns the generator of the resource type, nil is returned if the type is not supported.
+func GetGenerator(resourceType string) *Generator {
+	for i := range Generators {
+		if Generators[i].ResourceType == resourceType {
+			return &Generators[i]
+		}
+	}
+	return nil
+}
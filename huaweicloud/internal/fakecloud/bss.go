@@ -0,0 +1,165 @@
+package fakecloud
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// The discounts of the account in the fake cloud, the pay-per-use products have a fixed discount, and the
+// yearly/monthly products have the optional discounts, e.g. the promotions.
+const (
+	onDemandDiscount = 0.9
+	hoursPerMonth    = 720
+)
+
+var periodDiscounts = []float64{0.95, 0.83}
+
+// hourlyPrices are the official hourly prices (in yuan) of the specifications in the fake cloud, the prices of the
+// volumes and the bandwidths are per GB and per Mbps.
+var hourlyPrices = map[string]float64{
+	"hws.resource.type.vm/s6.small.1.linux":         0.1,
+	"hws.resource.type.vm/s6.small.1.win":           0.2,
+	"hws.resource.type.volume/SSD":                  0.001,
+	"hws.resource.type.volume/SAS":                  0.0005,
+	"hws.resource.type.ip/5_bgp":                    0.02,
+	"hws.resource.type.bandwidth/19_bgp":            0.08,
+	"hws.resource.type.ip/5_sbgp":                   0.03,
+	"hws.resource.type.bandwidth/19_sbgp":           0.1,
+	"hws.resource.type.rds.vm/rds.mysql.n1.large.2": 0.8,
+	"hws.resource.type.rds.volume/CLOUDSSD":         0.002,
+}
+
+type productInfo struct {
+	ID           string `json:"id"`
+	ResourceType string `json:"resource_type"`
+	ResourceSpec string `json:"resource_spec"`
+	ResourceSize int    `json:"resource_size"`
+	PeriodType   int    `json:"period_type"`
+	PeriodNum    int    `json:"period_num"`
+	Subscription int    `json:"subscription_num"`
+}
+
+func (s *Server) registerBss(mux *http.ServeMux) {
+	s.handle(mux, "POST /v2/bills/ratings/on-demand-resources", s.rateOnDemandResources)
+	s.handle(mux, "POST /v2/bills/ratings/period-resources/subscribe-rate", s.ratePeriodResources)
+}
+
+// officialPrice returns the official price of the product for the hours.
+func (p productInfo) officialPrice(hours int) (float64, error) {
+	price, ok := hourlyPrices[p.ResourceType+"/"+p.ResourceSpec]
+	if !ok {
+		return 0, fmt.Errorf("the product (%s) is not on sale: %s", p.ID, p.ResourceSpec)
+	}
+	size := 1
+	if p.ResourceSize > 0 {
+		size = p.ResourceSize
+	}
+	return roundPrice(price * float64(size*p.Subscription*hours)), nil
+}
+
+// rateOnDemandResources returns the hourly prices of the pay-per-use products.
+func (s *Server) rateOnDemandResources(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		ProductInfos []productInfo `json:"product_infos"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	results := make([]interface{}, 0, len(body.ProductInfos))
+	var officialTotal, total float64
+	for _, product := range body.ProductInfos {
+		official, err := product.officialPrice(1)
+		if err != nil {
+			return http.StatusBadRequest, errorBody("CBC.99003651", err.Error())
+		}
+		amount := roundPrice(official * onDemandDiscount)
+		officialTotal += official
+		total += amount
+		results = append(results, map[string]interface{}{
+			"id":                      product.ID,
+			"official_website_amount": official,
+			"amount":                  amount,
+			"discount_amount":         roundPrice(official - amount),
+			"measure_id":              1,
+		})
+	}
+	return http.StatusOK, map[string]interface{}{
+		"currency":                "CNY",
+		"measure_id":              1,
+		"official_website_amount": roundPrice(officialTotal),
+		"amount":                  roundPrice(total),
+		"discount_amount":         roundPrice(officialTotal - total),
+		"product_rating_results":  results,
+	}
+}
+
+// ratePeriodResources returns the prices of the yearly/monthly products for the whole periods, the official prices
+// are in fen, and the discounted prices are in yuan.
+func (s *Server) ratePeriodResources(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		ProductInfos []productInfo `json:"product_infos"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	officialResults := make([]interface{}, 0, len(body.ProductInfos))
+	officialPrices := make([]float64, 0, len(body.ProductInfos))
+	var officialTotal float64
+	for _, product := range body.ProductInfos {
+		months := product.PeriodNum
+		if product.PeriodType == 3 {
+			months *= 12
+		}
+		official, err := product.officialPrice(months * hoursPerMonth)
+		if err != nil {
+			return http.StatusBadRequest, errorBody("CBC.99003651", err.Error())
+		}
+		officialPrices = append(officialPrices, official)
+		officialTotal += official
+		officialResults = append(officialResults, map[string]interface{}{
+			"id":                      product.ID,
+			"official_website_amount": roundPrice(official * 100),
+			"measure_id":              3,
+		})
+	}
+
+	discountResults := make([]interface{}, 0, len(periodDiscounts))
+	for i, discount := range periodDiscounts {
+		results := make([]interface{}, 0, len(body.ProductInfos))
+		for j, product := range body.ProductInfos {
+			amount := roundPrice(officialPrices[j] * discount)
+			results = append(results, map[string]interface{}{
+				"id":                      product.ID,
+				"official_website_amount": officialPrices[j],
+				"amount":                  amount,
+				"discount_amount":         roundPrice(officialPrices[j] - amount),
+				"measure_id":              1,
+			})
+		}
+		amount := roundPrice(officialTotal * discount)
+		discountResults = append(discountResults, map[string]interface{}{
+			"discount_id":             fmt.Sprintf("discount-%d", i),
+			"official_website_amount": roundPrice(officialTotal),
+			"amount":                  amount,
+			"discount_amount":         roundPrice(officialTotal - amount),
+			"measure_id":              1,
+			"product_rating_results":  results,
+		})
+	}
+	return http.StatusOK, map[string]interface{}{
+		"currency": "CNY",
+		"official_website_rating_result": map[string]interface{}{
+			"official_website_amount": roundPrice(officialTotal * 100),
+			"measure_id":              3,
+			"product_rating_results":  officialResults,
+		},
+		"optional_discount_rating_results": discountResults,
+	}
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*10000) / 10000
+}
@@ -0,0 +1,573 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kindPort is the kind of the NICs of the ECS instances, the ports are not exposed by the fake cloud.
+const kindPort = "ports"
+
+func (s *Server) registerEcs(mux *http.ServeMux) {
+	s.handle(mux, "POST /v1/{project_id}/cloudservers", s.createServers)
+	s.handle(mux, "POST /v1.1/{project_id}/cloudservers", s.createServers)
+	s.handle(mux, "GET /v1/{project_id}/cloudservers/detail", s.listServers)
+	s.handle(mux, "GET /v1/{project_id}/cloudservers/{server_id}", s.getServer)
+	s.handle(mux, "PUT /v1/{project_id}/cloudservers/{server_id}", s.updateServer)
+	s.handle(mux, "POST /v1/{project_id}/cloudservers/delete", s.deleteServers)
+	s.handle(mux, "POST /v1/{project_id}/cloudservers/action", s.serverPowerAction)
+
+	s.handle(mux, "POST /v1/{project_id}/cloudservers/{server_id}/metadata", s.updateServerMetadata)
+	s.handle(mux, "DELETE /v1/{project_id}/cloudservers/{server_id}/metadata/{key}", s.deleteServerMetadata)
+	s.handle(mux, "POST /v1/{project_id}/cloudservers/{server_id}/tags/action", s.serverTagAction)
+	s.handle(mux, "GET /v1/{project_id}/cloudservers/{server_id}/tags", s.listServerTags)
+
+	s.handle(mux, "GET /v1/{project_id}/cloudservers/flavors", s.listFlavors)
+	s.handle(mux, "GET /v1/{project_id}/cloudservers/limits", s.getServerLimits)
+}
+
+type serverFlavor struct {
+	// zones are the suffixes of the zones where the flavor is on sale
+	zones []string
+	vcpus int
+	// ram is the memory size in MB
+	ram int
+}
+
+// serverFlavors is the static flavor catalog.
+var serverFlavors = map[string]serverFlavor{
+	"c7.large.2":  {zones: []string{"a"}, vcpus: 2, ram: 4096},
+	"m6.xlarge.8": {zones: []string{"b"}, vcpus: 4, ram: 32768},
+	"s6.medium.2": {zones: []string{"a", "b"}, vcpus: 1, ram: 2048},
+	"s6.small.1":  {zones: []string{"a", "b"}, vcpus: 1, ram: 1024},
+}
+
+// defaultServerQuotas are the max values of the ECS quotas, they can be overridden by the object of KindQuota whose
+// ID is "ecs".
+var defaultServerQuotas = map[string]int{
+	"maxTotalInstances": 100,
+	"maxTotalCores":     400,
+	"maxTotalRAMSize":   1638400,
+}
+
+type serverVolume struct {
+	VolumeType string `json:"volumetype"`
+	Size       int    `json:"size"`
+}
+
+type serverCreateOpts struct {
+	Name             string                   `json:"name"`
+	Description      string                   `json:"description"`
+	ImageRef         string                   `json:"imageRef"`
+	FlavorRef        string                   `json:"flavorRef"`
+	VpcID            string                   `json:"vpcid"`
+	AvailabilityZone string                   `json:"availability_zone"`
+	Count            int                      `json:"count"`
+	KeyName          string                   `json:"key_name"`
+	Metadata         map[string]string        `json:"metadata"`
+	SecurityGroups   []map[string]interface{} `json:"security_groups"`
+	RootVolume       serverVolume             `json:"root_volume"`
+	DataVolumes      []serverVolume           `json:"data_volumes"`
+	ServerTags       []map[string]string      `json:"server_tags"`
+	Nics             []struct {
+		SubnetID  string `json:"subnet_id"`
+		IpAddress string `json:"ip_address"`
+	} `json:"nics"`
+	ExtendParam map[string]interface{} `json:"extendparam"`
+}
+
+// createServers creates the pay-per-use ECS instances with the NICs, the system disk and the data disks, the
+// instances are active once the job is returned.
+func (s *Server) createServers(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Server *serverCreateOpts `json:"server"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	opts := body.Server
+	if opts == nil || opts.Name == "" || opts.FlavorRef == "" || opts.ImageRef == "" || len(opts.Nics) == 0 {
+		return http.StatusBadRequest, errorBody("Ecs.0005", "name, flavorRef, imageRef and nics are required")
+	}
+	if _, ok := s.get(KindVpc, opts.VpcID); !ok {
+		return vpcNotFound(opts.VpcID)
+	}
+	for _, nic := range opts.Nics {
+		subnet, ok := s.get(KindSubnet, nic.SubnetID)
+		if !ok || subnet["vpc_id"] != opts.VpcID {
+			return subnetNotFound(nic.SubnetID)
+		}
+	}
+	if opts.Count < 1 {
+		opts.Count = 1
+	}
+
+	serverIDs := make([]string, opts.Count)
+	subJobs := make([]interface{}, opts.Count)
+	for i := 0; i < opts.Count; i++ {
+		server := s.newServer(opts)
+		serverIDs[i] = server["id"].(string)
+		subJobs[i] = map[string]interface{}{
+			"job_id":   s.newID(),
+			"job_type": "createSingleServer",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"server_id": server["id"]},
+		}
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"job_id": s.newJob("createServer", map[string]interface{}{
+			"sub_jobs_total": opts.Count,
+			"sub_jobs":       subJobs,
+		}),
+		"serverIds": serverIDs,
+	}
+}
+
+// newServer saves an active ECS instance, and the ports and the volumes of it.
+func (s *Server) newServer(opts *serverCreateOpts) map[string]interface{} {
+	now := time.Now().UTC().Format(time.RFC3339)
+	serverID := s.newID()
+	name := opts.Name
+	if opts.Count > 1 {
+		name = fmt.Sprintf("%s-%04d", name, s.nextSeq())
+	}
+
+	addresses := make([]interface{}, 0, len(opts.Nics))
+	for _, nic := range opts.Nics {
+		port := s.newPort(serverID, nic.SubnetID, nic.IpAddress)
+		addresses = append(addresses, map[string]interface{}{
+			"version":                 "4",
+			"addr":                    port["ip_address"],
+			"OS-EXT-IPS:type":         "fixed",
+			"OS-EXT-IPS:port_id":      port["id"],
+			"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+		})
+	}
+
+	volumes := make([]interface{}, 0, len(opts.DataVolumes)+1)
+	rootVolume := s.newServerVolume(serverID, opts, opts.RootVolume, "/dev/vda")
+	volumes = append(volumes, map[string]interface{}{
+		"id":                    rootVolume["id"],
+		"device":                "/dev/vda",
+		"bootIndex":             "0",
+		"delete_on_termination": "true",
+	})
+	for i, dataVolume := range opts.DataVolumes {
+		device := fmt.Sprintf("/dev/vd%c", 'b'+i)
+		volume := s.newServerVolume(serverID, opts, dataVolume, device)
+		volumes = append(volumes, map[string]interface{}{
+			"id":                    volume["id"],
+			"device":                device,
+			"bootIndex":             "",
+			"delete_on_termination": "false",
+		})
+	}
+
+	metadata := map[string]interface{}{
+		"vpc_id":            opts.VpcID,
+		"charging_mode":     "0",
+		"metering.image_id": opts.ImageRef,
+		"image_name":        opts.ImageRef,
+	}
+	for k, v := range opts.Metadata {
+		metadata[k] = v
+	}
+
+	tags := make([]interface{}, 0, len(opts.ServerTags))
+	tagList := make([]interface{}, 0, len(opts.ServerTags))
+	for _, tag := range opts.ServerTags {
+		tags = append(tags, fmt.Sprintf("%s=%s", tag["key"], tag["value"]))
+		tagList = append(tagList, map[string]interface{}{"key": tag["key"], "value": tag["value"]})
+	}
+	s.tags[KindServer+"/"+serverID] = tagList
+
+	securityGroups := make([]interface{}, 0, len(opts.SecurityGroups))
+	for _, sg := range opts.SecurityGroups {
+		securityGroups = append(securityGroups, map[string]interface{}{"id": sg["id"], "name": sg["id"]})
+	}
+
+	epsID := "0"
+	if v, ok := opts.ExtendParam["enterprise_project_id"].(string); ok && v != "" {
+		epsID = v
+	}
+
+	server := map[string]interface{}{
+		"id":          serverID,
+		"name":        name,
+		"description": opts.Description,
+		"status":      "ACTIVE",
+		"created":     now,
+		"updated":     now,
+		"tenant_id":   ProjectID,
+		"user_id":     UserID,
+		"key_name":    opts.KeyName,
+		"flavor": map[string]interface{}{
+			"id":    opts.FlavorRef,
+			"name":  opts.FlavorRef,
+			"vcpus": strconv.Itoa(serverFlavors[opts.FlavorRef].vcpus),
+			"ram":   strconv.Itoa(serverFlavors[opts.FlavorRef].ram),
+			"disk":  "0",
+		},
+		"image":                                map[string]interface{}{"id": opts.ImageRef},
+		"addresses":                            map[string]interface{}{opts.VpcID: addresses},
+		"metadata":                             metadata,
+		"tags":                                 tags,
+		"security_groups":                      securityGroups,
+		"enterprise_project_id":                epsID,
+		"OS-EXT-AZ:availability_zone":          opts.AvailabilityZone,
+		"OS-EXT-STS:vm_state":                  "active",
+		"OS-EXT-STS:power_state":               1,
+		"OS-EXT-SRV-ATTR:root_device_name":     "/dev/vda",
+		"OS-EXT-SRV-ATTR:hostname":             name,
+		"OS-SRV-USG:launched_at":               now,
+		"os-extended-volumes:volumes_attached": volumes,
+		"sys_tags":                             []interface{}{},
+	}
+	s.put(KindServer, server)
+	return server
+}
+
+// newPort saves a port of the ECS instance, the IP address is allocated from the subnet unless it's specified.
+func (s *Server) newPort(serverID, subnetID, ipAddress string) map[string]interface{} {
+	if ipAddress == "" {
+		subnet, _ := s.get(KindSubnet, subnetID)
+		ipAddress = allocateAddress(stringValue(subnet, "cidr"), len(s.list(kindPort, func(obj map[string]interface{}) bool {
+			return obj["subnet_id"] == subnetID
+		})))
+	}
+
+	seq := s.nextSeq()
+	port := map[string]interface{}{
+		"id":          s.newID(),
+		"device_id":   serverID,
+		"subnet_id":   subnetID,
+		"ip_address":  ipAddress,
+		"mac_address": fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", seq>>16&0xff, seq>>8&0xff, seq&0xff),
+	}
+	s.put(kindPort, port)
+	return port
+}
+
+// newServerVolume saves an in-use volume which is attached to the ECS instance.
+func (s *Server) newServerVolume(serverID string, opts *serverCreateOpts, params serverVolume,
+	device string) map[string]interface{} {
+	volumeParams := map[string]interface{}{
+		"name":              opts.Name + "-volume",
+		"size":              float64(params.Size),
+		"volume_type":       params.VolumeType,
+		"availability_zone": opts.AvailabilityZone,
+	}
+	if device == "/dev/vda" {
+		volumeParams["imageRef"] = opts.ImageRef
+	}
+
+	volume := s.newVolume(volumeParams)
+	volume["status"] = "in-use"
+	volume["attachments"] = []interface{}{
+		map[string]interface{}{
+			"id":            volume["id"],
+			"volume_id":     volume["id"],
+			"attachment_id": s.newID(),
+			"server_id":     serverID,
+			"device":        device,
+			"attached_at":   volume["created_at"],
+		},
+	}
+	return volume
+}
+
+func (s *Server) listServers(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+	servers := s.list(KindServer, func(obj map[string]interface{}) bool {
+		if name := query.Get("name"); name != "" && !strings.Contains(stringValue(obj, "name"), name) {
+			return false
+		}
+		return matchQuery(obj, query, "status", "enterprise_project_id")
+	})
+	return http.StatusOK, map[string]interface{}{
+		"servers": servers,
+		"count":   len(servers),
+	}
+}
+
+// listFlavors returns the flavors which are on sale in the zone, or in any zone if the zone is not specified.
+func (s *Server) listFlavors(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	zone := r.URL.Query().Get("availability_zone")
+	names := make([]string, 0, len(serverFlavors))
+	for name, flavor := range serverFlavors {
+		for _, suffix := range flavor.zones {
+			if zone == "" || zone == Region+suffix {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+
+	flavors := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		flavors = append(flavors, map[string]interface{}{
+			"id":    name,
+			"name":  name,
+			"vcpus": strconv.Itoa(serverFlavors[name].vcpus),
+			"ram":   serverFlavors[name].ram,
+			"os_extra_specs": map[string]interface{}{
+				"ecs:performancetype":   "normal",
+				"cond:operation:status": "normal",
+			},
+		})
+	}
+	return http.StatusOK, map[string]interface{}{"flavors": flavors}
+}
+
+// getServerLimits returns the ECS quotas, the used values are counted by the instances and their flavors.
+func (s *Server) getServerLimits(_ http.ResponseWriter, _ *http.Request) (int, interface{}) {
+	absolute := map[string]interface{}{
+		"totalInstancesUsed": 0,
+		"totalCoresUsed":     0,
+		"totalRAMUsed":       0,
+	}
+	quotas, _ := s.get(KindQuota, "ecs")
+	for k, v := range defaultServerQuotas {
+		absolute[k] = v
+		if value, ok := quotas[k]; ok {
+			absolute[k] = value
+		}
+	}
+
+	for _, obj := range s.list(KindServer, nil) {
+		flavorID, _ := obj.(map[string]interface{})["flavor"].(map[string]interface{})["id"].(string)
+		absolute["totalInstancesUsed"] = absolute["totalInstancesUsed"].(int) + 1
+		absolute["totalCoresUsed"] = absolute["totalCoresUsed"].(int) + serverFlavors[flavorID].vcpus
+		absolute["totalRAMUsed"] = absolute["totalRAMUsed"].(int) + serverFlavors[flavorID].ram
+	}
+	return http.StatusOK, map[string]interface{}{"absolute": absolute}
+}
+
+func (s *Server) getServer(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	server, ok := s.get(KindServer, r.PathValue("server_id"))
+	if !ok {
+		return serverNotFound(r.PathValue("server_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"server": server}
+}
+
+func (s *Server) updateServer(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	server, ok := s.get(KindServer, r.PathValue("server_id"))
+	if !ok {
+		return serverNotFound(r.PathValue("server_id"))
+	}
+
+	var body struct {
+		Server map[string]interface{} `json:"server"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	merge(server, body.Server)
+	server["updated"] = time.Now().UTC().Format(time.RFC3339)
+	return http.StatusOK, map[string]interface{}{"server": server}
+}
+
+// deleteServers deletes the ECS instances and detaches the volumes, the data disks and the EIPs are deleted only if
+// delete_volume and delete_publicip are true.
+func (s *Server) deleteServers(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Servers []struct {
+			ID string `json:"id"`
+		} `json:"servers"`
+		DeletePublicIp bool `json:"delete_publicip"`
+		DeleteVolume   bool `json:"delete_volume"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	for _, item := range body.Servers {
+		if _, ok := s.get(KindServer, item.ID); !ok {
+			return serverNotFound(item.ID)
+		}
+	}
+
+	subJobs := make([]interface{}, 0, len(body.Servers))
+	for _, item := range body.Servers {
+		s.releaseServer(item.ID, body.DeletePublicIp, body.DeleteVolume)
+		subJobs = append(subJobs, map[string]interface{}{
+			"job_id":   s.newID(),
+			"job_type": "deleteSingleServer",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"server_id": item.ID},
+		})
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"job_id": s.newJob("deleteServer", map[string]interface{}{
+			"sub_jobs_total": len(subJobs),
+			"sub_jobs":       subJobs,
+		}),
+	}
+}
+
+func (s *Server) releaseServer(serverID string, deletePublicIp, deleteVolume bool) {
+	ports := s.list(kindPort, func(obj map[string]interface{}) bool {
+		return obj["device_id"] == serverID
+	})
+	for _, p := range ports {
+		portID := p.(map[string]interface{})["id"].(string)
+		publicIps := s.list(KindPublicIp, func(obj map[string]interface{}) bool {
+			return obj["port_id"] == portID
+		})
+		for _, ip := range publicIps {
+			publicIp := ip.(map[string]interface{})
+			if deletePublicIp {
+				s.releasePublicIp(publicIp)
+				continue
+			}
+			delete(publicIp, "port_id")
+			delete(publicIp, "private_ip_address")
+			publicIp["status"] = "DOWN"
+		}
+		s.remove(kindPort, portID)
+	}
+
+	server, _ := s.get(KindServer, serverID)
+	attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+	for _, v := range attached {
+		item := v.(map[string]interface{})
+		volumeID := item["id"].(string)
+		if deleteVolume || item["delete_on_termination"] == "true" {
+			s.remove(KindVolume, volumeID)
+			continue
+		}
+		if volume, ok := s.get(KindVolume, volumeID); ok {
+			volume["status"] = "available"
+			volume["attachments"] = []interface{}{}
+		}
+	}
+
+	s.remove(KindServer, serverID)
+}
+
+// serverPowerAction starts, stops or reboots the ECS instances: {"os-stop": {"servers": [{"id": "xxx"}]}}
+func (s *Server) serverPowerAction(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body map[string]struct {
+		Servers []struct {
+			ID string `json:"id"`
+		} `json:"servers"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	statuses := map[string][2]string{
+		"os-start": {"ACTIVE", "active"},
+		"os-stop":  {"SHUTOFF", "stopped"},
+		"reboot":   {"ACTIVE", "active"},
+	}
+	for action, opts := range body {
+		status, ok := statuses[action]
+		if !ok {
+			return badRequest(fmt.Errorf("invalid action: %s", action))
+		}
+		for _, item := range opts.Servers {
+			server, ok := s.get(KindServer, item.ID)
+			if !ok {
+				return serverNotFound(item.ID)
+			}
+			server["status"] = status[0]
+			server["OS-EXT-STS:vm_state"] = status[1]
+		}
+		return http.StatusOK, map[string]interface{}{"job_id": s.newJob(action, map[string]interface{}{})}
+	}
+	return badRequest(fmt.Errorf("the action is missing"))
+}
+
+func (s *Server) updateServerMetadata(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	server, ok := s.get(KindServer, r.PathValue("server_id"))
+	if !ok {
+		return serverNotFound(r.PathValue("server_id"))
+	}
+
+	var body struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	metadata := server["metadata"].(map[string]interface{})
+	for k, v := range body.Metadata {
+		metadata[k] = v
+	}
+	return http.StatusOK, map[string]interface{}{"metadata": metadata}
+}
+
+func (s *Server) deleteServerMetadata(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	server, ok := s.get(KindServer, r.PathValue("server_id"))
+	if !ok {
+		return serverNotFound(r.PathValue("server_id"))
+	}
+
+	metadata := server["metadata"].(map[string]interface{})
+	if _, ok := metadata[r.PathValue("key")]; !ok {
+		return http.StatusNotFound, errorBody("Ecs.0914", fmt.Sprintf("metadata %s not found", r.PathValue("key")))
+	}
+	delete(metadata, r.PathValue("key"))
+	return http.StatusNoContent, nil
+}
+
+// serverTagAction updates the tags of the ECS instance, which are also saved as "key=value" in the instance detail.
+func (s *Server) serverTagAction(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	serverID := r.PathValue("server_id")
+	server, ok := s.get(KindServer, serverID)
+	if !ok {
+		return serverNotFound(serverID)
+	}
+
+	status, body := s.tagAction(KindServer, serverID, r)
+	if status != http.StatusNoContent {
+		return status, body
+	}
+
+	tags := s.getTags(KindServer, serverID)
+	serverTags := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		t := tag.(map[string]interface{})
+		serverTags = append(serverTags, fmt.Sprintf("%v=%v", t["key"], t["value"]))
+	}
+	server["tags"] = serverTags
+	return status, body
+}
+
+func (s *Server) listServerTags(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	serverID := r.PathValue("server_id")
+	if _, ok := s.get(KindServer, serverID); !ok {
+		return serverNotFound(serverID)
+	}
+	return http.StatusOK, map[string]interface{}{"tags": s.getTags(KindServer, serverID)}
+}
+
+func serverNotFound(id string) (int, interface{}) {
+	return http.StatusNotFound, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    "Ecs.0114",
+			"message": fmt.Sprintf("Instance[%s] could not be found.", id),
+		},
+	}
+}
+
+// portAddress returns the private IP address of the port.
+func (s *Server) portAddress(portID string) string {
+	port, _ := s.get(kindPort, portID)
+	return stringValue(port, "ip_address")
+}
+
+// portServer returns the ID of the ECS instance which the port belongs to.
+func (s *Server) portServer(portID string) string {
+	port, _ := s.get(kindPort, portID)
+	return stringValue(port, "device_id")
+}
@@ -0,0 +1,255 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerEip(mux *http.ServeMux) {
+	s.handle(mux, "POST /v1/{project_id}/publicips", s.createPublicIp)
+	s.handle(mux, "POST /v2.0/{project_id}/publicips", s.createPublicIp)
+	s.handle(mux, "GET /v1/{project_id}/publicips", s.listPublicIps)
+	s.handle(mux, "GET /v1/{project_id}/publicips/{publicip_id}", s.getPublicIp)
+	s.handle(mux, "PUT /v1/{project_id}/publicips/{publicip_id}", s.updatePublicIp)
+	s.handle(mux, "DELETE /v1/{project_id}/publicips/{publicip_id}", s.deletePublicIp)
+	s.handle(mux, "GET /v3/{project_id}/eip/publicips/{publicip_id}", s.getPublicIpV3)
+
+	s.handle(mux, "GET /v1/{project_id}/bandwidths/{bandwidth_id}", s.getBandwidth)
+	s.handle(mux, "PUT /v1/{project_id}/bandwidths/{bandwidth_id}", s.updateBandwidth)
+}
+
+// createPublicIp allocates a pay-per-use EIP with a dedicated bandwidth, or with the shared bandwidth specified by
+// the ID.
+func (s *Server) createPublicIp(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		PublicIp            map[string]interface{} `json:"publicip"`
+		Bandwidth           map[string]interface{} `json:"bandwidth"`
+		EnterpriseProjectID string                 `json:"enterprise_project_id"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.PublicIp == nil || body.Bandwidth == nil {
+		return badRequest(fmt.Errorf("publicip and bandwidth are required"))
+	}
+
+	epsID := body.EnterpriseProjectID
+	if epsID == "" {
+		epsID = "0"
+	}
+
+	var bandwidth map[string]interface{}
+	if bandwidthID := stringValue(body.Bandwidth, "id"); bandwidthID != "" {
+		var ok bool
+		if bandwidth, ok = s.get(KindBandwidth, bandwidthID); !ok {
+			return notFound(KindBandwidth, bandwidthID)
+		}
+	} else {
+		bandwidth = map[string]interface{}{
+			"id":                    s.newID(),
+			"name":                  stringValue(body.Bandwidth, "name"),
+			"size":                  body.Bandwidth["size"],
+			"share_type":            stringValueOr(body.Bandwidth, "share_type", "PER"),
+			"charge_mode":           stringValueOr(body.Bandwidth, "charge_mode", "bandwidth"),
+			"bandwidth_type":        "bgp",
+			"billing_info":          "",
+			"enterprise_project_id": epsID,
+			"tenant_id":             ProjectID,
+			"status":                "NORMAL",
+			"publicip_info":         []interface{}{},
+			"created_at":            time.Now().UTC().Format(timeFormat),
+		}
+		s.put(KindBandwidth, bandwidth)
+	}
+
+	seq := s.nextSeq()
+	address := stringValueOr(body.PublicIp, "ip_address", fmt.Sprintf("100.85.%d.%d", seq/250%250, seq%250+1))
+	publicIp := map[string]interface{}{
+		"id":                    s.newID(),
+		"status":                "DOWN",
+		"type":                  stringValueOr(body.PublicIp, "type", "5_bgp"),
+		"public_ip_address":     address,
+		"ip_version":            4,
+		"alias":                 stringValue(body.PublicIp, "alias"),
+		"enterprise_project_id": epsID,
+		"tenant_id":             ProjectID,
+		"create_time":           time.Now().UTC().Format("2006-01-02 15:04:05"),
+	}
+	s.put(KindPublicIp, publicIp)
+	s.bindBandwidth(publicIp, bandwidth)
+
+	return http.StatusOK, map[string]interface{}{"publicip": publicIp}
+}
+
+// bindBandwidth saves the bandwidth information in the EIP and the EIP in the bandwidth.
+func (s *Server) bindBandwidth(publicIp, bandwidth map[string]interface{}) {
+	publicIp["bandwidth_id"] = bandwidth["id"]
+	publicIp["bandwidth_name"] = bandwidth["name"]
+	publicIp["bandwidth_size"] = bandwidth["size"]
+	publicIp["bandwidth_share_type"] = bandwidth["share_type"]
+
+	ipInfo, _ := bandwidth["publicip_info"].([]interface{})
+	bandwidth["publicip_info"] = append(ipInfo, map[string]interface{}{
+		"publicip_id":      publicIp["id"],
+		"publicip_address": publicIp["public_ip_address"],
+		"ip_version":       publicIp["ip_version"],
+		"publicip_type":    publicIp["type"],
+	})
+}
+
+func (s *Server) listPublicIps(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+	publicIps := s.list(KindPublicIp, func(obj map[string]interface{}) bool {
+		return matchQuery(obj, query, "id", "public_ip_address", "port_id", "enterprise_project_id")
+	})
+	return http.StatusOK, map[string]interface{}{"publicips": publicIps}
+}
+
+func (s *Server) getPublicIp(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	publicIp, ok := s.get(KindPublicIp, r.PathValue("publicip_id"))
+	if !ok {
+		return notFound(KindPublicIp, r.PathValue("publicip_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"publicip": publicIp}
+}
+
+// updatePublicIp updates the alias and binds or unbinds the port: an empty port_id unbinds the EIP.
+func (s *Server) updatePublicIp(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	publicIp, ok := s.get(KindPublicIp, r.PathValue("publicip_id"))
+	if !ok {
+		return notFound(KindPublicIp, r.PathValue("publicip_id"))
+	}
+
+	var body struct {
+		PublicIp map[string]interface{} `json:"publicip"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	if portID, ok := body.PublicIp["port_id"].(string); ok {
+		if portID == "" {
+			delete(publicIp, "port_id")
+			delete(publicIp, "private_ip_address")
+			publicIp["status"] = "DOWN"
+		} else {
+			publicIp["port_id"] = portID
+			publicIp["private_ip_address"] = s.portAddress(portID)
+			publicIp["status"] = "ACTIVE"
+		}
+		delete(body.PublicIp, "port_id")
+	}
+	merge(publicIp, body.PublicIp)
+	return http.StatusOK, map[string]interface{}{"publicip": publicIp}
+}
+
+// deletePublicIp releases the EIP and its dedicated bandwidth, the EIP should be unbound first.
+func (s *Server) deletePublicIp(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	publicIpID := r.PathValue("publicip_id")
+	publicIp, ok := s.get(KindPublicIp, publicIpID)
+	if !ok {
+		return notFound(KindPublicIp, publicIpID)
+	}
+	if stringValue(publicIp, "port_id") != "" {
+		return http.StatusConflict, errorBody("VPC.0413", "the EIP is bound to a port, unbind it first")
+	}
+
+	s.releasePublicIp(publicIp)
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) releasePublicIp(publicIp map[string]interface{}) {
+	publicIpID := publicIp["id"].(string)
+	s.remove(KindPublicIp, publicIpID)
+
+	bandwidthID := stringValue(publicIp, "bandwidth_id")
+	bandwidth, ok := s.get(KindBandwidth, bandwidthID)
+	if !ok {
+		return
+	}
+	if bandwidth["share_type"] == "PER" {
+		s.remove(KindBandwidth, bandwidthID)
+		return
+	}
+
+	ipInfo, _ := bandwidth["publicip_info"].([]interface{})
+	result := make([]interface{}, 0, len(ipInfo))
+	for _, info := range ipInfo {
+		if info.(map[string]interface{})["publicip_id"] != publicIpID {
+			result = append(result, info)
+		}
+	}
+	bandwidth["publicip_info"] = result
+}
+
+// getPublicIpV3 returns the EIP in the format of the v3 API.
+func (s *Server) getPublicIpV3(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	publicIp, ok := s.get(KindPublicIp, r.PathValue("publicip_id"))
+	if !ok {
+		return notFound(KindPublicIp, r.PathValue("publicip_id"))
+	}
+
+	vnic := map[string]interface{}{}
+	associateType := ""
+	if portID := stringValue(publicIp, "port_id"); portID != "" {
+		associateType = "PORT"
+		vnic["port_id"] = portID
+		vnic["private_ip_address"] = publicIp["private_ip_address"]
+		if serverID := s.portServer(portID); serverID != "" {
+			vnic["instance_id"] = serverID
+			vnic["instance_type"] = "ECS"
+		}
+	}
+	return http.StatusOK, map[string]interface{}{
+		"request_id": s.newID(),
+		"publicip": map[string]interface{}{
+			"id":                      publicIp["id"],
+			"status":                  publicIp["status"],
+			"alias":                   publicIp["alias"],
+			"public_ip_address":       publicIp["public_ip_address"],
+			"ip_version":              publicIp["ip_version"],
+			"publicip_pool_name":      publicIp["type"],
+			"enterprise_project_id":   publicIp["enterprise_project_id"],
+			"billing_info":            "",
+			"created_at":              publicIp["create_time"],
+			"updated_at":              publicIp["create_time"],
+			"associate_instance_type": associateType,
+			"associate_instance_id":   stringValue(publicIp, "port_id"),
+			"vnic":                    vnic,
+		},
+	}
+}
+
+func (s *Server) getBandwidth(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	bandwidth, ok := s.get(KindBandwidth, r.PathValue("bandwidth_id"))
+	if !ok {
+		return notFound(KindBandwidth, r.PathValue("bandwidth_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"bandwidth": bandwidth}
+}
+
+// updateBandwidth updates the name and size of the bandwidth, and the bandwidth information of the EIPs.
+func (s *Server) updateBandwidth(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	bandwidth, ok := s.get(KindBandwidth, r.PathValue("bandwidth_id"))
+	if !ok {
+		return notFound(KindBandwidth, r.PathValue("bandwidth_id"))
+	}
+
+	var body struct {
+		Bandwidth map[string]interface{} `json:"bandwidth"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	merge(bandwidth, body.Bandwidth)
+
+	for _, publicIp := range s.list(KindPublicIp, nil) {
+		ip := publicIp.(map[string]interface{})
+		if ip["bandwidth_id"] == bandwidth["id"] {
+			ip["bandwidth_name"] = bandwidth["name"]
+			ip["bandwidth_size"] = bandwidth["size"]
+		}
+	}
+	return http.StatusOK, map[string]interface{}{"bandwidth": bandwidth}
+}
@@ -0,0 +1,245 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerEvs(mux *http.ServeMux) {
+	s.handle(mux, "POST /v2.1/{project_id}/cloudvolumes", s.createVolumes)
+	s.handle(mux, "POST /v2.1/{project_id}/cloudvolumes/{volume_id}/action", s.volumeAction)
+	// the v2 and v2.1 clients share the query, update and deletion APIs
+	for _, version := range []string{"v2", "v2.1"} {
+		s.handle(mux, "GET /"+version+"/{project_id}/cloudvolumes/detail", s.listVolumes)
+		s.handle(mux, "GET /"+version+"/{project_id}/cloudvolumes/{volume_id}", s.getVolume)
+		s.handle(mux, "PUT /"+version+"/{project_id}/cloudvolumes/{volume_id}", s.updateVolume)
+		s.handle(mux, "DELETE /"+version+"/{project_id}/cloudvolumes/{volume_id}", s.deleteVolume)
+	}
+	s.handle(mux, "POST /v2/{project_id}/cloudvolumes/{volume_id}/tags/action", s.volumeTagAction)
+	s.handle(mux, "GET /v2/{project_id}/cloudvolumes/{volume_id}/tags", s.listVolumeTags)
+
+	// the jobs of ECS and EVS share the same API
+	s.handle(mux, "GET /v1/{project_id}/jobs/{job_id}", s.getJob)
+}
+
+// createVolumes creates the pay-per-use volumes, the volumes are available once the job is returned.
+func (s *Server) createVolumes(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Volume map[string]interface{} `json:"volume"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.Volume == nil || stringValue(body.Volume, "availability_zone") == "" || stringValue(body.Volume, "volume_type") == "" {
+		return http.StatusBadRequest, errorBody("EVS.2001", "availability_zone and volume_type are required")
+	}
+
+	count := 1
+	if v, ok := body.Volume["count"].(float64); ok && v > 1 {
+		count = int(v)
+	}
+
+	volumeIDs := make([]string, count)
+	subJobs := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		volume := s.newVolume(body.Volume)
+		volumeIDs[i] = volume["id"].(string)
+		subJobs[i] = map[string]interface{}{
+			"job_id":   s.newID(),
+			"job_type": "createVolume",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"volume_id": volume["id"]},
+		}
+	}
+
+	entities := map[string]interface{}{"sub_jobs": subJobs}
+	if count == 1 {
+		entities = map[string]interface{}{"volume_id": volumeIDs[0]}
+	}
+	return http.StatusAccepted, map[string]interface{}{
+		"job_id":     s.newJob("batchCreateVolumes", entities),
+		"volume_ids": volumeIDs,
+	}
+}
+
+// newVolume saves an available volume with the creation parameters.
+func (s *Server) newVolume(params map[string]interface{}) map[string]interface{} {
+	name := stringValue(params, "name")
+	if v, ok := params["count"].(float64); ok && v > 1 {
+		name = fmt.Sprintf("%s-%04d", name, s.nextSeq())
+	}
+	metadata, _ := params["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	tags, _ := params["tags"].(map[string]interface{})
+	if tags == nil {
+		tags = make(map[string]interface{})
+	}
+	now := time.Now().UTC().Format(timeFormat)
+
+	volume := map[string]interface{}{
+		"id":                    s.newID(),
+		"name":                  name,
+		"description":           stringValue(params, "description"),
+		"size":                  params["size"],
+		"status":                "available",
+		"volume_type":           params["volume_type"],
+		"availability_zone":     params["availability_zone"],
+		"snapshot_id":           stringValue(params, "snapshot_id"),
+		"multiattach":           params["multiattach"] == true,
+		"bootable":              "false",
+		"encrypted":             false,
+		"enterprise_project_id": stringValueOr(params, "enterprise_project_id", "0"),
+		"attachments":           []interface{}{},
+		"metadata":              metadata,
+		"tags":                  tags,
+		"wwn":                   fmt.Sprintf("688860300000000%017d", s.nextSeq()),
+		"service_type":          "EVS",
+		"created_at":            now,
+		"updated_at":            now,
+	}
+	if iops, ok := params["iops"]; ok {
+		volume["iops"] = map[string]interface{}{"total_val": iops}
+	}
+	if throughput, ok := params["throughput"]; ok {
+		volume["throughput"] = map[string]interface{}{"total_val": throughput}
+	}
+	if imageID := stringValue(params, "imageRef"); imageID != "" {
+		volume["volume_image_metadata"] = map[string]interface{}{"image_id": imageID}
+		volume["bootable"] = "true"
+	}
+	s.put(KindVolume, volume)
+	return volume
+}
+
+func (s *Server) listVolumes(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+	volumes := s.list(KindVolume, func(obj map[string]interface{}) bool {
+		return matchQuery(obj, query, "name", "status", "availability_zone", "volume_type", "enterprise_project_id")
+	})
+	return http.StatusOK, map[string]interface{}{
+		"volumes": volumes,
+		"count":   len(volumes),
+	}
+}
+
+func (s *Server) getVolume(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volume, ok := s.get(KindVolume, r.PathValue("volume_id"))
+	if !ok {
+		return volumeNotFound(r.PathValue("volume_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"volume": volume}
+}
+
+func (s *Server) updateVolume(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volume, ok := s.get(KindVolume, r.PathValue("volume_id"))
+	if !ok {
+		return volumeNotFound(r.PathValue("volume_id"))
+	}
+
+	var body struct {
+		Volume map[string]interface{} `json:"volume"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	merge(volume, body.Volume)
+	volume["updated_at"] = time.Now().UTC().Format(timeFormat)
+	return http.StatusOK, map[string]interface{}{"volume": volume}
+}
+
+// deleteVolume refuses to delete the volume which is attached to ECS instances, as the cloud does.
+func (s *Server) deleteVolume(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volumeID := r.PathValue("volume_id")
+	volume, ok := s.get(KindVolume, volumeID)
+	if !ok {
+		return volumeNotFound(volumeID)
+	}
+	if attachments, _ := volume["attachments"].([]interface{}); len(attachments) > 0 {
+		return http.StatusBadRequest, errorBody("EVS.2010", "the volume is attached, detach it first")
+	}
+
+	s.remove(KindVolume, volumeID)
+	return http.StatusOK, nil
+}
+
+// volumeAction handles the expansion of the volume: {"os-extend": {"new_size": 100}}
+func (s *Server) volumeAction(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volumeID := r.PathValue("volume_id")
+	volume, ok := s.get(KindVolume, volumeID)
+	if !ok {
+		return volumeNotFound(volumeID)
+	}
+
+	var body struct {
+		Extend *struct {
+			NewSize int `json:"new_size"`
+		} `json:"os-extend"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.Extend == nil {
+		return badRequest(fmt.Errorf("only os-extend is supported"))
+	}
+	if size, _ := volume["size"].(float64); float64(body.Extend.NewSize) <= size {
+		return http.StatusBadRequest, errorBody("EVS.2016", "the new size should be greater than the current size")
+	}
+
+	volume["size"] = float64(body.Extend.NewSize)
+	return http.StatusAccepted, map[string]interface{}{
+		"job_id": s.newJob("extendVolume", map[string]interface{}{"volume_id": volumeID}),
+	}
+}
+
+// volumeTagAction updates the tags which are saved in the volume detail.
+func (s *Server) volumeTagAction(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volume, ok := s.get(KindVolume, r.PathValue("volume_id"))
+	if !ok {
+		return volumeNotFound(r.PathValue("volume_id"))
+	}
+
+	var body struct {
+		Action string              `json:"action"`
+		Tags   []map[string]string `json:"tags"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	tags, _ := volume["tags"].(map[string]interface{})
+	if tags == nil {
+		tags = make(map[string]interface{})
+	}
+	for _, tag := range body.Tags {
+		switch body.Action {
+		case "create":
+			tags[tag["key"]] = tag["value"]
+		case "delete":
+			delete(tags, tag["key"])
+		default:
+			return badRequest(fmt.Errorf("invalid action: %s", body.Action))
+		}
+	}
+	volume["tags"] = tags
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) listVolumeTags(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	volume, ok := s.get(KindVolume, r.PathValue("volume_id"))
+	if !ok {
+		return volumeNotFound(r.PathValue("volume_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"tags": volume["tags"]}
+}
+
+func volumeNotFound(id string) (int, interface{}) {
+	return http.StatusNotFound, map[string]interface{}{
+		"itemNotFound": map[string]interface{}{
+			"code":    404,
+			"message": fmt.Sprintf("Volume %s could not be found.", id),
+		},
+	}
+}
@@ -0,0 +1,448 @@
+// Package fakecloud provides an in-memory fake of the HuaweiCloud APIs for the unit tests.
+//
+// The fake server emulates the IAM authentication, the agency assuming, the OIDC federation, the CRUD APIs of VPC,
+// subnet, ECS, EVS and EIP, and the price inquiry APIs of BSS, so that the resources, the importers, the waiters and
+// the authentication can be tested without the cloud credentials and TF_ACC, e.g.:
+//
+//	server := fakecloud.NewServer(t)
+//	cfg := server.Config(t)
+//	d := schema.TestResourceDataRaw(t, vpc.ResourceVirtualPrivateCloudV1().Schema, raw)
+//	diags := vpc.ResourceVirtualPrivateCloudV1().CreateContext(ctx, d, cfg)
+//
+// The asynchronous jobs are completed immediately, and the resources are in the target status once created.
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	// Region is the region of the fake cloud.
+	Region = "cn-north-4"
+	// ProjectID is the ID of the only project in the fake cloud, which is named as the region.
+	ProjectID = "0970dd7a1300f5672ff2c003c60ae115"
+	// DomainID is the ID of the account in the fake cloud.
+	DomainID = "0970d7b7d400f2470fbec00316a03560"
+	// DomainName is the name of the account in the fake cloud.
+	DomainName = "fakecloud"
+	// UserID is the ID of the IAM user in the fake cloud.
+	UserID = "0970d7b7d700f2470f0ac0031af2dd3a"
+	// AccessKey is the access key accepted by the fake cloud, the signatures are not verified.
+	AccessKey = "FAKECLOUDACCESSKEY"
+	// SecretKey is the secret key accepted by the fake cloud.
+	SecretKey = "FakeCloudSecretKey"
+
+	timeFormat = "2006-01-02T15:04:05Z"
+)
+
+// The kinds of the objects saved in the fake cloud.
+const (
+	KindVpc       = "vpcs"
+	KindSubnet    = "subnets"
+	KindServer    = "servers"
+	KindVolume    = "volumes"
+	KindPublicIp  = "publicips"
+	KindBandwidth = "bandwidths"
+	// KindQuota is the kind of the quotas of the services, the ID is the service name, e.g. ecs, and the object
+	// overrides the max values of the quotas, e.g. {"maxTotalCores": 8}.
+	KindQuota = "quotas"
+	// KindSecurityToken is the kind of the temporary credentials issued by assuming the agencies, the ID is the
+	// access key of the credential.
+	KindSecurityToken = "securitytokens"
+	// KindFederatedToken is the kind of the tokens exchanged by the OIDC tokens, the ID is the token.
+	KindFederatedToken = "federatedtokens"
+)
+
+// Server is a fake HuaweiCloud API server which saves the resources in memory.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]map[string]map[string]interface{}
+	tags    map[string][]interface{}
+	jobs    map[string]map[string]interface{}
+	// order saves the creation sequence of the objects, the list APIs return the objects in this order
+	order map[string]int
+	seq   int
+}
+
+// NewServer starts a fake cloud server, it's closed when the test and all its subtests complete.
+func NewServer(t testing.TB) *Server {
+	return NewServerAt(t, "")
+}
+
+// NewServerAt is the same as NewServer, but the server listens on the specified address, such as 127.0.0.1:18080,
+// so the URLs of the API requests are the same in each run, e.g. to record the requests to a cassette.
+// The server listens on a random port of the loopback address if addr is empty.
+func NewServerAt(t testing.TB, addr string) *Server {
+	s := &Server{
+		objects: make(map[string]map[string]map[string]interface{}),
+		tags:    make(map[string][]interface{}),
+		jobs:    make(map[string]map[string]interface{}),
+		order:   make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	s.registerIAM(mux)
+	s.registerVpc(mux)
+	s.registerEip(mux)
+	s.registerEvs(mux)
+	s.registerEcs(mux)
+	s.registerBss(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("[WARN] the API is not emulated by the fake cloud: %s %s", r.Method, r.URL)
+		writeJSON(w, http.StatusNotFound, errorBody("APIGW.0101", "The API does not exist or has not been published"))
+	})
+
+	s.Server = httptest.NewUnstartedServer(mux)
+	if addr != "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatalf("failed to listen on %s: %s", addr, err)
+		}
+		s.Server.Listener.Close()
+		s.Server.Listener = listener
+	}
+	s.Start()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Config returns a provider configuration which authenticates with the fake cloud, and the endpoints of all
+// services point at the fake server. The options modify the configuration before it's loaded.
+func (s *Server) Config(t testing.TB, options ...func(*config.Config)) *config.Config {
+	return NewConfig(t, s.URL, options...)
+}
+
+// NewConfig returns the provider configuration of the fake cloud whose URL is serverURL, the server is not required
+// if the API interactions are replayed from a cassette.
+func NewConfig(t testing.TB, serverURL string, options ...func(*config.Config)) *config.Config {
+	endpoint := serverURL + "/"
+	endpoints := make(map[string]string)
+	for _, key := range config.GetServiceCatalogKeys() {
+		endpoints[key] = endpoint
+	}
+
+	cfg := &config.Config{
+		AccessKey:          AccessKey,
+		SecretKey:          SecretKey,
+		Region:             Region,
+		TenantName:         Region,
+		DelegatedProject:   Region,
+		IdentityEndpoint:   serverURL + "/v3",
+		Cloud:              "myhuaweicloud.com",
+		Endpoints:          endpoints,
+		RegionProjectIDMap: make(map[string]string),
+		RPLock:             new(sync.Mutex),
+		SecurityKeyLock:    new(sync.Mutex),
+	}
+	for _, option := range options {
+		option(cfg)
+	}
+	if err := cfg.LoadAndValidate(); err != nil {
+		t.Fatalf("failed to configure the provider with the fake cloud: %s", err)
+	}
+	return cfg
+}
+
+// Object returns a copy of the object saved in the fake cloud, the kind is one of the Kind constants.
+func (s *Server) Object(kind, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// SetObject saves the object in the fake cloud, it can be used to prepare the dependent resources or to simulate
+// the changes made outside of Terraform.
+func (s *Server) SetObject(kind, id string, obj map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj = copyObject(obj)
+	obj["id"] = id
+	s.put(kind, obj)
+}
+
+// DeleteObject removes the object from the fake cloud, as if it's deleted outside of Terraform.
+func (s *Server) DeleteObject(kind, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(kind, id)
+}
+
+// Count returns the number of the objects of the kind.
+func (s *Server) Count(kind string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.objects[kind])
+}
+
+// apiHandler handles an API request and returns the status code and the response body, which is encoded as JSON
+// unless it's nil. The handlers are serialized by the lock of the server.
+type apiHandler func(w http.ResponseWriter, r *http.Request) (int, interface{})
+
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler apiHandler) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if projectID := r.PathValue("project_id"); projectID != "" && projectID != ProjectID {
+			writeJSON(w, http.StatusNotFound, errorBody("APIGW.0106", fmt.Sprintf("project %s not found", projectID)))
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// the body is encoded with the lock held, since it may refer to the saved objects
+		status, body := handler(w, r)
+		writeJSON(w, status, body)
+	})
+}
+
+func (s *Server) newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// nextSeq returns an increasing sequence number, which is used to order the objects and generate the addresses.
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+func (s *Server) put(kind string, obj map[string]interface{}) {
+	if s.objects[kind] == nil {
+		s.objects[kind] = make(map[string]map[string]interface{})
+	}
+	id := obj["id"].(string)
+	s.objects[kind][id] = obj
+	if _, ok := s.order[kind+"/"+id]; !ok {
+		s.order[kind+"/"+id] = s.nextSeq()
+	}
+}
+
+func (s *Server) get(kind, id string) (map[string]interface{}, bool) {
+	obj, ok := s.objects[kind][id]
+	return obj, ok
+}
+
+// list returns the objects of the kind which match the filter, they are sorted by the creation sequence.
+func (s *Server) list(kind string, filter func(obj map[string]interface{}) bool) []interface{} {
+	result := make([]interface{}, 0, len(s.objects[kind]))
+	for _, obj := range s.objects[kind] {
+		if filter == nil || filter(obj) {
+			result = append(result, obj)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return s.order[kind+"/"+result[i].(map[string]interface{})["id"].(string)] <
+			s.order[kind+"/"+result[j].(map[string]interface{})["id"].(string)]
+	})
+	return result
+}
+
+func (s *Server) remove(kind, id string) {
+	delete(s.objects[kind], id)
+	delete(s.tags, kind+"/"+id)
+	delete(s.order, kind+"/"+id)
+}
+
+// newJob saves a successful job, the asynchronous operations are completed immediately in the fake cloud.
+func (s *Server) newJob(jobType string, entities map[string]interface{}) string {
+	now := time.Now().UTC().Format(timeFormat)
+	jobID := s.newID()
+	s.jobs[jobID] = map[string]interface{}{
+		"job_id":     jobID,
+		"job_type":   jobType,
+		"status":     "SUCCESS",
+		"entities":   entities,
+		"begin_time": now,
+		"end_time":   now,
+	}
+	return jobID
+}
+
+// getJob handles the job API of ECS and EVS: GET /v1/{project_id}/jobs/{job_id}
+func (s *Server) getJob(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	job, ok := s.jobs[r.PathValue("job_id")]
+	if !ok {
+		return http.StatusNotFound, errorBody("Common.0024", "the job does not exist")
+	}
+	return http.StatusOK, job
+}
+
+// getTags returns the tags of the object in the format of the tag APIs.
+func (s *Server) getTags(kind, id string) []interface{} {
+	if tags, ok := s.tags[kind+"/"+id]; ok {
+		return tags
+	}
+	return make([]interface{}, 0)
+}
+
+// tagAction creates or deletes the tags of the object with the body of the tag APIs:
+// {"action": "create", "tags": [{"key": "foo", "value": "bar"}]}
+func (s *Server) tagAction(kind, id string, r *http.Request) (int, interface{}) {
+	if _, ok := s.get(kind, id); !ok {
+		return notFound(kind, id)
+	}
+
+	var body struct {
+		Action string                   `json:"action"`
+		Tags   []map[string]interface{} `json:"tags"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	key := kind + "/" + id
+	tags := s.getTags(kind, id)
+	result := make([]interface{}, 0, len(tags)+len(body.Tags))
+	for _, tag := range tags {
+		if !containsTagKey(body.Tags, tag.(map[string]interface{})["key"]) {
+			result = append(result, tag)
+		}
+	}
+	switch body.Action {
+	case "create":
+		for _, tag := range body.Tags {
+			result = append(result, tag)
+		}
+	case "delete":
+	default:
+		return badRequest(fmt.Errorf("invalid action: %s", body.Action))
+	}
+	s.tags[key] = result
+	return http.StatusNoContent, nil
+}
+
+func containsTagKey(tags []map[string]interface{}, key interface{}) bool {
+	for _, tag := range tags {
+		if tag["key"] == key {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %s", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[WARN] failed to write the response of the fake cloud: %s", err)
+	}
+}
+
+func errorBody(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"error_code": code,
+		"error_msg":  message,
+	}
+}
+
+func notFound(kind, id string) (int, interface{}) {
+	return http.StatusNotFound, errorBody("Common.0404", fmt.Sprintf("the %s (%s) does not exist", kind, id))
+}
+
+func badRequest(err error) (int, interface{}) {
+	return http.StatusBadRequest, errorBody("Common.0400", err.Error())
+}
+
+// merge sets the non-nil values of the updates to the object.
+func merge(obj, updates map[string]interface{}) {
+	for k, v := range updates {
+		if v != nil {
+			obj[k] = v
+		}
+	}
+}
+
+// copyObject returns a deep copy of the JSON object.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(content, &result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// stringValue returns the string value of the key in the object, or an empty string if it's not a string.
+func stringValue(obj map[string]interface{}, key string) string {
+	return stringValueOr(obj, key, "")
+}
+
+func stringValueOr(obj map[string]interface{}, key, defaultValue string) string {
+	if v, ok := obj[key].(string); ok && v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// paginateByMarker returns the page of the objects after the marker, the page size is the limit query parameter.
+func paginateByMarker(objects []interface{}, query url.Values) []interface{} {
+	if marker := query.Get("marker"); marker != "" {
+		for i, obj := range objects {
+			if obj.(map[string]interface{})["id"] == marker {
+				objects = objects[i+1:]
+				break
+			}
+		}
+	}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < len(objects) {
+		objects = objects[:limit]
+	}
+	return objects
+}
+
+// matchQuery returns true if the string values of the object match the query parameters of the keys.
+// The enterprise project "all_granted_eps" matches all objects.
+func matchQuery(obj map[string]interface{}, query url.Values, keys ...string) bool {
+	for _, key := range keys {
+		v := query.Get(key)
+		if key == "enterprise_project_id" && v == "all_granted_eps" {
+			continue
+		}
+		if v != "" && stringValue(obj, key) != v {
+			return false
+		}
+	}
+	return true
+}
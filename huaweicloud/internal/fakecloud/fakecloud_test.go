@@ -0,0 +1,116 @@
+package fakecloud_test
+
+import (
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/eips"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestConfig(t *testing.T) {
+	cfg := fakecloud.NewServer(t).Config(t)
+
+	th.AssertEquals(t, fakecloud.DomainID, cfg.DomainID)
+	th.AssertEquals(t, fakecloud.ProjectID, cfg.RegionProjectIDMap[fakecloud.Region])
+
+	client, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, fakecloud.ProjectID, client.ProjectID)
+}
+
+func TestNetworkAndServer(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+
+	vpcClient, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	ecsClient, err := cfg.ComputeV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	evsClient, err := cfg.BlockStorageV21Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+
+	network, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-fake", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+	subnet, err := subnets.Create(vpcClient, subnets.CreateOpts{
+		Name:      "subnet-fake",
+		CIDR:      "192.168.0.0/24",
+		GatewayIP: "192.168.0.1",
+		VPC_ID:    network.ID,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ACTIVE", subnet.Status)
+
+	job, err := cloudservers.Create(ecsClient, cloudservers.CreateOpts{
+		Name:             "ecs-fake",
+		ImageRef:         "image-id",
+		FlavorRef:        "s6.small.1",
+		VpcId:            network.ID,
+		AvailabilityZone: fakecloud.Region + "a",
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD", Size: 40},
+		DataVolumes:      []cloudservers.DataVolume{{VolumeType: "SSD", Size: 10}},
+	}).ExtractJobResponse()
+	th.AssertNoErr(t, err)
+
+	status, err := cloudservers.GetJobEntity(ecsClient, job.JobID, "server_id")
+	th.AssertNoErr(t, err)
+	serverID := status.(string)
+	instance, err := cloudservers.Get(ecsClient, serverID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ACTIVE", instance.Status)
+	th.AssertEquals(t, "192.168.0.2", instance.Addresses[network.ID][0].Addr)
+	th.AssertEquals(t, 2, len(instance.VolumeAttached))
+	th.AssertEquals(t, 2, server.Count(fakecloud.KindVolume))
+
+	// the subnet can not be deleted until the server is deleted
+	err = subnets.Delete(vpcClient, network.ID, subnet.ID).ExtractErr()
+	th.AssertEquals(t, 409, err.(golangsdk.ErrUnexpectedResponseCode).Actual)
+
+	publicIp, err := eips.Apply(vpcClient, eips.ApplyOpts{
+		IP:        eips.PublicIpOpts{Type: "5_bgp"},
+		Bandwidth: eips.BandwidthOpts{Name: "bandwidth-fake", Size: 5, ShareType: "PER"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	portID := instance.Addresses[network.ID][0].PortID
+	_, err = eips.Update(vpcClient, publicIp.ID, eips.UpdateOpts{PortID: portID}).Extract()
+	th.AssertNoErr(t, err)
+	bound, err := eips.Get(vpcClient, publicIp.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ACTIVE", bound.Status)
+	th.AssertEquals(t, "192.168.0.2", bound.PrivateAddress)
+
+	job, err = cloudservers.Delete(ecsClient, cloudservers.DeleteOpts{
+		Servers:        []cloudservers.Server{{Id: serverID}},
+		DeletePublicIP: true,
+	}).ExtractJobResponse()
+	th.AssertNoErr(t, err)
+	_, err = cloudservers.GetJobEntity(ecsClient, job.JobID, "server_id")
+	th.AssertNoErr(t, err)
+
+	// the system disk and the EIP are released with the server, the data disk is detached
+	_, err = cloudservers.Get(ecsClient, serverID).Extract()
+	th.AssertEquals(t, true, isNotFound(err))
+	th.AssertEquals(t, 0, server.Count(fakecloud.KindPublicIp))
+	th.AssertEquals(t, 1, server.Count(fakecloud.KindVolume))
+
+	volumes, err := cloudvolumes.ListPage(evsClient, cloudvolumes.ListOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(volumes))
+	th.AssertEquals(t, "available", volumes[0].Status)
+	th.AssertNoErr(t, cloudvolumes.Delete(evsClient, volumes[0].ID, cloudvolumes.DeleteOpts{}).ExtractErr())
+
+	th.AssertNoErr(t, subnets.Delete(vpcClient, network.ID, subnet.ID).ExtractErr())
+	th.AssertNoErr(t, vpcs.Delete(vpcClient, network.ID).ExtractErr())
+}
+
+func isNotFound(err error) bool {
+	_, ok := err.(golangsdk.ErrDefault404)
+	return ok
+}
@@ -0,0 +1,219 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// accessKeyRegexp matches the access key in the Authorization header of the AK/SK signed requests.
+var accessKeyRegexp = regexp.MustCompile(`Access=([^,\s]+)`)
+
+func (s *Server) registerIAM(mux *http.ServeMux) {
+	s.handle(mux, "POST /v3/auth/tokens", s.createToken)
+	s.handle(mux, "GET /v3/auth/projects", s.listProjects)
+	s.handle(mux, "GET /v3/projects", s.listProjects)
+	s.handle(mux, "GET /v3/auth/domains", s.listDomains)
+	s.handle(mux, "GET /v3/users", s.listUsers)
+	s.handle(mux, "POST /v3.0/OS-CREDENTIAL/securitytokens", s.createSecurityToken)
+	s.handle(mux, "POST /v3.0/OS-AUTH/id-token/tokens", s.createFederatedToken)
+}
+
+func fakeProject() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          ProjectID,
+		"name":        Region,
+		"domain_id":   DomainID,
+		"description": "",
+		"enabled":     true,
+		"is_domain":   false,
+		"parent_id":   DomainID,
+	}
+}
+
+func fakeDomain() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   DomainID,
+		"name": DomainName,
+	}
+}
+
+// createToken issues a project-scoped token for any password, token or agency authentication.
+func (s *Server) createToken(w http.ResponseWriter, _ *http.Request) (int, interface{}) {
+	now := time.Now().UTC()
+	project := fakeProject()
+	w.Header().Set("X-Subject-Token", "fake-token-"+s.newID())
+	return http.StatusCreated, map[string]interface{}{
+		"token": map[string]interface{}{
+			"methods":    []string{"password"},
+			"issued_at":  now.Format(timeFormat),
+			"expires_at": now.Add(24 * time.Hour).Format(timeFormat),
+			"catalog":    []interface{}{},
+			"project": map[string]interface{}{
+				"id":     project["id"],
+				"name":   project["name"],
+				"domain": fakeDomain(),
+			},
+			"user": map[string]interface{}{
+				"id":     UserID,
+				"name":   DomainName,
+				"domain": fakeDomain(),
+			},
+			"roles": []interface{}{
+				map[string]interface{}{"id": "0", "name": "te_admin"},
+			},
+		},
+	}
+}
+
+// listProjects returns the only project which is named as the region, it can be filtered by the name.
+func (s *Server) listProjects(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	projects := make([]interface{}, 0, 1)
+	if name := r.URL.Query().Get("name"); name == "" || name == Region {
+		projects = append(projects, fakeProject())
+	}
+	return http.StatusOK, map[string]interface{}{
+		"projects": projects,
+		"links": map[string]interface{}{
+			"self": s.URL + r.URL.String(),
+		},
+	}
+}
+
+func (s *Server) listDomains(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"domains": []interface{}{fakeDomain()},
+		"links": map[string]interface{}{
+			"self": s.URL + r.URL.String(),
+		},
+	}
+}
+
+// listUsers returns a user with the queried name, so that the user ID can be resolved by any user name.
+func (s *Server) listUsers(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	users := make([]interface{}, 0, 1)
+	if name := r.URL.Query().Get("name"); name != "" {
+		users = append(users, map[string]interface{}{
+			"id":        UserID,
+			"name":      name,
+			"domain_id": DomainID,
+			"enabled":   true,
+		})
+	}
+	return http.StatusOK, map[string]interface{}{
+		"users": users,
+		"links": map[string]interface{}{
+			"self": s.URL + r.URL.String(),
+		},
+	}
+}
+
+// createFederatedToken exchanges the OIDC token of the identity provider for a federated token, the token is saved
+// with the identity provider and the OIDC token.
+func (s *Server) createFederatedToken(w http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Auth struct {
+			IdToken struct {
+				ID string `json:"id"`
+			} `json:"id_token"`
+		} `json:"auth"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	idpID := r.Header.Get("X-Idp-Id")
+	if idpID == "" || body.Auth.IdToken.ID == "" {
+		return http.StatusUnauthorized, errorBody("IAM.0044", "the identity provider and the ID token are required")
+	}
+
+	token := fmt.Sprintf("federated-token-%d", s.nextSeq())
+	s.put(KindFederatedToken, map[string]interface{}{
+		"id":       token,
+		"idp_id":   idpID,
+		"id_token": body.Auth.IdToken.ID,
+	})
+	status, response := s.createToken(w, r)
+	w.Header().Set("X-Subject-Token", token)
+	return status, response
+}
+
+// createSecurityToken issues a temporary credential by assuming the agency or by the federated token, the credential
+// is saved with the agency and the access key which signed the request, or with the federated token, so that the
+// tests can check how the credential is issued.
+func (s *Server) createSecurityToken(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Auth struct {
+			Identity struct {
+				Methods    []string `json:"methods"`
+				AssumeRole struct {
+					AgencyName      string `json:"agency_name"`
+					DomainName      string `json:"domain_name"`
+					DurationSeconds int    `json:"duration_seconds"`
+					SessionUser     struct {
+						Name string `json:"name"`
+					} `json:"session_user"`
+				} `json:"assume_role"`
+				Token struct {
+					ID              string `json:"id"`
+					DurationSeconds int    `json:"duration_seconds"`
+				} `json:"token"`
+				Policy    interface{} `json:"policy"`
+				PolicyIDs []string    `json:"policy_ids"`
+			} `json:"identity"`
+		} `json:"auth"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	identity := body.Auth.Identity
+	if len(identity.Methods) == 1 && identity.Methods[0] == "token" {
+		if _, ok := s.get(KindFederatedToken, identity.Token.ID); !ok || r.Header.Get("X-Auth-Token") != identity.Token.ID {
+			return http.StatusUnauthorized, errorBody("IAM.0043", "the token is invalid")
+		}
+		accessKey := fmt.Sprintf("ak-federated-%d", s.nextSeq())
+		s.put(KindSecurityToken, map[string]interface{}{
+			"id":               accessKey,
+			"federated_token":  identity.Token.ID,
+			"duration_seconds": identity.Token.DurationSeconds,
+		})
+		return http.StatusCreated, newCredential(accessKey, identity.Token.DurationSeconds)
+	}
+
+	assumeRole := identity.AssumeRole
+	if assumeRole.AgencyName == "" || assumeRole.DomainName == "" {
+		return badRequest(fmt.Errorf("the agency name and the domain name are required"))
+	}
+
+	accessKey := fmt.Sprintf("ak-%s-%d", assumeRole.AgencyName, s.nextSeq())
+	signedBy := ""
+	if matches := accessKeyRegexp.FindStringSubmatch(r.Header.Get("Authorization")); len(matches) > 1 {
+		signedBy = matches[1]
+	}
+	s.put(KindSecurityToken, map[string]interface{}{
+		"id":                accessKey,
+		"agency_name":       assumeRole.AgencyName,
+		"domain_name":       assumeRole.DomainName,
+		"duration_seconds":  assumeRole.DurationSeconds,
+		"session_user_name": assumeRole.SessionUser.Name,
+		"policy":            identity.Policy,
+		"policy_ids":        identity.PolicyIDs,
+		"signed_by":         signedBy,
+	})
+	return http.StatusCreated, newCredential(accessKey, assumeRole.DurationSeconds)
+}
+
+// newCredential returns the response of a temporary credential, the duration defaults to 15 minutes.
+func newCredential(accessKey string, duration int) map[string]interface{} {
+	if duration == 0 {
+		duration = 900
+	}
+	return map[string]interface{}{
+		"credential": map[string]interface{}{
+			"access":        accessKey,
+			"secret":        "sk-" + accessKey,
+			"securitytoken": "token-" + accessKey,
+			"expires_at":    time.Now().UTC().Add(time.Duration(duration) * time.Second).Format(timeFormat),
+		},
+	}
+}
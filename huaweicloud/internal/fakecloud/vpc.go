@@ -0,0 +1,256 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+func (s *Server) registerVpc(mux *http.ServeMux) {
+	s.handle(mux, "POST /v1/{project_id}/vpcs", s.createVpc)
+	s.handle(mux, "GET /v1/{project_id}/vpcs", s.listVpcs)
+	s.handle(mux, "GET /v1/{project_id}/vpcs/{vpc_id}", s.getVpc)
+	s.handle(mux, "PUT /v1/{project_id}/vpcs/{vpc_id}", s.updateVpc)
+	s.handle(mux, "DELETE /v1/{project_id}/vpcs/{vpc_id}", s.deleteVpc)
+	s.handle(mux, "GET /v3/{project_id}/vpc/vpcs/{vpc_id}", s.getVpcV3)
+
+	s.handle(mux, "POST /v1/{project_id}/subnets", s.createSubnet)
+	s.handle(mux, "GET /v1/{project_id}/subnets", s.listSubnets)
+	s.handle(mux, "GET /v1/{project_id}/subnets/{subnet_id}", s.getSubnet)
+	s.handle(mux, "PUT /v1/{project_id}/vpcs/{vpc_id}/subnets/{subnet_id}", s.updateSubnet)
+	s.handle(mux, "DELETE /v1/{project_id}/vpcs/{vpc_id}/subnets/{subnet_id}", s.deleteSubnet)
+
+	// the tags of VPCs, subnets and EIPs
+	s.handle(mux, "POST /v2.0/{project_id}/{kind}/{id}/tags/action", s.networkTagAction)
+	s.handle(mux, "GET /v2.0/{project_id}/{kind}/{id}/tags", s.listNetworkTags)
+}
+
+func (s *Server) createVpc(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Vpc map[string]interface{} `json:"vpc"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if _, err := netip.ParsePrefix(stringValue(body.Vpc, "cidr")); err != nil {
+		return http.StatusBadRequest, errorBody("VPC.0002", fmt.Sprintf("invalid cidr: %s", err))
+	}
+
+	vpc := map[string]interface{}{
+		"id":                    s.newID(),
+		"name":                  body.Vpc["name"],
+		"cidr":                  body.Vpc["cidr"],
+		"description":           stringValue(body.Vpc, "description"),
+		"enterprise_project_id": stringValueOr(body.Vpc, "enterprise_project_id", "0"),
+		"status":                "OK",
+		"routes":                []interface{}{},
+		"enable_shared_snat":    false,
+		"extend_cidrs":          []interface{}{},
+		"created_at":            time.Now().UTC().Format(timeFormat),
+	}
+	s.put(KindVpc, vpc)
+	return http.StatusOK, map[string]interface{}{"vpc": vpc}
+}
+
+func (s *Server) listVpcs(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+	vpcs := s.list(KindVpc, func(obj map[string]interface{}) bool {
+		return matchQuery(obj, query, "id", "enterprise_project_id")
+	})
+	return http.StatusOK, map[string]interface{}{"vpcs": paginateByMarker(vpcs, query)}
+}
+
+func (s *Server) getVpc(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	vpc, ok := s.get(KindVpc, r.PathValue("vpc_id"))
+	if !ok {
+		return vpcNotFound(r.PathValue("vpc_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"vpc": vpc}
+}
+
+func (s *Server) updateVpc(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	vpc, ok := s.get(KindVpc, r.PathValue("vpc_id"))
+	if !ok {
+		return vpcNotFound(r.PathValue("vpc_id"))
+	}
+
+	var body struct {
+		Vpc map[string]interface{} `json:"vpc"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	merge(vpc, body.Vpc)
+	return http.StatusOK, map[string]interface{}{"vpc": vpc}
+}
+
+// deleteVpc refuses to delete the VPC which still has subnets, as the cloud does.
+func (s *Server) deleteVpc(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	vpcID := r.PathValue("vpc_id")
+	if _, ok := s.get(KindVpc, vpcID); !ok {
+		return vpcNotFound(vpcID)
+	}
+
+	subnets := s.list(KindSubnet, func(obj map[string]interface{}) bool {
+		return obj["vpc_id"] == vpcID
+	})
+	if len(subnets) > 0 {
+		return http.StatusConflict, errorBody("VPC.0103", "the VPC has subnets, delete them first")
+	}
+
+	s.remove(KindVpc, vpcID)
+	return http.StatusNoContent, nil
+}
+
+// getVpcV3 returns the VPC in the format of the v3 API.
+func (s *Server) getVpcV3(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	vpc, ok := s.get(KindVpc, r.PathValue("vpc_id"))
+	if !ok {
+		return vpcNotFound(r.PathValue("vpc_id"))
+	}
+	return http.StatusOK, map[string]interface{}{
+		"request_id": s.newID(),
+		"vpc": map[string]interface{}{
+			"id":                    vpc["id"],
+			"name":                  vpc["name"],
+			"description":           vpc["description"],
+			"cidr":                  vpc["cidr"],
+			"extend_cidrs":          vpc["extend_cidrs"],
+			"status":                "ACTIVE",
+			"project_id":            ProjectID,
+			"enterprise_project_id": vpc["enterprise_project_id"],
+			"tags":                  s.getTags(KindVpc, vpc["id"].(string)),
+			"cloud_resources":       []interface{}{},
+		},
+	}
+}
+
+func vpcNotFound(id string) (int, interface{}) {
+	return http.StatusNotFound, errorBody("VPC.0202", fmt.Sprintf("Query resource by id %s fail.the vpc does not exist", id))
+}
+
+func (s *Server) createSubnet(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	var body struct {
+		Subnet map[string]interface{} `json:"subnet"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	vpcID := stringValue(body.Subnet, "vpc_id")
+	if _, ok := s.get(KindVpc, vpcID); !ok {
+		return vpcNotFound(vpcID)
+	}
+	if _, err := netip.ParsePrefix(stringValue(body.Subnet, "cidr")); err != nil {
+		return http.StatusBadRequest, errorBody("VPC.0002", fmt.Sprintf("invalid cidr: %s", err))
+	}
+
+	subnet := map[string]interface{}{
+		"id":                 s.newID(),
+		"status":             "ACTIVE",
+		"neutron_subnet_id":  s.newID(),
+		"neutron_network_id": s.newID(),
+		"dhcp_enable":        true,
+		"dnsList":            []interface{}{},
+		"extra_dhcp_opts":    []interface{}{},
+		"created_at":         time.Now().UTC().Format(timeFormat),
+	}
+	merge(subnet, body.Subnet)
+	s.put(KindSubnet, subnet)
+	return http.StatusOK, map[string]interface{}{"subnet": subnet}
+}
+
+func (s *Server) listSubnets(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+	subnets := s.list(KindSubnet, func(obj map[string]interface{}) bool {
+		return matchQuery(obj, query, "vpc_id")
+	})
+	return http.StatusOK, map[string]interface{}{"subnets": subnets}
+}
+
+func (s *Server) getSubnet(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	subnet, ok := s.get(KindSubnet, r.PathValue("subnet_id"))
+	if !ok {
+		return subnetNotFound(r.PathValue("subnet_id"))
+	}
+	return http.StatusOK, map[string]interface{}{"subnet": subnet}
+}
+
+func (s *Server) updateSubnet(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	subnet, ok := s.get(KindSubnet, r.PathValue("subnet_id"))
+	if !ok || subnet["vpc_id"] != r.PathValue("vpc_id") {
+		return subnetNotFound(r.PathValue("subnet_id"))
+	}
+
+	var body struct {
+		Subnet map[string]interface{} `json:"subnet"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return badRequest(err)
+	}
+	merge(subnet, body.Subnet)
+	return http.StatusOK, map[string]interface{}{
+		"subnet": map[string]interface{}{
+			"id":     subnet["id"],
+			"status": subnet["status"],
+		},
+	}
+}
+
+// deleteSubnet refuses to delete the subnet which is used by ECS instances.
+func (s *Server) deleteSubnet(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	subnetID := r.PathValue("subnet_id")
+	subnet, ok := s.get(KindSubnet, subnetID)
+	if !ok || subnet["vpc_id"] != r.PathValue("vpc_id") {
+		return subnetNotFound(subnetID)
+	}
+
+	ports := s.list(kindPort, func(obj map[string]interface{}) bool {
+		return obj["subnet_id"] == subnetID
+	})
+	if len(ports) > 0 {
+		return http.StatusConflict, errorBody("VPC.0511", "the subnet is still in use by ECS instances")
+	}
+
+	s.remove(KindSubnet, subnetID)
+	return http.StatusNoContent, nil
+}
+
+func subnetNotFound(id string) (int, interface{}) {
+	return http.StatusNotFound, errorBody("VPC.0202", fmt.Sprintf("Query resource by id %s fail.the subnet does not exist", id))
+}
+
+// networkTagAction handles the tag APIs of VPCs, subnets and EIPs: POST /v2.0/{project_id}/{kind}/{id}/tags/action
+func (s *Server) networkTagAction(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	kind := r.PathValue("kind")
+	if kind != KindVpc && kind != KindSubnet && kind != KindPublicIp {
+		return notFound(kind, r.PathValue("id"))
+	}
+	return s.tagAction(kind, r.PathValue("id"), r)
+}
+
+func (s *Server) listNetworkTags(_ http.ResponseWriter, r *http.Request) (int, interface{}) {
+	kind, id := r.PathValue("kind"), r.PathValue("id")
+	if _, ok := s.get(kind, id); !ok || (kind != KindVpc && kind != KindSubnet && kind != KindPublicIp) {
+		return notFound(kind, id)
+	}
+	return http.StatusOK, map[string]interface{}{"tags": s.getTags(kind, id)}
+}
+
+// allocateAddress returns the n-th available address in the CIDR, the network and gateway addresses are skipped.
+func allocateAddress(cidr string, n int) string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return ""
+	}
+
+	addr := prefix.Masked().Addr().Next()
+	for i := 0; i <= n; i++ {
+		addr = addr.Next()
+	}
+	if !prefix.Contains(addr) {
+		return ""
+	}
+	return addr.String()
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/aad"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/antiddos"
@@ -21,6 +25,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/as"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/bcs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/bms"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/bss"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cbh"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cbr"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cc"
@@ -87,6 +92,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/organizations"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ram"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rds"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rest"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rfs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rms"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/sdrs"
@@ -104,6 +110,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpn"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/waf"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/workspace"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const (
@@ -213,9 +220,9 @@ func Provider() *schema.Provider {
 			},
 
 			"assume_role": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["assume_role"],
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"agency_name": {
@@ -230,6 +237,78 @@ func Provider() *schema.Provider {
 							Description: descriptions["assume_role_domain_name"],
 							DefaultFunc: schema.EnvDefaultFunc("HW_ASSUME_ROLE_DOMAIN_NAME", nil),
 						},
+						"duration": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  descriptions["assume_role_duration"],
+							ValidateFunc: validation.IntBetween(900, 86400),
+						},
+						"policy": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  descriptions["assume_role_policy"],
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"policy_ids": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["assume_role_policy_ids"],
+						},
+						"session_user_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["assume_role_session_user_name"],
+						},
+					},
+				},
+			},
+
+			"assume_role_with_oidc": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["assume_role_with_oidc"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"idp_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["oidc_idp_id"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_OIDC_IDP_ID", nil),
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: descriptions["oidc_token"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_OIDC_TOKEN", ""),
+						},
+						"token_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["oidc_token_file"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_OIDC_TOKEN_FILE", ""),
+						},
+						"token_env": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["oidc_token_env"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_OIDC_TOKEN_ENV", ""),
+						},
+						"audience": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["oidc_audience"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_OIDC_AUDIENCE", ""),
+						},
+						"duration": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  descriptions["oidc_duration"],
+							DefaultFunc:  schema.EnvDefaultFunc("HW_OIDC_DURATION", nil),
+							ValidateFunc: validation.IntBetween(900, 86400),
+						},
 					},
 				},
 			},
@@ -389,6 +468,99 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 				DefaultFunc: schema.EnvDefaultFunc("HW_MAX_RETRIES", 5),
 			},
+
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: descriptions["retry_max_attempts"],
+						},
+						"min_backoff": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: descriptions["retry_min_backoff"],
+						},
+						"max_backoff": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     30,
+							Description: descriptions["retry_max_backoff"],
+						},
+						"jitter": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: descriptions["retry_jitter"],
+						},
+						"retryable_status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: descriptions["retry_retryable_status_codes"],
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"retryable_error_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: descriptions["retry_retryable_error_codes"],
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"rate_limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["rate_limits"],
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["default_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: descriptions["default_tags_tags"],
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["ignore_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: descriptions["ignore_tags_keys"],
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: descriptions["ignore_tags_key_prefixes"],
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -407,6 +579,8 @@ func Provider() *schema.Provider {
 
 			"huaweicloud_bms_flavors": bms.DataSourceBmsFlavors(),
 
+			"huaweicloud_price_estimate": bss.DataSourcePriceEstimate(),
+
 			"huaweicloud_cbr_backup":   cbr.DataSourceBackup(),
 			"huaweicloud_cbr_vaults":   cbr.DataSourceVaults(),
 			"huaweicloud_cbr_policies": cbr.DataSourcePolicies(),
@@ -601,8 +775,9 @@ func Provider() *schema.Provider {
 
 			"huaweicloud_mapreduce_clusters": mrs.DataSourceMrsClusters(),
 
-			"huaweicloud_obs_buckets":       obs.DataSourceObsBuckets(),
-			"huaweicloud_obs_bucket_object": obs.DataSourceObsBucketObject(),
+			"huaweicloud_obs_buckets":        obs.DataSourceObsBuckets(),
+			"huaweicloud_obs_bucket_object":  obs.DataSourceObsBucketObject(),
+			"huaweicloud_obs_bucket_objects": obs.DataSourceObsBucketObjects(),
 
 			"huaweicloud_ram_resource_permissions": ram.DataSourceRAMPermissions(),
 
@@ -624,6 +799,8 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_mysql_binlog":                  rds.DataSourceRdsMysqlBinlog(),
 			"huaweicloud_rds_parametergroups":               rds.DataSourceParametergroups(),
 
+			"huaweicloud_rest_request": rest.DataSourceRestRequest(),
+
 			"huaweicloud_rms_policy_definitions":           rms.DataSourcePolicyDefinitions(),
 			"huaweicloud_rms_assignment_package_templates": rms.DataSourceTemplates(),
 
@@ -1214,12 +1391,19 @@ func Provider() *schema.Provider {
 			"huaweicloud_networking_vip":           vpc.ResourceNetworkingVip(),
 			"huaweicloud_networking_vip_associate": vpc.ResourceNetworkingVIPAssociateV2(),
 
-			"huaweicloud_obs_bucket":             obs.ResourceObsBucket(),
-			"huaweicloud_obs_bucket_acl":         obs.ResourceOBSBucketAcl(),
-			"huaweicloud_obs_bucket_object":      obs.ResourceObsBucketObject(),
-			"huaweicloud_obs_bucket_object_acl":  obs.ResourceOBSBucketObjectAcl(),
-			"huaweicloud_obs_bucket_policy":      obs.ResourceObsBucketPolicy(),
-			"huaweicloud_obs_bucket_replication": obs.ResourceObsBucketReplication(),
+			"huaweicloud_obs_bucket":                           obs.ResourceObsBucket(),
+			"huaweicloud_obs_bucket_acl":                       obs.ResourceOBSBucketAcl(),
+			"huaweicloud_obs_bucket_cors_configuration":        obs.ResourceObsBucketCorsConfiguration(),
+			"huaweicloud_obs_bucket_lifecycle_configuration":   obs.ResourceObsBucketLifecycleConfiguration(),
+			"huaweicloud_obs_bucket_logging":                   obs.ResourceObsBucketLogging(),
+			"huaweicloud_obs_bucket_notification":              obs.ResourceObsBucketNotification(),
+			"huaweicloud_obs_bucket_object":                    obs.ResourceObsBucketObject(),
+			"huaweicloud_obs_bucket_object_acl":                obs.ResourceOBSBucketObjectAcl(),
+			"huaweicloud_obs_bucket_object_lock_configuration": obs.ResourceObsBucketObjectLockConfiguration(),
+			"huaweicloud_obs_bucket_objects_sync":              obs.ResourceObsBucketObjectsSync(),
+			"huaweicloud_obs_bucket_policy":                    obs.ResourceObsBucketPolicy(),
+			"huaweicloud_obs_bucket_replication":               obs.ResourceObsBucketReplication(),
+			"huaweicloud_obs_bucket_website_configuration":     obs.ResourceObsBucketWebsiteConfiguration(),
 
 			"huaweicloud_oms_migration_sync_task":  oms.ResourceMigrationSyncTask(),
 			"huaweicloud_oms_migration_task":       oms.ResourceMigrationTask(),
@@ -1245,6 +1429,8 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_pg_plugin":                    rds.ResourceRdsPgPlugin(),
 			"huaweicloud_rds_pg_hba":                       rds.ResourcePgHba(),
 
+			"huaweicloud_rest_resource": rest.ResourceRestResource(),
+
 			"huaweicloud_rms_policy_assignment":                  rms.ResourcePolicyAssignment(),
 			"huaweicloud_rms_resource_aggregator":                rms.ResourceAggregator(),
 			"huaweicloud_rms_resource_aggregation_authorization": rms.ResourceAggregationAuthorization(),
@@ -1564,6 +1750,19 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	// apply the provider-level default tags and ignore tags to all taggable resources, and the ignore tags to the data
+	// sources which return the tags
+	common.InjectProviderTags(provider.ResourcesMap)
+	common.InjectIgnoreTags(provider.DataSourcesMap)
+
+	// protect the stateful resources from being deleted or replaced by the deletion_protection argument
+	common.InjectDeletionProtection(provider.ResourcesMap, common.DeletionProtectedResources...)
+
+	// record the operations and their API requests as spans if the tracing is enabled by HW_OTEL_EXPORTER
+	if config.TracingEnabled() {
+		common.InjectTracing(provider.ResourcesMap, provider.DataSourcesMap)
+	}
+
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -1628,6 +1827,30 @@ func init() {
 
 		"assume_role_domain_name": "The name of domain for assume role.",
 
+		"assume_role": "The agencies to assume in order, each one is assumed with the credential of the previous one.",
+
+		"assume_role_duration": "The validity period of the temporary credential, in seconds.",
+
+		"assume_role_policy": "The JSON of an inline policy which scopes down the permissions of the temporary credential.",
+
+		"assume_role_policy_ids": "The IDs of the custom policies which scope down the permissions of the temporary credential.",
+
+		"assume_role_session_user_name": "The name of the session user of the temporary credential.",
+
+		"assume_role_with_oidc": "The configuration to exchange an OIDC token for the temporary credential.",
+
+		"oidc_idp_id": "The ID of the OIDC identity provider registered in IAM.",
+
+		"oidc_token": "The OIDC token to exchange for the temporary credential.",
+
+		"oidc_token_file": "The path of the file which contains the OIDC token.",
+
+		"oidc_token_env": "The name of the environment variable which contains the OIDC token.",
+
+		"oidc_audience": "The audience of the OIDC token requested from GitHub Actions.",
+
+		"oidc_duration": "The validity period of the temporary credential exchanged by the OIDC token, in seconds.",
+
 		"cloud": "The endpoint of cloud provider, defaults to myhuaweicloud.com",
 
 		"endpoints": "The custom endpoints used to override the default endpoint URL.",
@@ -1640,11 +1863,38 @@ func init() {
 
 		"max_retries": "How many times HTTP connection should be retried until giving up.",
 
+		"retry": "Configuration block with the retry policy of the API requests.",
+
+		"retry_max_attempts": "The maximum number of attempts for an API request, including the first one. " +
+			"Defaults to max_retries plus one.",
+
+		"retry_min_backoff": "The wait time in seconds before the first retry, it doubles for each subsequent retry.",
+
+		"retry_max_backoff": "The maximum wait time in seconds between two attempts.",
+
+		"retry_jitter": "Whether to randomize the wait time between two attempts.",
+
+		"retry_retryable_status_codes": "The HTTP status codes which will be retried.",
+
+		"retry_retryable_error_codes": "The error codes in the response body which will be retried.",
+
 		"enterprise_project_id": "enterprise project id",
+
+		"rate_limits": "The maximum number of API requests per second of each service, the key is the service name.",
+
+		"default_tags": "Configuration block with resource tag settings to apply across all resources.",
+
+		"default_tags_tags": "The key/value pairs of tags which will be merged into the tags of all taggable resources.",
+
+		"ignore_tags": "Configuration block with resource tag settings to ignore across all resources.",
+
+		"ignore_tags_keys": "The tag keys which will be ignored across all resources.",
+
+		"ignore_tags_key_prefixes": "The tag key prefixes which will be ignored across all resources.",
 	}
 }
 
-func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersion string) (interface{},
+func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{},
 	diag.Diagnostics) {
 	var tenantName, tenantID, delegatedProject, identityEndpoint string
 	region := d.Get("region").(string)
@@ -1716,6 +1966,7 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 		SharedConfigFile:    d.Get("shared_config_file").(string),
 		Profile:             d.Get("profile").(string),
 		TerraformVersion:    terraformVersion,
+		CassetteName:        config.CassetteNameFromContext(ctx),
 		RegionProjectIDMap:  make(map[string]string),
 		RPLock:              new(sync.Mutex),
 		SecurityKeyLock:     new(sync.Mutex),
@@ -1732,9 +1983,42 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 			config.AssumeRoleDomain = delegatedDomianName
 		}
 	} else {
-		assumeRole := assumeRoleList[0].(map[string]interface{})
-		config.AssumeRoleAgency = assumeRole["agency_name"].(string)
-		config.AssumeRoleDomain = assumeRole["domain_name"].(string)
+		config.AssumeRoles = buildAssumeRoles(assumeRoleList)
+	}
+
+	// get assume role with OIDC
+	assumeRoleWithOIDC, err := buildAssumeRoleWithOIDC(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	config.AssumeRoleWithOIDC = assumeRoleWithOIDC
+
+	// get retry policy
+	if retryList := d.Get("retry").([]interface{}); len(retryList) > 0 && retryList[0] != nil {
+		config.RetryPolicy = buildRetryPolicy(retryList[0].(map[string]interface{}), config.MaxRetries)
+	}
+
+	// get rate limits
+	if rateLimits := d.Get("rate_limits").(map[string]interface{}); len(rateLimits) > 0 {
+		config.RateLimits = make(map[string]int, len(rateLimits))
+		for name, limit := range rateLimits {
+			config.RateLimits[name] = limit.(int)
+		}
+	}
+
+	// get default tags
+	if defaultTagsList := d.Get("default_tags").([]interface{}); len(defaultTagsList) > 0 && defaultTagsList[0] != nil {
+		defaultTags := defaultTagsList[0].(map[string]interface{})
+		config.DefaultTags = defaultTags["tags"].(map[string]interface{})
+	}
+
+	// get ignore tags
+	if ignoreTagsList := d.Get("ignore_tags").([]interface{}); len(ignoreTagsList) > 0 && ignoreTagsList[0] != nil {
+		ignoreTags := ignoreTagsList[0].(map[string]interface{})
+		config.IgnoreTags = &utils.IgnoreTagsConfig{
+			Keys:        utils.ExpandToStringListBySet(ignoreTags["keys"].(*schema.Set)),
+			KeyPrefixes: utils.ExpandToStringListBySet(ignoreTags["key_prefixes"].(*schema.Set)),
+		}
 	}
 
 	// get custom endpoints
@@ -1751,6 +2035,82 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 	return &config, nil
 }
 
+func buildAssumeRoles(assumeRoleList []interface{}) []config.AssumeRole {
+	assumeRoles := make([]config.AssumeRole, 0, len(assumeRoleList))
+	for _, v := range assumeRoleList {
+		assumeRole, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		assumeRoles = append(assumeRoles, config.AssumeRole{
+			AgencyName:      assumeRole["agency_name"].(string),
+			DomainName:      assumeRole["domain_name"].(string),
+			Duration:        int32(assumeRole["duration"].(int)),
+			Policy:          assumeRole["policy"].(string),
+			PolicyIDs:       utils.ExpandToStringList(assumeRole["policy_ids"].([]interface{})),
+			SessionUserName: assumeRole["session_user_name"].(string),
+		})
+	}
+	return assumeRoles
+}
+
+func buildAssumeRoleWithOIDC(d *schema.ResourceData) (*config.AssumeRoleWithOIDC, error) {
+	if oidcList := d.Get("assume_role_with_oidc").([]interface{}); len(oidcList) > 0 && oidcList[0] != nil {
+		oidc := oidcList[0].(map[string]interface{})
+		return &config.AssumeRoleWithOIDC{
+			IdpID:     oidc["idp_id"].(string),
+			Token:     oidc["token"].(string),
+			TokenFile: oidc["token_file"].(string),
+			TokenEnv:  oidc["token_env"].(string),
+			Audience:  oidc["audience"].(string),
+			Duration:  int32(oidc["duration"].(int)),
+		}, nil
+	}
+
+	// without assume_role_with_oidc block in provider
+	if idpID := os.Getenv("HW_OIDC_IDP_ID"); idpID != "" {
+		var duration int
+		if v := os.Getenv("HW_OIDC_DURATION"); v != "" {
+			var err error
+			duration, err = strconv.Atoi(v)
+			if err != nil || duration < 900 || duration > 86400 {
+				return nil, fmt.Errorf("invalid value of HW_OIDC_DURATION: %q, it should be an integer from 900 to "+
+					"86400", v)
+			}
+		}
+		return &config.AssumeRoleWithOIDC{
+			IdpID:     idpID,
+			Token:     os.Getenv("HW_OIDC_TOKEN"),
+			TokenFile: os.Getenv("HW_OIDC_TOKEN_FILE"),
+			TokenEnv:  os.Getenv("HW_OIDC_TOKEN_ENV"),
+			Audience:  os.Getenv("HW_OIDC_AUDIENCE"),
+			Duration:  int32(duration),
+		}, nil
+	}
+	return nil, nil
+}
+
+func buildRetryPolicy(raw map[string]interface{}, maxRetries int) *config.RetryPolicy {
+	policy := config.NewRetryPolicy(maxRetries)
+	if v := raw["max_attempts"].(int); v > 0 {
+		policy.MaxAttempts = v
+	}
+	policy.MinBackoff = time.Duration(raw["min_backoff"].(int)) * time.Second
+	policy.MaxBackoff = time.Duration(raw["max_backoff"].(int)) * time.Second
+	policy.Jitter = raw["jitter"].(bool)
+
+	if statusCodes := raw["retryable_status_codes"].(*schema.Set); statusCodes.Len() > 0 {
+		policy.RetryableStatusCodes = make([]int, 0, statusCodes.Len())
+		for _, code := range statusCodes.List() {
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+		}
+	}
+	if errorCodes := raw["retryable_error_codes"].(*schema.Set); errorCodes.Len() > 0 {
+		policy.RetryableErrorCodes = utils.ExpandToStringListBySet(errorCodes)
+	}
+	return policy
+}
+
 func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error) {
 	endpoints := d.Get("endpoints").(map[string]interface{})
 	epMap := make(map[string]string)
@@ -0,0 +1,191 @@
+package huaweicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+)
+
+// resourceMove moves the state of the deprecated resource type to its replacement, the upgrade function converts the
+// state of the source type to the one of the target type, the attributes which are not in the target schema are
+// removed after the upgrade.
+type resourceMove struct {
+	Source  string
+	Target  string
+	Upgrade schema.StateUpgradeFunc
+}
+
+// deprecatedResourceMoves are the moves whose schemas are different. The aliases whose attributes are the same as
+// the canonical types, e.g. huaweicloud_compute_instance_v2, are found by buildResourceMoves.
+var deprecatedResourceMoves = []resourceMove{
+	{
+		Source: "huaweicloud_blockstorage_volume_v2",
+		Target: "huaweicloud_evs_volume",
+		Upgrade: common.RemoveStateAttributes("metadata", "source_vol_id", "consistency_group_id",
+			"source_replica"),
+	},
+	{
+		Source: "huaweicloud_networking_floatingip_v2",
+		Target: "huaweicloud_vpc_eip",
+		Upgrade: common.ChainStateUpgrades(
+			common.RenameStateAttributes(map[string]string{"fixed_ip": "private_ip"}),
+			common.RemoveStateAttributes("pool", "tenant_id", "value_specs"),
+			common.SetStateAttributeDefaults(map[string]interface{}{"charging_mode": "postPaid"}),
+		),
+	},
+	{
+		Source: "huaweicloud_compute_floatingip_v2",
+		Target: "huaweicloud_vpc_eip",
+		Upgrade: common.ChainStateUpgrades(
+			common.RenameStateAttributes(map[string]string{"fixed_ip": "private_ip"}),
+			common.RemoveStateAttributes("pool"),
+			common.SetStateAttributeDefaults(map[string]interface{}{"charging_mode": "postPaid"}),
+		),
+	},
+}
+
+var aliasSuffixRegexp = regexp.MustCompile(`_v\d+$`)
+
+// buildResourceMoves returns the upgrade functions of the moves, which are indexed by the target type and the source
+// type. The alias, e.g. huaweicloud_vpc_v1, can be moved to the type without the version suffix if all its attributes
+// have the same types in the target schema.
+func buildResourceMoves(p *schema.Provider) map[string]map[string]schema.StateUpgradeFunc {
+	moves := make(map[string]map[string]schema.StateUpgradeFunc)
+	addMove := func(source, target string, upgrade schema.StateUpgradeFunc) {
+		if moves[target] == nil {
+			moves[target] = make(map[string]schema.StateUpgradeFunc)
+		}
+		moves[target][source] = upgrade
+	}
+
+	for source, r := range p.ResourcesMap {
+		target := aliasSuffixRegexp.ReplaceAllString(source, "")
+		if target == source || p.ResourcesMap[target] == nil {
+			continue
+		}
+		if isStateCompatible(r, p.ResourcesMap[target]) {
+			addMove(source, target, nil)
+		}
+	}
+	for _, move := range deprecatedResourceMoves {
+		if p.ResourcesMap[move.Source] != nil && p.ResourcesMap[move.Target] != nil {
+			addMove(move.Source, move.Target, move.Upgrade)
+		}
+	}
+	return moves
+}
+
+// isStateCompatible returns true if all the attributes and blocks of the source schema have the same types in the
+// target schema, so that the state can be moved without any conversion.
+func isStateCompatible(source, target *schema.Resource) bool {
+	targetTypes := target.CoreConfigSchema().ImpliedType().AttributeTypes()
+	for k, t := range source.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		if targetType, ok := targetTypes[k]; !ok || !targetType.Equals(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// moveStateServer supports the moved blocks from the deprecated resource types to their replacements, the other
+// RPCs are served by the SDKv2 provider server.
+type moveStateServer struct {
+	tfprotov5.ProviderServer
+
+	provider *schema.Provider
+	moves    map[string]map[string]schema.StateUpgradeFunc
+}
+
+func newMoveStateServer(p *schema.Provider) func() tfprotov5.ProviderServer {
+	moves := buildResourceMoves(p)
+	return func() tfprotov5.ProviderServer {
+		return &moveStateServer{
+			ProviderServer: p.GRPCProvider(),
+			provider:       p,
+			moves:          moves,
+		}
+	}
+}
+
+// isHuaweiCloudProvider returns true if the provider address, e.g. registry.terraform.io/huaweicloud/huaweicloud, is
+// of this provider. The hostname and the namespace are not checked, so the mirrors and the local builds are supported.
+func isHuaweiCloudProvider(address string) bool {
+	return address[strings.LastIndex(address, "/")+1:] == "huaweicloud"
+}
+
+// MoveResourceState upgrades the source state to the current schema version of the source type, converts it by the
+// upgrade function of the move, and then upgrades it as the state of the target type. The private state, which
+// saves the timeouts of the source resource, is dropped. The resources of the other providers are not moved even if
+// their type names are the same.
+func (s *moveStateServer) MoveResourceState(ctx context.Context,
+	req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("MoveResourceState request is nil")
+	}
+	upgrade, ok := s.moves[req.TargetTypeName][req.SourceTypeName]
+	if !ok || !isHuaweiCloudProvider(req.SourceProviderAddress) {
+		return s.ProviderServer.MoveResourceState(ctx, req)
+	}
+
+	rawState, err := s.moveRawState(ctx, req, upgrade)
+	if err != nil {
+		return &tfprotov5.MoveResourceStateResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Error moving resource state",
+					Detail: fmt.Sprintf("The state of %s can not be moved to %s: %s", req.SourceTypeName,
+						req.TargetTypeName, err),
+				},
+			},
+		}, nil
+	}
+
+	upgradeResp, err := s.ProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		Version:  int64(s.provider.ResourcesMap[req.TargetTypeName].SchemaVersion),
+		RawState: &tfprotov5.RawState{JSON: rawState},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.MoveResourceStateResponse{
+		TargetState: upgradeResp.UpgradedState,
+		Diagnostics: upgradeResp.Diagnostics,
+	}, nil
+}
+
+func (s *moveStateServer) moveRawState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest,
+	upgrade schema.StateUpgradeFunc) ([]byte, error) {
+	if req.SourceState == nil || req.SourceState.JSON == nil {
+		return nil, fmt.Errorf("the source state is not in JSON format")
+	}
+
+	var rawState map[string]interface{}
+	if err := json.Unmarshal(req.SourceState.JSON, &rawState); err != nil {
+		return nil, fmt.Errorf("error parsing the source state: %s", err)
+	}
+
+	meta := s.provider.Meta()
+	rawState, err := common.UpgradeJSONState(ctx, s.provider.ResourcesMap[req.SourceTypeName],
+		int(req.SourceSchemaVersion), rawState, meta)
+	if err != nil {
+		return nil, fmt.Errorf("error upgrading the source state: %s", err)
+	}
+	if upgrade != nil {
+		if rawState, err = upgrade(ctx, rawState, meta); err != nil {
+			return nil, err
+		}
+	}
+	if rawState == nil {
+		return nil, fmt.Errorf("the source state is empty")
+	}
+	return json.Marshal(rawState)
+}
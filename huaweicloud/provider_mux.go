@@ -0,0 +1,35 @@
+package huaweicloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/framework"
+)
+
+// ProtoV5ProviderServerFactory returns the factory of the provider server which muxes the SDKv2 provider and
+// the framework provider. The SDKv2 provider must be the first one, so that it's configured before the framework
+// provider which shares its configuration.
+func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return NewProtoV5ProviderServerFactory(ctx, Provider())
+}
+
+// NewProtoV5ProviderServerFactory is the same as ProtoV5ProviderServerFactory, but muxes the specified SDKv2 provider,
+// such as the provider of an acceptance test whose configure function is customized.
+func NewProtoV5ProviderServerFactory(ctx context.Context, primary *schema.Provider) (func() tfprotov5.ProviderServer,
+	error) {
+	servers := []func() tfprotov5.ProviderServer{
+		newMoveStateServer(primary),
+		providerserver.NewProtocol5(framework.New(primary)),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
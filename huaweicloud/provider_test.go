@@ -5,6 +5,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
@@ -241,3 +243,127 @@ func envVarFile(varName string) (string, error) {
 	}
 	return tmpFile.Name(), nil
 }
+
+func TestProvider_muxServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	for _, name := range []string{"normalize_json", "cidr_subnet_with_gateway", "encrypt_password", "parse_import_id"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("the provider function %s is not registered", name)
+		}
+	}
+	for _, name := range []string{"huaweicloud_csms_secret_version", "huaweicloud_kms_data_key"} {
+		if _, ok := resp.EphemeralResourceSchemas[name]; !ok {
+			t.Errorf("the ephemeral resource %s is not registered", name)
+		}
+	}
+}
+
+func TestProvider_moveResourceState(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	server := serverFactory()
+	if _, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		source, target, state string
+		expected              map[string]string
+	}{
+		{
+			source:   "huaweicloud_compute_instance_v2",
+			target:   "huaweicloud_compute_instance",
+			state:    `{"id":"ecs-id","name":"ecs-test","flavor_id":"s6.small.1"}`,
+			expected: map[string]string{"id": "ecs-id", "name": "ecs-test", "flavor_id": "s6.small.1"},
+		},
+		{
+			source:   "huaweicloud_blockstorage_volume_v2",
+			target:   "huaweicloud_evs_volume",
+			state:    `{"id":"volume-id","name":"volume-test","size":10,"volume_type":"SSD","metadata":{"foo":"bar"}}`,
+			expected: map[string]string{"id": "volume-id", "name": "volume-test", "volume_type": "SSD"},
+		},
+		{
+			source: "huaweicloud_networking_floatingip_v2",
+			target: "huaweicloud_vpc_eip",
+			state: `{"id":"eip-id","address":"100.1.1.1","fixed_ip":"192.168.0.10","pool":"admin_external_net",` +
+				`"value_specs":{"foo":"bar"}}`,
+			expected: map[string]string{"id": "eip-id", "address": "100.1.1.1", "private_ip": "192.168.0.10",
+				"charging_mode": "postPaid"},
+		},
+		{
+			source: "huaweicloud_compute_floatingip_v2",
+			target: "huaweicloud_vpc_eip",
+			state:  `{"id":"eip-id","address":"100.1.1.1","fixed_ip":"192.168.0.10","instance_id":"ecs-id"}`,
+			expected: map[string]string{"id": "eip-id", "address": "100.1.1.1", "private_ip": "192.168.0.10",
+				"instance_id": "ecs-id"},
+		},
+	}
+	for _, c := range cases {
+		resp, err := server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/huaweicloud/huaweicloud",
+			SourceTypeName:        c.source,
+			SourceState:           &tfprotov5.RawState{JSON: []byte(c.state)},
+			TargetTypeName:        c.target,
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic of moving %s: %s: %s", c.source, d.Summary, d.Detail)
+		}
+
+		ty := Provider().ResourcesMap[c.target].CoreConfigSchema().ImpliedType()
+		state, err := msgpack.Unmarshal(resp.TargetState.MsgPack, ty)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for k, v := range c.expected {
+			if actual := state.GetAttr(k).AsString(); actual != v {
+				t.Errorf("expected %s of %s to be %q, but got %q", k, c.target, v, actual)
+			}
+		}
+	}
+
+	// the resources of the other providers can not be moved
+	resp, err := server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/example/other",
+		SourceTypeName:        "huaweicloud_vpc_v1",
+		SourceState:           &tfprotov5.RawState{JSON: []byte(`{"id":"vpc-id"}`)},
+		TargetTypeName:        "huaweicloud_vpc",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Errorf("expected an error moving the resource of the other provider")
+	}
+
+	// the resource types which are not replaced by each other can not be moved
+	resp, err = server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "huaweicloud_vpc_v1",
+		SourceState:    &tfprotov5.RawState{JSON: []byte(`{"id":"vpc-id"}`)},
+		TargetTypeName: "huaweicloud_evs_volume",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Errorf("expected an error moving huaweicloud_vpc_v1 to huaweicloud_evs_volume")
+	}
+}
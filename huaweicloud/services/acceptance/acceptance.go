@@ -2,10 +2,12 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -282,6 +284,10 @@ var TestAccProviderFactories map[string]func() (*schema.Provider, error)
 // TestAccProvider is the "main" provider instance
 var TestAccProvider *schema.Provider
 
+// TestAccProtoV5ProviderFactories is a static map containing the muxed provider server, which is required by the
+// tests of the capabilities implemented by the framework provider, such as provider-defined functions.
+var TestAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
 func init() {
 	TestAccProvider = huaweicloud.Provider()
 
@@ -294,6 +300,16 @@ func init() {
 			return TestAccProvider, nil
 		},
 	}
+
+	TestAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"huaweicloud": func() (tfprotov5.ProviderServer, error) {
+			serverFactory, err := huaweicloud.ProtoV5ProviderServerFactory(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			return serverFactory(), nil
+		},
+	}
 }
 
 func preCheckRequiredEnvVars(t *testing.T) {
@@ -362,6 +378,7 @@ func TestAccPreCheck(t *testing.T) {
 	}
 
 	preCheckRequiredEnvVars(t)
+	preCheckVcr(t)
 }
 
 // lintignore:AT003
@@ -477,19 +494,19 @@ func TestAccPreCheckMaas(t *testing.T) {
 }
 
 func RandomAccResourceName() string {
-	return fmt.Sprintf("tf_test_%s", acctest.RandString(5))
+	return fmt.Sprintf("%s%s", ResourceNamePrefix, randomString(newRandomSource(), 5, acctest.CharSetAlphaNum))
 }
 
 func RandomAccResourceNameWithDash() string {
-	return fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	return fmt.Sprintf("%s%s", ResourceNamePrefixWithDash, randomString(newRandomSource(), 5, acctest.CharSetAlphaNum))
 }
 
 func RandomCidr() string {
-	return fmt.Sprintf("172.16.%d.0/24", acctest.RandIntRange(0, 255))
+	return fmt.Sprintf("172.16.%d.0/24", randomIntRange(newRandomSource(), 0, 255))
 }
 
 func RandomCidrAndGatewayIp() (string, string) {
-	seed := acctest.RandIntRange(0, 255)
+	seed := randomIntRange(newRandomSource(), 0, 255)
 	return fmt.Sprintf("172.16.%d.0/24", seed), fmt.Sprintf("172.16.%d.1", seed)
 }
 
@@ -500,11 +517,13 @@ func RandomPassword(customChars ...string) string {
 	} else {
 		specialChars = customChars[0]
 	}
+
+	src := newRandomSource()
 	return fmt.Sprintf("%s%s%s%d",
-		acctest.RandStringFromCharSet(2, "ABCDEFGHIJKLMNOPQRSTUVWXZY"),
-		acctest.RandStringFromCharSet(3, acctest.CharSetAlpha),
-		acctest.RandStringFromCharSet(2, specialChars),
-		acctest.RandIntRange(1000, 9999))
+		randomString(src, 2, "ABCDEFGHIJKLMNOPQRSTUVWXZY"),
+		randomString(src, 3, acctest.CharSetAlpha),
+		randomString(src, 2, specialChars),
+		randomIntRange(src, 1000, 9999))
 }
 
 // lintignore:AT003
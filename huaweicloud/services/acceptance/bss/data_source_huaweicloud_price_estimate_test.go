@@ -0,0 +1,80 @@
+package bss
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccPriceEstimateDataSource_basic(t *testing.T) {
+	var (
+		dataSourceName = "data.huaweicloud_price_estimate.test"
+		dc             = acceptance.InitDataSourceCheck(dataSourceName)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPriceEstimateDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSourceName, "currency"),
+					resource.TestCheckResourceAttr(dataSourceName, "prices.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "prices.0.resource_type", "compute_instance"),
+					resource.TestCheckResourceAttr(dataSourceName, "prices.0.charging_mode", "postPaid"),
+					resource.TestCheckResourceAttr(dataSourceName, "prices.1.charging_mode", "prePaid"),
+					resource.TestCheckOutput("is_on_demand_priced", "true"),
+					resource.TestCheckOutput("is_period_discounted", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccPriceEstimateDataSource_basic = `
+data "huaweicloud_availability_zones" "test" {}
+
+data "huaweicloud_compute_flavors" "test" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "huaweicloud_price_estimate" "test" {
+  compute_instance {
+    flavor_id         = data.huaweicloud_compute_flavors.test.ids[0]
+    availability_zone = data.huaweicloud_availability_zones.test.names[0]
+    system_disk_type  = "SSD"
+    system_disk_size  = 40
+  }
+
+  evs_volume {
+    volume_type       = "SSD"
+    size              = 100
+    availability_zone = data.huaweicloud_availability_zones.test.names[0]
+    charging_mode     = "prePaid"
+    period_unit       = "month"
+    period            = 1
+  }
+
+  vpc_eip {
+    bandwidth_size = 5
+    charging_mode  = "prePaid"
+    period_unit    = "month"
+    period         = 1
+  }
+}
+
+output "is_on_demand_priced" {
+  value = data.huaweicloud_price_estimate.test.on_demand_official_amount > 0
+}
+
+output "is_period_discounted" {
+  value = data.huaweicloud_price_estimate.test.period_amount <= data.huaweicloud_price_estimate.test.period_official_amount
+}
+`
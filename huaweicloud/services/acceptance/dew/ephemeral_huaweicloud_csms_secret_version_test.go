@@ -0,0 +1,44 @@
+package dew
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+// The ephemeral resources are supported in Terraform 1.10 and later, and the values are never persisted to the state,
+// so this test only checks whether the secret version can be opened during the plan and apply.
+func TestAccEphemeralCsmsSecretVersion_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acceptance.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralCsmsSecretVersion_basic(name),
+				Check:  resource.TestCheckResourceAttr("huaweicloud_csms_secret.test", "name", name),
+			},
+		},
+	})
+}
+
+func testAccEphemeralCsmsSecretVersion_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_csms_secret" "test" {
+  name        = "%s"
+  secret_text = "this is a password"
+}
+
+ephemeral "huaweicloud_csms_secret_version" "test" {
+  secret_name = huaweicloud_csms_secret.test.name
+}
+
+locals {
+  secret_text = ephemeral.huaweicloud_csms_secret_version.test.secret_text
+}
+`, name)
+}
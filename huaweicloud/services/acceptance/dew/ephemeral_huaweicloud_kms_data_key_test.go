@@ -0,0 +1,45 @@
+package dew
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+// The ephemeral resources are supported in Terraform 1.10 and later, and the values are never persisted to the state,
+// so this test only checks whether the data key can be created during the plan and apply.
+func TestAccEphemeralKmsDataKey_basic(t *testing.T) {
+	keyAlias := acceptance.RandomAccResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheckKms(t) },
+		ProtoV5ProviderFactories: acceptance.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralKmsDataKey_basic(keyAlias),
+				Check:  resource.TestCheckResourceAttr("huaweicloud_kms_key.test", "key_alias", keyAlias),
+			},
+		},
+	})
+}
+
+func testAccEphemeralKmsDataKey_basic(keyAlias string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_kms_key" "test" {
+  key_alias    = "%s"
+  pending_days = "7"
+}
+
+ephemeral "huaweicloud_kms_data_key" "test" {
+  key_id         = huaweicloud_kms_key.test.id
+  datakey_length = "512"
+}
+
+locals {
+  plain_text = ephemeral.huaweicloud_kms_data_key.test.plain_text
+}
+`, keyAlias)
+}
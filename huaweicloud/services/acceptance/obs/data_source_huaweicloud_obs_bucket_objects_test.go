@@ -0,0 +1,85 @@
+package obs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccObsBucketObjectsDataSource_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceNameWithDash()
+	all := "data.huaweicloud_obs_bucket_objects.all"
+	byDelimiter := "data.huaweicloud_obs_bucket_objects.by_delimiter"
+	limited := "data.huaweicloud_obs_bucket_objects.limited"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectsDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(all, "keys.#", "3"),
+					resource.TestCheckResourceAttr(all, "keys.0", "images/a.png"),
+					resource.TestCheckResourceAttr(all, "objects.#", "3"),
+					resource.TestCheckResourceAttr(all, "objects.0.key", "images/a.png"),
+					resource.TestCheckResourceAttr(all, "objects.0.size", "1"),
+					resource.TestCheckResourceAttr(all, "objects.0.storage_class", "STANDARD"),
+					resource.TestCheckResourceAttrSet(all, "objects.0.etag"),
+					resource.TestCheckResourceAttrSet(all, "objects.0.last_modified"),
+					resource.TestCheckResourceAttr(byDelimiter, "keys.#", "1"),
+					resource.TestCheckResourceAttr(byDelimiter, "keys.0", "images/a.png"),
+					resource.TestCheckResourceAttr(byDelimiter, "common_prefixes.#", "1"),
+					resource.TestCheckResourceAttr(byDelimiter, "common_prefixes.0", "images/icons/"),
+					resource.TestCheckResourceAttr(limited, "keys.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccObsBucketObjectsDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_obs_bucket_object" "test" {
+  for_each = toset(["images/a.png", "images/icons/b.png", "images/icons/c.png"])
+
+  bucket  = huaweicloud_obs_bucket.test.bucket
+  key     = each.key
+  content = "x"
+}
+
+data "huaweicloud_obs_bucket_objects" "all" {
+  depends_on = [huaweicloud_obs_bucket_object.test]
+
+  bucket = huaweicloud_obs_bucket.test.bucket
+  prefix = "images/"
+}
+
+data "huaweicloud_obs_bucket_objects" "by_delimiter" {
+  depends_on = [huaweicloud_obs_bucket_object.test]
+
+  bucket    = huaweicloud_obs_bucket.test.bucket
+  prefix    = "images/"
+  delimiter = "/"
+}
+
+data "huaweicloud_obs_bucket_objects" "limited" {
+  depends_on = [huaweicloud_obs_bucket_object.test]
+
+  bucket   = huaweicloud_obs_bucket.test.bucket
+  max_keys = 2
+}
+`, name)
+}
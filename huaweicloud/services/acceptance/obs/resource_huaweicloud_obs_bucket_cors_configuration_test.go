@@ -0,0 +1,119 @@
+package obs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketCorsConfigurationResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	obsClient, err := cfg.ObjectStorageClient(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+	return obsClient.GetBucketCors(state.Primary.ID)
+}
+
+func TestAccObsBucketCorsConfiguration_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_cors_configuration.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketCorsConfigurationResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketCorsConfiguration_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(rName, "rule.0.allowed_origins.0", "https://www.example.com"),
+					resource.TestCheckResourceAttr(rName, "rule.0.allowed_methods.#", "2"),
+					resource.TestCheckResourceAttr(rName, "rule.0.allowed_headers.0", "*"),
+					resource.TestCheckResourceAttr(rName, "rule.0.expose_headers.0", "ETag"),
+					resource.TestCheckResourceAttr(rName, "rule.0.max_age_seconds", "3000"),
+				),
+			},
+			{
+				Config: testAccObsBucketCorsConfiguration_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(rName, "rule.0.allowed_methods.#", "1"),
+					resource.TestCheckResourceAttr(rName, "rule.0.max_age_seconds", "100"),
+					resource.TestCheckResourceAttr(rName, "rule.1.allowed_origins.0", "*"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketCorsConfiguration_base(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%s"
+  storage_class = "STANDARD"
+  acl           = "private"
+  force_destroy = true
+}
+`, name)
+}
+
+func testAccObsBucketCorsConfiguration_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_cors_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  rule {
+    allowed_origins = ["https://www.example.com"]
+    allowed_methods = ["PUT", "POST"]
+    allowed_headers = ["*"]
+    expose_headers  = ["ETag"]
+    max_age_seconds = 3000
+  }
+}
+`, testAccObsBucketCorsConfiguration_base(name))
+}
+
+func testAccObsBucketCorsConfiguration_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_cors_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  rule {
+    allowed_origins = ["https://www.example.com"]
+    allowed_methods = ["PUT"]
+  }
+  rule {
+    allowed_origins = ["*"]
+    allowed_methods = ["GET", "HEAD"]
+  }
+}
+`, testAccObsBucketCorsConfiguration_base(name))
+}
@@ -0,0 +1,191 @@
+package obs
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketLifecycleConfigurationResourceFunc(cfg *config.Config,
+	state *terraform.ResourceState) (interface{}, error) {
+	obsClient, err := cfg.ObjectStorageClient(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+	return obsClient.GetBucketLifecycleConfiguration(state.Primary.ID)
+}
+
+func TestAccObsBucketLifecycleConfiguration_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_lifecycle_configuration.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketLifecycleConfigurationResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketLifecycleConfiguration_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(rName, "rule.0.name", "expire-logs"),
+					resource.TestCheckResourceAttr(rName, "rule.0.prefix", "logs/"),
+					resource.TestCheckResourceAttr(rName, "rule.0.enabled", "true"),
+					resource.TestCheckResourceAttr(rName, "rule.0.expiration.0.days", "365"),
+					resource.TestCheckResourceAttr(rName, "rule.0.transition.0.days", "30"),
+					resource.TestCheckResourceAttr(rName, "rule.0.transition.0.storage_class", "WARM"),
+					// The bucket without the inline lifecycle rules reads the rules of the standalone resource.
+					resource.TestCheckResourceAttr("huaweicloud_obs_bucket.test", "lifecycle_rule.#", "1"),
+				),
+			},
+			{
+				Config: testAccObsBucketLifecycleConfiguration_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(rName, "rule.0.enabled", "false"),
+					resource.TestCheckResourceAttr(rName, "rule.0.transition.#", "0"),
+					resource.TestCheckResourceAttr(rName, "rule.1.name", "clean-uploads"),
+					resource.TestCheckResourceAttr(rName, "rule.1.abort_incomplete_multipart_upload.0.days", "7"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccObsBucketLifecycleConfiguration_conflict(t *testing.T) {
+	name := acceptance.RandomAccResourceNameWithDash()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccObsBucketLifecycleConfiguration_conflict(name),
+				ExpectError: regexp.MustCompile("lifecycle configuration of OBS bucket .* already exists"),
+			},
+		},
+	})
+}
+
+func testAccObsBucketLifecycleConfiguration_base(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%s"
+  storage_class = "STANDARD"
+  acl           = "private"
+  force_destroy = true
+}
+`, name)
+}
+
+func testAccObsBucketLifecycleConfiguration_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_lifecycle_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  rule {
+    name    = "expire-logs"
+    prefix  = "logs/"
+    enabled = true
+
+    expiration {
+      days = 365
+    }
+    transition {
+      days          = 30
+      storage_class = "WARM"
+    }
+  }
+}
+`, testAccObsBucketLifecycleConfiguration_base(name))
+}
+
+func testAccObsBucketLifecycleConfiguration_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_lifecycle_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  rule {
+    name    = "expire-logs"
+    prefix  = "logs/"
+    enabled = false
+
+    expiration {
+      days = 180
+    }
+  }
+  rule {
+    name    = "clean-uploads"
+    enabled = true
+
+    abort_incomplete_multipart_upload {
+      days = 7
+    }
+  }
+}
+`, testAccObsBucketLifecycleConfiguration_base(name))
+}
+
+func testAccObsBucketLifecycleConfiguration_conflict(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%s"
+  storage_class = "STANDARD"
+  acl           = "private"
+  force_destroy = true
+
+  lifecycle_rule {
+    name    = "inline"
+    enabled = true
+
+    expiration {
+      days = 365
+    }
+  }
+}
+
+resource "huaweicloud_obs_bucket_lifecycle_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  rule {
+    name    = "standalone"
+    enabled = true
+
+    expiration {
+      days = 30
+    }
+  }
+}
+`, name)
+}
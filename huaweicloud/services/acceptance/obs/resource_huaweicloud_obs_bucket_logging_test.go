@@ -0,0 +1,96 @@
+package obs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketLoggingResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	obsClient, err := cfg.ObjectStorageClientWithSignature(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketLoggingConfiguration(state.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	if output.TargetBucket == "" {
+		return nil, fmt.Errorf("the logging of OBS bucket %s is disabled", state.Primary.ID)
+	}
+	return output, nil
+}
+
+func TestAccObsBucketLogging_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_logging.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketLoggingResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketLogging_basic(name, "log/"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttrPair(rName, "target_bucket", "huaweicloud_obs_bucket.log", "bucket"),
+					resource.TestCheckResourceAttr(rName, "target_prefix", "log/"),
+					resource.TestCheckResourceAttr(rName, "agency", "live_to_obs"),
+				),
+			},
+			{
+				Config: testAccObsBucketLogging_basic(name, "access-log/"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "target_prefix", "access-log/"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketLogging_basic(name, prefix string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "log" {
+  bucket        = "%[1]s-log"
+  acl           = "log-delivery-write"
+  force_destroy = true
+}
+
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_obs_bucket_logging" "test" {
+  bucket        = huaweicloud_obs_bucket.test.bucket
+  target_bucket = huaweicloud_obs_bucket.log.bucket
+  target_prefix = "%[2]s"
+  agency        = "live_to_obs"
+}
+`, name, prefix)
+}
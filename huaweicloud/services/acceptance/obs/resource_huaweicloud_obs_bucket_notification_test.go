@@ -0,0 +1,156 @@
+package obs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketNotificationResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	region := acceptance.HW_REGION_NAME
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketNotification(state.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	// The topic configurations are always specified in the test configurations.
+	if len(output.TopicConfigurations) == 0 {
+		return nil, fmt.Errorf("the notification configuration of OBS bucket %s is empty", state.Primary.ID)
+	}
+	return output, nil
+}
+
+func TestAccObsBucketNotification_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_notification.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketNotificationResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketNotification_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.#", "1"),
+					resource.TestCheckResourceAttrPair(rName, "topic_configuration.0.topic_urn",
+						"huaweicloud_smn_topic.test", "topic_urn"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.events.#", "1"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.events.0", "ObjectCreated:*"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.prefix", "images/"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.suffix", ".jpg"),
+					resource.TestCheckResourceAttrSet(rName, "topic_configuration.0.id"),
+					resource.TestCheckResourceAttr(rName, "function_configuration.#", "0"),
+				),
+			},
+			{
+				Config: testAccObsBucketNotification_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.#", "1"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.id", "remove-logs"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.events.#", "2"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.prefix", "logs/"),
+					resource.TestCheckResourceAttr(rName, "topic_configuration.0.suffix", ""),
+					resource.TestCheckResourceAttr(rName, "function_configuration.#", "1"),
+					resource.TestCheckResourceAttrPair(rName, "function_configuration.0.function_urn",
+						"huaweicloud_fgs_function.test", "urn"),
+					resource.TestCheckResourceAttr(rName, "function_configuration.0.events.0", "ObjectCreated:Put"),
+					resource.TestCheckResourceAttr(rName, "function_configuration.0.suffix", ".png"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketNotification_base(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  storage_class = "STANDARD"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_smn_topic" "test" {
+  name                     = "%[1]s"
+  services_publish_allowed = "obs"
+}
+
+resource "huaweicloud_fgs_function" "test" {
+  name        = "%[1]s"
+  app         = "default"
+  handler     = "index.handler"
+  memory_size = 128
+  timeout     = 3
+  runtime     = "Python2.7"
+  code_type   = "inline"
+  func_code   = "e42a37a22f4988ba7a681e3042e5c7d13c04e6c1"
+}
+`, name)
+}
+
+func testAccObsBucketNotification_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_notification" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  topic_configuration {
+    topic_urn = huaweicloud_smn_topic.test.topic_urn
+    events    = ["ObjectCreated:*"]
+    prefix    = "images/"
+    suffix    = ".jpg"
+  }
+}
+`, testAccObsBucketNotification_base(name))
+}
+
+func testAccObsBucketNotification_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_notification" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+
+  topic_configuration {
+    id        = "remove-logs"
+    topic_urn = huaweicloud_smn_topic.test.topic_urn
+    events    = ["ObjectRemoved:Delete", "ObjectRemoved:DeleteMarkerCreated"]
+    prefix    = "logs/"
+  }
+
+  function_configuration {
+    function_urn = huaweicloud_fgs_function.test.urn
+    events       = ["ObjectCreated:Put"]
+    suffix       = ".png"
+  }
+}
+`, testAccObsBucketNotification_base(name))
+}
@@ -0,0 +1,112 @@
+package obs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketObjectLockConfigurationResourceFunc(cfg *config.Config,
+	state *terraform.ResourceState) (interface{}, error) {
+	region := acceptance.HW_REGION_NAME
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+
+	signedURL, err := obsClient.CreateSignedUrl(&obs.CreateSignedUrlInput{
+		Method:      obs.HttpMethodGet,
+		Bucket:      state.Primary.ID,
+		SubResource: "object-lock",
+	})
+	if err != nil {
+		return nil, err
+	}
+	output, err := obsClient.GetObjectWithSignedUrl(signedURL.SignedUrl, signedURL.ActualSignedRequestHeaders)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	// The WORM can not be disabled, the configuration is deleted if the default retention is removed.
+	if !strings.Contains(string(body), "<DefaultRetention>") {
+		return nil, fmt.Errorf("the default retention of OBS bucket %s is not found", state.Primary.ID)
+	}
+	return string(body), nil
+}
+
+func TestAccObsBucketObjectLockConfiguration_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_object_lock_configuration.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketObjectLockConfigurationResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectLockConfiguration_basic(name, "days = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "mode", "COMPLIANCE"),
+					resource.TestCheckResourceAttr(rName, "days", "1"),
+					resource.TestCheckResourceAttr(rName, "years", "0"),
+				),
+			},
+			{
+				Config: testAccObsBucketObjectLockConfiguration_basic(name, "years = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "days", "0"),
+					resource.TestCheckResourceAttr(rName, "years", "1"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketObjectLockConfiguration_basic(name, period string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  storage_class = "STANDARD"
+  acl           = "private"
+  versioning    = true
+}
+
+resource "huaweicloud_obs_bucket_object_lock_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+  mode   = "COMPLIANCE"
+  %[2]s
+}
+`, name, period)
+}
@@ -1,9 +1,12 @@
 package obs
 
 import (
+	"bytes"
+	"crypto/md5"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -93,6 +96,122 @@ func TestAccObsBucketObject_content(t *testing.T) {
 	})
 }
 
+func TestAccObsBucketObject_multipart(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_obs_bucket_object.object"
+
+	tmpFile, err := os.CreateTemp("", "tf-acc-obs-obj-multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	// write 3 MB data to the tempfile, which is uploaded by 3 parts
+	initialData := bytes.Repeat([]byte("a"), 3*1024*1024)
+	updatedData := bytes.Repeat([]byte("b"), 3*1024*1024)
+	initialHash := fmt.Sprintf("%x", md5.Sum(initialData))
+	updatedHash := fmt.Sprintf("%x", md5.Sum(updatedData))
+	err = os.WriteFile(tmpFile.Name(), initialData, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckObsBucketObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectConfig_multipart(rInt, tmpFile.Name(), initialHash),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "size", "3145728"),
+					resource.TestCheckResourceAttr(resourceName, "source_hash", initialHash),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.release", "v1"),
+					resource.TestCheckResourceAttr(resourceName, "cache_control", "max-age=300"),
+					resource.TestCheckResourceAttr(resourceName, "content_disposition", "attachment"),
+					resource.TestCheckResourceAttr(resourceName, "content_encoding", "identity"),
+					resource.TestCheckResourceAttr(resourceName, "expires", "Thu, 01 Jan 2099 00:00:00 GMT"),
+				),
+			},
+			{
+				// the update is triggered by the source hash, the etag of the multipart upload is not the MD5
+				PreConfig: func() {
+					if err := os.WriteFile(tmpFile.Name(), updatedData, 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccObsBucketObjectConfig_multipart(rInt, tmpFile.Name(), updatedHash),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "source_hash", updatedHash),
+					resource.TestCheckResourceAttrSet(resourceName, "etag"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObsBucketObject_retention(t *testing.T) {
+	var versionID string
+	name := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_obs_bucket_object.object"
+
+	// the object can not be deleted until the retention expires, so the retention is as short as possible
+	initialDate := time.Now().UTC().Add(5 * time.Minute).Truncate(time.Second)
+	extendedDate := initialDate.Add(2 * time.Minute)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckObsBucketObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectConfig_retention(name, initialDate.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "object_lock_mode", "COMPLIANCE"),
+					resource.TestCheckResourceAttr(resourceName, "object_lock_retain_until_date",
+						initialDate.Format(time.RFC3339)),
+					resource.TestCheckResourceAttrWith(resourceName, "version_id", func(value string) error {
+						versionID = value
+						return nil
+					}),
+				),
+			},
+			{
+				// only the retention is extended, the object is not uploaded again
+				Config: testAccObsBucketObjectConfig_retention(name, extendedDate.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_lock_retain_until_date",
+						extendedDate.Format(time.RFC3339)),
+					resource.TestCheckResourceAttrWith(resourceName, "version_id", func(value string) error {
+						if value != versionID {
+							return fmt.Errorf("the object is uploaded again, the version ID is changed from %s to %s",
+								versionID, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// wait for the retention to expire, so the object can be deleted
+				PreConfig: func() {
+					time.Sleep(time.Until(extendedDate.Add(10 * time.Second)))
+				},
+				Config: testAccObsBucketObjectConfig_retention(name, extendedDate.Format(time.RFC3339)),
+			},
+		},
+	})
+}
+
 func testAccCheckObsBucketObjectDestroy(s *terraform.State) error {
 	conf := acceptance.TestAccProvider.Meta().(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(acceptance.HW_REGION_NAME)
@@ -238,3 +357,53 @@ encryption   = true
 }
 `, randInt, source)
 }
+
+func testAccObsBucketObjectConfig_multipart(randInt int, source, sourceHash string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "object_bucket" {
+  bucket = "tf-acc-test-bucket-%d"
+}
+
+resource "huaweicloud_obs_bucket_object" "object" {
+  bucket              = huaweicloud_obs_bucket.object_bucket.bucket
+  key                 = "test-key"
+  source              = "%s"
+  source_hash         = "%s"
+  part_size           = 1
+  concurrency         = 3
+  cache_control       = "max-age=300"
+  content_disposition = "attachment"
+  content_encoding    = "identity"
+  expires             = "Thu, 01 Jan 2099 00:00:00 GMT"
+
+  metadata = {
+    release = "v1"
+  }
+}
+`, randInt, source, sourceHash)
+}
+
+func testAccObsBucketObjectConfig_retention(name, retainUntilDate string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "object_bucket" {
+  bucket        = "%[1]s"
+  force_destroy = true
+  versioning    = true
+}
+
+# enable the WORM without the default retention, which can not be shortened by the object retention
+resource "huaweicloud_obs_bucket_object_lock_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.object_bucket.bucket
+}
+
+resource "huaweicloud_obs_bucket_object" "object" {
+  bucket                        = huaweicloud_obs_bucket.object_bucket.bucket
+  key                           = "test-key"
+  content                       = "some_bucket_content"
+  object_lock_mode              = "COMPLIANCE"
+  object_lock_retain_until_date = "%[2]s"
+
+  depends_on = [huaweicloud_obs_bucket_object_lock_configuration.test]
+}
+`, name, retainUntilDate)
+}
@@ -0,0 +1,168 @@
+package obs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccObsBucketObjectsSync_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_objects_sync.test"
+
+	sourceDir := t.TempDir()
+	writeFile := func(relativePath, content string) {
+		filePath := filepath.Join(sourceDir, relativePath)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("index.html", "<html>index</html>")
+	writeFile("css/main.css", "body {}")
+	writeFile("js/main.js", "console.log('v1')")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckObsBucketObjectsSyncDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectsSync_basic(name, sourceDir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectsSyncObjects(rName, map[string]string{
+						"site/index.html":   "no-cache",
+						"site/css/main.css": "max-age=3600",
+						"site/js/main.js":   "max-age=3600",
+					}),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "files.%", "3"),
+					resource.TestCheckResourceAttrSet(rName, "files.site/index.html"),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile("js/main.js", "console.log('v2')")
+					writeFile("js/vendor.js", "console.log('vendor')")
+					if err := os.Remove(filepath.Join(sourceDir, "css/main.css")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccObsBucketObjectsSync_basic(name, sourceDir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectsSyncObjects(rName, map[string]string{
+						"site/index.html":   "no-cache",
+						"site/js/main.js":   "max-age=3600",
+						"site/js/vendor.js": "max-age=3600",
+					}),
+					resource.TestCheckResourceAttr(rName, "files.%", "3"),
+					resource.TestCheckNoResourceAttr(rName, "files.site/css/main.css"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckObsBucketObjectsSyncObjects checks the objects under the prefix and their cache control headers.
+func testAccCheckObsBucketObjectsSyncObjects(n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		conf := acceptance.TestAccProvider.Meta().(*config.Config)
+		obsClient, err := conf.ObjectStorageClient(acceptance.HW_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("error creating OBS client: %s", err)
+		}
+
+		bucket := rs.Primary.Attributes["bucket"]
+		input := &obs.ListObjectsInput{Bucket: bucket}
+		input.Prefix = rs.Primary.Attributes["prefix"]
+		resp, err := obsClient.ListObjects(input)
+		if err != nil {
+			return fmt.Errorf("error listing objects of OBS bucket %s: %s", bucket, err)
+		}
+		if len(resp.Contents) != len(expected) {
+			return fmt.Errorf("expected %d objects in OBS bucket %s, but got %d", len(expected), bucket,
+				len(resp.Contents))
+		}
+
+		for key, cacheControl := range expected {
+			output, err := obsClient.GetObjectMetadata(&obs.GetObjectMetadataInput{Bucket: bucket, Key: key})
+			if err != nil {
+				return fmt.Errorf("error fetching object %s in bucket %s: %s", key, bucket, err)
+			}
+			if output.CacheControl != cacheControl {
+				return fmt.Errorf("expected cache control of object %s to be %s, but got %s", key, cacheControl,
+					output.CacheControl)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckObsBucketObjectsSyncDestroy(s *terraform.State) error {
+	conf := acceptance.TestAccProvider.Meta().(*config.Config)
+	obsClient, err := conf.ObjectStorageClient(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("error creating OBS client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_obs_bucket_objects_sync" {
+			continue
+		}
+
+		bucket := rs.Primary.Attributes["bucket"]
+		input := &obs.ListObjectsInput{Bucket: bucket}
+		input.Prefix = rs.Primary.Attributes["prefix"]
+		resp, err := obsClient.ListObjects(input)
+		if err != nil {
+			if obsError, ok := err.(obs.ObsError); ok && obsError.Code == "NoSuchBucket" {
+				continue
+			}
+			return fmt.Errorf("error listing objects of OBS bucket %s: %s", bucket, err)
+		}
+		if len(resp.Contents) > 0 {
+			return fmt.Errorf("the synchronized objects still exist in bucket %s", bucket)
+		}
+	}
+	return nil
+}
+
+func testAccObsBucketObjectsSync_basic(name, sourceDir string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket = "%[1]s"
+  acl    = "private"
+}
+
+resource "huaweicloud_obs_bucket_objects_sync" "test" {
+  bucket        = huaweicloud_obs_bucket.test.bucket
+  source_dir    = "%[2]s"
+  prefix        = "site/"
+  cache_control = "max-age=3600"
+
+  override {
+    pattern       = "*.html"
+    cache_control = "no-cache"
+  }
+}
+`, name, filepath.ToSlash(sourceDir))
+}
@@ -2,6 +2,7 @@ package obs
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -360,6 +361,14 @@ func TestAccObsBucket_lifecycle(t *testing.T) {
 						resourceName, "lifecycle_rule.1.abort_incomplete_multipart_upload.0.days", "2147483647"),
 				),
 			},
+			{
+				// removing the inline argument deletes the lifecycle rules
+				Config: testAccObsBucketConfigWithVersioning(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -418,6 +427,34 @@ func TestAccObsBucket_cors(t *testing.T) {
 						resourceName, "cors_rule.0.max_age_seconds", "3000"),
 				),
 			},
+			{
+				// removing the inline argument deletes the CORS rules
+				Config: testAccObsBucketConfigWithoutCORS(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.#", "0"),
+				),
+			},
+			{
+				// the CORS rules managed by the standalone resource are not deleted by the bucket
+				Config: testAccObsBucketConfigWithStandaloneCORS(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_obs_bucket_cors_configuration.test", "rule.0.max_age_seconds", "100"),
+				),
+			},
+			{
+				Config: testAccObsBucketConfigWithStandaloneCORS(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.0.max_age_seconds", "100"),
+				),
+			},
+			{
+				// the bucket does not delete the CORS rules which are not specified by itself
+				Config:      testAccObsBucketConfigWithUnlistedStandaloneCORS(rInt),
+				ExpectError: regexp.MustCompile(`the cors_rule of the bucket .* is not specified by this resource`),
+			},
 		},
 	})
 }
@@ -853,6 +890,55 @@ resource "huaweicloud_obs_bucket" "bucket" {
 `, randInt)
 }
 
+func testAccObsBucketConfigWithoutCORS(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "public-read"
+}
+`, randInt)
+}
+
+func testAccObsBucketConfigWithStandaloneCORS(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "public-read"
+
+  standalone_configurations = ["cors_rule"]
+}
+
+resource "huaweicloud_obs_bucket_cors_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.bucket.bucket
+
+  rule {
+    allowed_methods = ["GET"]
+    allowed_origins = ["*"]
+    max_age_seconds = 100
+  }
+}
+`, randInt)
+}
+
+func testAccObsBucketConfigWithUnlistedStandaloneCORS(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket = "tf-test-bucket-%d"
+  acl    = "public-read"
+}
+
+resource "huaweicloud_obs_bucket_cors_configuration" "test" {
+  bucket = huaweicloud_obs_bucket.bucket.bucket
+
+  rule {
+    allowed_methods = ["GET"]
+    allowed_origins = ["*"]
+    max_age_seconds = 100
+  }
+}
+`, randInt)
+}
+
 func testAccObsBucketConfigWithUserDomainNames(randInt int) string {
 	return fmt.Sprintf(`
 resource "huaweicloud_obs_bucket" "bucket" {
@@ -0,0 +1,112 @@
+package obs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getOBSBucketWebsiteConfigurationResourceFunc(cfg *config.Config,
+	state *terraform.ResourceState) (interface{}, error) {
+	obsClient, err := cfg.ObjectStorageClient(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OBS Client: %s", err)
+	}
+	return obsClient.GetBucketWebsiteConfiguration(state.Primary.ID)
+}
+
+func TestAccObsBucketWebsiteConfiguration_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceNameWithDash()
+	rName := "huaweicloud_obs_bucket_website_configuration.test"
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOBSBucketWebsiteConfigurationResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketWebsiteConfiguration_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "bucket", name),
+					resource.TestCheckResourceAttr(rName, "index_document", "index.html"),
+					resource.TestCheckResourceAttr(rName, "error_document", "error.html"),
+					resource.TestCheckResourceAttrSet(rName, "routing_rules"),
+				),
+			},
+			{
+				Config: testAccObsBucketWebsiteConfiguration_redirect(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "redirect_all_requests_to", "https://www.example.com"),
+					resource.TestCheckResourceAttr(rName, "index_document", ""),
+					resource.TestCheckResourceAttr(rName, "routing_rules", ""),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccObsBucketWebsiteConfiguration_base(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%s"
+  storage_class = "STANDARD"
+  acl           = "public-read"
+  force_destroy = true
+}
+`, name)
+}
+
+func testAccObsBucketWebsiteConfiguration_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_website_configuration" "test" {
+  bucket         = huaweicloud_obs_bucket.test.bucket
+  index_document = "index.html"
+  error_document = "error.html"
+  routing_rules  = <<EOF
+[{
+  "Condition": {
+    "KeyPrefixEquals": "docs/"
+  },
+  "Redirect": {
+    "ReplaceKeyPrefixWith": "documents/"
+  }
+}]
+EOF
+}
+`, testAccObsBucketWebsiteConfiguration_base(name))
+}
+
+func testAccObsBucketWebsiteConfiguration_redirect(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_obs_bucket_website_configuration" "test" {
+  bucket                   = huaweicloud_obs_bucket.test.bucket
+  redirect_all_requests_to = "https://www.example.com"
+}
+`, testAccObsBucketWebsiteConfiguration_base(name))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -21,6 +22,7 @@ type ResourceCheck struct {
 	resourceObject  interface{}
 	getResourceFunc ServiceFunc
 	resourceType    string
+	test            *testing.T
 }
 
 const (
@@ -64,6 +66,13 @@ func InitResourceCheck(rName string, rObject interface{}, getResourceFunc Servic
 	}
 }
 
+// WithTest makes the checks use the provider configuration of the test t, it's required by the tests which use the
+// provider factories of TestAccProviderFactoriesWithVcr.
+func (rc *ResourceCheck) WithTest(t *testing.T) *ResourceCheck {
+	rc.test = t
+	return rc
+}
+
 func parseVariableToName(variable string) (string, string, error) {
 	var name, field string
 
@@ -148,7 +157,7 @@ func (rc *ResourceCheck) CheckResourceDestroy() resource.TestCheckFunc {
 			return fmt.Errorf("the 'getResourceFunc' is nil, please set it during initialization")
 		}
 
-		conf := TestAccProvider.Meta().(*config.Config)
+		conf := TestAccProviderMeta(rc.test)
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type != resourceType {
 				continue
@@ -180,7 +189,7 @@ func (rc *ResourceCheck) checkResourceExists(s *terraform.State) error {
 		return fmt.Errorf("the 'getResourceFunc' is nil, please set it during initialization")
 	}
 
-	conf := TestAccProvider.Meta().(*config.Config)
+	conf := TestAccProviderMeta(rc.test)
 	r, err := rc.getResourceFunc(conf, rs)
 	if err != nil {
 		return fmt.Errorf("checking resource %s %s exists error: %s ",
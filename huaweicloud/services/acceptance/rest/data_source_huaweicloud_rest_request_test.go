@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccRestRequestDataSource_basic(t *testing.T) {
+	var (
+		name           = acceptance.RandomAccResourceName()
+		dataSourceName = "data.huaweicloud_rest_request.test"
+		dc             = acceptance.InitDataSourceCheck(dataSourceName)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestRequestDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSourceName, "output"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "1"),
+					resource.TestCheckOutput("is_vpc_found", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRestRequestDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+data "huaweicloud_rest_request" "test" {
+  service = "vpc"
+  path    = "v1/{project_id}/vpcs"
+
+  pagination {
+    type       = "marker"
+    items_path = "vpcs"
+    limit      = 10
+  }
+
+  result_paths = ["[?name=='%s'].id | [0]"]
+
+  depends_on = [huaweicloud_vpc.test]
+}
+
+output "is_vpc_found" {
+  value = jsondecode(data.huaweicloud_rest_request.test.results[0]) == huaweicloud_vpc.test.id
+}
+`, name, name)
+}
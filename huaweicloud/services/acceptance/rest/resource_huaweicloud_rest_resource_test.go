@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getRestResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	client, err := cfg.NetworkingV1Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v1 client: %s", err)
+	}
+
+	return vpcs.Get(client, state.Primary.ID).Extract()
+}
+
+func TestAccRestResource_basic(t *testing.T) {
+	var (
+		obj vpcs.Vpc
+
+		rName      = "huaweicloud_rest_resource.test"
+		name       = acceptance.RandomAccResourceName()
+		updateName = acceptance.RandomAccResourceName()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getRestResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestResource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "service", "vpc"),
+					resource.TestCheckResourceAttrSet(rName, "output"),
+					resource.TestCheckOutput("vpc_name", name),
+				),
+			},
+			{
+				Config: testAccRestResource_basic(updateName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckOutput("vpc_name", updateName),
+				),
+			},
+		},
+	})
+}
+
+func testAccRestResource_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_rest_resource" "test" {
+  service = "vpc"
+  id_path = "vpc.id"
+
+  create {
+    path = "v1/{project_id}/vpcs"
+  }
+
+  update {
+    path = "v1/{project_id}/vpcs/{id}"
+  }
+
+  body = jsonencode({
+    vpc = {
+      name = "%s"
+      cidr = "192.168.0.0/16"
+    }
+  })
+
+  polling {
+    status_path = "vpc.status"
+    target      = ["OK"]
+  }
+}
+
+output "vpc_name" {
+  value = jsondecode(huaweicloud_rest_resource.test.output).vpc.name
+}
+`, name)
+}
@@ -0,0 +1,18 @@
+package sweep
+
+import (
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func init() {
+	cce := &acceptance.RestSweeper{
+		Service:   "cce",
+		ListPath:  "api/v3/projects/{project_id}/clusters",
+		ItemsPath: "items",
+		NamePath:  "metadata.name",
+		IdPath:    "metadata.uid",
+		DeletePath: "api/v3/projects/{project_id}/clusters/{id}" +
+			"?delete_efs=true&delete_evs=true&delete_net=true&delete_obs=true&delete_sfs=true",
+	}
+	acceptance.AddTestSweepers("huaweicloud_cce_cluster", cce.Sweep)
+}
@@ -0,0 +1,31 @@
+package sweep
+
+import (
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func init() {
+	ecs := &acceptance.RestSweeper{
+		Service:      "ecs",
+		ListPath:     "v1/{project_id}/cloudservers/detail?limit=1000",
+		ItemsPath:    "servers",
+		DeleteMethod: "POST",
+		DeletePath:   "v1/{project_id}/cloudservers/delete",
+		DeleteBody: func(id string) map[string]interface{} {
+			return map[string]interface{}{
+				"servers":         []map[string]interface{}{{"id": id}},
+				"delete_publicip": true,
+				"delete_volume":   true,
+			}
+		},
+	}
+	acceptance.AddTestSweepers("huaweicloud_compute_instance", ecs.Sweep)
+
+	evs := &acceptance.RestSweeper{
+		Service:    "evs",
+		ListPath:   "v2/{project_id}/cloudvolumes/detail?limit=1000",
+		ItemsPath:  "volumes",
+		DeletePath: "v2/{project_id}/cloudvolumes/{id}?cascade=true",
+	}
+	acceptance.AddTestSweepers("huaweicloud_evs_volume", evs.Sweep, "huaweicloud_compute_instance")
+}
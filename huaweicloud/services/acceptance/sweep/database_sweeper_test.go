@@ -0,0 +1,16 @@
+package sweep
+
+import (
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func init() {
+	rds := &acceptance.RestSweeper{
+		Service:    "rds",
+		ListPath:   "v3/{project_id}/instances",
+		PageType:   "offset",
+		ItemsPath:  "instances",
+		DeletePath: "v3/{project_id}/instances/{id}",
+	}
+	acceptance.AddTestSweepers("huaweicloud_rds_instance", rds.Sweep)
+}
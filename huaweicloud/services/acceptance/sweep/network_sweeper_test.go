@@ -0,0 +1,102 @@
+package sweep
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func init() {
+	elb := &acceptance.RestSweeper{
+		Service:    "elb",
+		ListPath:   "v3/{project_id}/elb/loadbalancers",
+		PageType:   "marker",
+		ItemsPath:  "loadbalancers",
+		DeletePath: "v3/{project_id}/elb/loadbalancers/{id}/force-elb",
+	}
+	acceptance.AddTestSweepers("huaweicloud_elb_loadbalancer", elb.Sweep, "huaweicloud_compute_instance")
+
+	nat := &acceptance.RestSweeper{
+		Service:    "nat",
+		ListPath:   "v2/{project_id}/nat_gateways",
+		ItemsPath:  "nat_gateways",
+		DeletePath: "v2/{project_id}/nat_gateways/{id}",
+	}
+	acceptance.AddTestSweepers("huaweicloud_nat_gateway", nat.Sweep, "huaweicloud_compute_instance")
+
+	eip := &acceptance.RestSweeper{
+		Service:    "vpc",
+		ListPath:   "v1/{project_id}/publicips?limit=1000",
+		PageType:   "marker",
+		ItemsPath:  "publicips",
+		NamePath:   "alias",
+		DeletePath: "v1/{project_id}/publicips/{id}",
+	}
+	acceptance.AddTestSweepers("huaweicloud_vpc_eip", eip.Sweep,
+		"huaweicloud_compute_instance", "huaweicloud_elb_loadbalancer", "huaweicloud_nat_gateway")
+
+	acceptance.AddTestSweepers("huaweicloud_vpc", sweepVpcs,
+		"huaweicloud_compute_instance", "huaweicloud_elb_loadbalancer", "huaweicloud_nat_gateway",
+		"huaweicloud_vpc_eip", "huaweicloud_rds_instance", "huaweicloud_cce_cluster")
+}
+
+// sweepVpcs deletes the subnets of the VPCs before deleting the VPCs.
+func sweepVpcs(cfg *config.Config, region string) error {
+	vpcs := &acceptance.RestSweeper{
+		Service:    "vpc",
+		ListPath:   "v1/{project_id}/vpcs",
+		PageType:   "marker",
+		ItemsPath:  "vpcs",
+		DeletePath: "v1/{project_id}/vpcs/{id}",
+	}
+	client, err := cfg.NewServiceClient(vpcs.Service, region)
+	if err != nil {
+		return fmt.Errorf("error creating VPC client: %s", err)
+	}
+
+	vpcList, err := vpcs.List(client)
+	if err != nil {
+		return err
+	}
+
+	var mErr *multierror.Error
+	for _, vpc := range vpcList {
+		vpcId := utils.PathSearch("id", vpc, "").(string)
+		vpcName := utils.PathSearch("name", vpc, "").(string)
+		if !acceptance.IsSweepableName(vpcName) {
+			continue
+		}
+
+		// the subnets are sweepable as long as the VPC is, their names may not be generated by the helpers
+		subnets := &acceptance.RestSweeper{
+			Service:    "vpc",
+			ListPath:   fmt.Sprintf("v1/{project_id}/subnets?vpc_id=%s", vpcId),
+			PageType:   "marker",
+			ItemsPath:  "subnets",
+			DeletePath: fmt.Sprintf("v1/{project_id}/vpcs/%s/subnets/{id}", vpcId),
+		}
+		subnetList, err := subnets.List(client)
+		if err != nil {
+			mErr = multierror.Append(mErr, err)
+			continue
+		}
+		for _, subnet := range subnetList {
+			subnetId := utils.PathSearch("id", subnet, "").(string)
+			log.Printf("[INFO] sweeping subnet %s of VPC %s in region %s", subnetId, vpcName, region)
+			if err := subnets.Delete(client, subnetId); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("error deleting subnet %s: %s", subnetId, err))
+			}
+		}
+
+		log.Printf("[INFO] sweeping VPC %s (%s) in region %s", vpcName, vpcId, region)
+		if err := vpcs.Delete(client, vpcId); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("error deleting VPC %s (%s): %s", vpcName, vpcId, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,86 @@
+package sweep
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func init() {
+	acceptance.AddTestSweepers("huaweicloud_obs_bucket", sweepObsBuckets)
+}
+
+// sweepObsBuckets deletes all object versions of the buckets in the region before deleting the buckets.
+func sweepObsBuckets(cfg *config.Config, region string) error {
+	client, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("error creating OBS client: %s", err)
+	}
+
+	output, err := client.ListBuckets(&obs.ListBucketsInput{QueryLocation: true})
+	if err != nil {
+		return fmt.Errorf("error listing OBS buckets: %s", err)
+	}
+
+	var mErr *multierror.Error
+	for _, bucket := range output.Buckets {
+		if bucket.Location != region || !acceptance.IsSweepableName(bucket.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping OBS bucket %s in region %s", bucket.Name, region)
+		if err := deleteObsBucketObjects(client, bucket.Name); err != nil {
+			mErr = multierror.Append(mErr, err)
+			continue
+		}
+		if _, err := client.DeleteBucket(bucket.Name); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("error deleting OBS bucket %s: %s", bucket.Name, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func deleteObsBucketObjects(client *obs.ObsClient, bucket string) error {
+	input := &obs.ListVersionsInput{
+		Bucket: bucket,
+	}
+	for {
+		output, err := client.ListVersions(input)
+		if err != nil {
+			return fmt.Errorf("error listing objects of OBS bucket %s: %s", bucket, err)
+		}
+
+		objects := make([]obs.ObjectToDelete, 0, len(output.Versions)+len(output.DeleteMarkers))
+		for _, version := range output.Versions {
+			objects = append(objects, obs.ObjectToDelete{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range output.DeleteMarkers {
+			objects = append(objects, obs.ObjectToDelete{Key: marker.Key, VersionId: marker.VersionId})
+		}
+		if len(objects) > 0 {
+			deleteOutput, err := client.DeleteObjects(&obs.DeleteObjectsInput{
+				Bucket:  bucket,
+				Quiet:   true,
+				Objects: objects,
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting objects of OBS bucket %s: %s", bucket, err)
+			}
+			if len(deleteOutput.Errors) > 0 {
+				return fmt.Errorf("error some objects are still exist in %s: %#v", bucket, deleteOutput.Errors)
+			}
+		}
+
+		if !output.IsTruncated {
+			return nil
+		}
+		input.KeyMarker = output.NextKeyMarker
+		input.VersionIdMarker = output.NextVersionIdMarker
+	}
+}
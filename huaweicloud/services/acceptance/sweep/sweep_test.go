@@ -0,0 +1,12 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestMain runs the sweepers registered in this package with `make sweep SWEEP=<region>`.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
@@ -0,0 +1,193 @@
+package acceptance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/pagination"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	// ResourceNamePrefix is the prefix of the names generated by RandomAccResourceName.
+	ResourceNamePrefix = "tf_test_"
+	// ResourceNamePrefixWithDash is the prefix of the names generated by RandomAccResourceNameWithDash.
+	ResourceNamePrefixWithDash = "tf-test-"
+)
+
+var sweeperConfigs sync.Map
+
+// SweeperFunc sweeps the resources of the acceptance tests in the region.
+type SweeperFunc func(cfg *config.Config, region string) error
+
+// AddTestSweepers registers a sweeper which is run by `make sweep`, the sweepers of the dependencies are run before
+// it, e.g. the VPC sweeper depends on the ECS sweeper.
+func AddTestSweepers(name string, f SweeperFunc, dependencies ...string) {
+	resource.AddTestSweepers(name, &resource.Sweeper{
+		Name:         name,
+		Dependencies: dependencies,
+		F: func(region string) error {
+			cfg, err := SweeperConfig(region)
+			if err != nil {
+				return err
+			}
+			return f(cfg, region)
+		},
+	})
+}
+
+// SweeperConfig returns the provider configuration of the sweepers in the region, the credentials are read from
+// the same environment variables as the acceptance tests.
+func SweeperConfig(region string) (*config.Config, error) {
+	if cfg, ok := sweeperConfigs.Load(region); ok {
+		return cfg.(*config.Config), nil
+	}
+
+	provider := huaweicloud.Provider()
+	raw := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region": region,
+	})
+	if diags := provider.Configure(context.Background(), raw); diags.HasError() {
+		return nil, fmt.Errorf("error configuring the provider for sweepers in region %s: %v", region, diags)
+	}
+
+	cfg := provider.Meta().(*config.Config)
+	sweeperConfigs.Store(region, cfg)
+	return cfg, nil
+}
+
+// IsSweepableName returns true if the name is generated by the random helpers of the acceptance tests.
+// The sweepers only delete the resources with these names.
+func IsSweepableName(name string) bool {
+	return strings.HasPrefix(name, ResourceNamePrefix) || strings.HasPrefix(name, ResourceNamePrefixWithDash)
+}
+
+// RestSweeper describes how to sweep the resources with the REST APIs of a service.
+// The paths are relative to the endpoint of the service, and {project_id} is replaced with the project ID,
+// {id} in DeletePath is replaced with the resource ID.
+type RestSweeper struct {
+	// Service is the name of the service catalog used to create the client, such as `ecs`.
+	Service string
+	// ListPath is the path with the query parameters of the list API.
+	ListPath string
+	// PageType is the pagination type of the list API: marker, offset or pageSize, the list API is only called once
+	// if it's empty.
+	PageType string
+	// ItemsPath is the JMESPath of the resources in the list response.
+	ItemsPath string
+	// NamePath is the JMESPath of the resource name, defaults to `name`.
+	NamePath string
+	// IdPath is the JMESPath of the resource ID, defaults to `id`.
+	IdPath string
+	// DeleteMethod is the HTTP method of the delete API, defaults to `DELETE`.
+	DeleteMethod string
+	// DeletePath is the path of the delete API.
+	DeletePath string
+	// DeleteBody builds the request body of the delete API if it's not nil.
+	DeleteBody func(id string) map[string]interface{}
+}
+
+// Sweep deletes all resources with the sweepable names, the errors of deleting resources are collected and returned
+// after trying all resources.
+func (s *RestSweeper) Sweep(cfg *config.Config, region string) error {
+	client, err := cfg.NewServiceClient(s.Service, region)
+	if err != nil {
+		return fmt.Errorf("error creating %s client: %s", strings.ToUpper(s.Service), err)
+	}
+
+	items, err := s.List(client)
+	if err != nil {
+		return err
+	}
+
+	namePath := s.NamePath
+	if namePath == "" {
+		namePath = "name"
+	}
+	idPath := s.IdPath
+	if idPath == "" {
+		idPath = "id"
+	}
+
+	var mErr *multierror.Error
+	for _, item := range items {
+		name := utils.PathSearch(namePath, item, "").(string)
+		id := utils.PathSearch(idPath, item, "").(string)
+		if id == "" || !IsSweepableName(name) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping %s resource %s (%s) in region %s", s.Service, name, id, region)
+		if err := s.Delete(client, id); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("error deleting %s resource %s (%s): %s", s.Service, name, id, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// List returns all resources returned by the list API.
+func (s *RestSweeper) List(client *golangsdk.ServiceClient) ([]interface{}, error) {
+	listPath := client.Endpoint + strings.ReplaceAll(s.ListPath, "{project_id}", client.ProjectID)
+
+	var respBody interface{}
+	if s.PageType != "" {
+		resp, err := pagination.ListAllItems(client, s.PageType, listPath, &pagination.QueryOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources: %s", s.Service, err)
+		}
+		respJson, err := json.Marshal(resp)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(respJson, &respBody); err != nil {
+			return nil, err
+		}
+	} else {
+		opt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		resp, err := client.Request("GET", listPath, &opt)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources: %s", s.Service, err)
+		}
+		respBody, err = utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return utils.PathSearch(s.ItemsPath, respBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+// Delete deletes the resource by the delete API.
+func (s *RestSweeper) Delete(client *golangsdk.ServiceClient, id string) error {
+	deletePath := client.Endpoint + strings.ReplaceAll(s.DeletePath, "{project_id}", client.ProjectID)
+	deletePath = strings.ReplaceAll(deletePath, "{id}", id)
+
+	method := s.DeleteMethod
+	if method == "" {
+		method = "DELETE"
+	}
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{200, 202, 204},
+	}
+	if s.DeleteBody != nil {
+		opt.JSONBody = s.DeleteBody(id)
+	}
+
+	_, err := client.Request(method, deletePath, &opt)
+	return err
+}
@@ -0,0 +1,192 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+var (
+	// vcrProviders saves the providers of the tests which run in VCR mode, the key is the test name
+	vcrProviders sync.Map
+
+	// vcrRandomSeqs saves the number of the random values generated by each test, the key is the test function
+	vcrRandomSeqs = make(map[string]int)
+	vcrRandomLock sync.Mutex
+)
+
+// randomSource generates the random values for the acceptance tests.
+type randomSource interface {
+	Intn(n int) int
+}
+
+type acctestSource struct{}
+
+func (acctestSource) Intn(n int) int {
+	return acctest.RandIntRange(0, n)
+}
+
+// TestAccProviderFactoriesWithVcr returns the provider factories of the test t. In VCR mode, the factories return
+// a provider of the test whose API interactions are recorded to or replayed from the cassette named by the test,
+// so the parallel tests never share a cassette. Otherwise, TestAccProviderFactories is returned.
+func TestAccProviderFactoriesWithVcr(t *testing.T) map[string]func() (*schema.Provider, error) {
+	if config.GetVcrMode() == "" {
+		return TestAccProviderFactories
+	}
+
+	provider := vcrProvider(t)
+	return map[string]func() (*schema.Provider, error){
+		"huaweicloud": func() (*schema.Provider, error) {
+			return provider, nil
+		},
+	}
+}
+
+// TestAccProtoV5ProviderFactoriesWithVcr is the same as TestAccProviderFactoriesWithVcr, but returns the factories
+// of the muxed provider server.
+func TestAccProtoV5ProviderFactoriesWithVcr(t *testing.T) map[string]func() (tfprotov5.ProviderServer, error) {
+	if config.GetVcrMode() == "" {
+		return TestAccProtoV5ProviderFactories
+	}
+
+	provider := vcrProvider(t)
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"huaweicloud": func() (tfprotov5.ProviderServer, error) {
+			serverFactory, err := huaweicloud.NewProtoV5ProviderServerFactory(context.Background(), provider)
+			if err != nil {
+				return nil, err
+			}
+			return serverFactory(), nil
+		},
+	}
+}
+
+// TestAccProviderMeta returns the configuration of the provider used by the test t, it's the configuration of
+// TestAccProvider unless the test uses the provider factories of TestAccProviderFactoriesWithVcr in VCR mode.
+func TestAccProviderMeta(t *testing.T) *config.Config {
+	if t != nil {
+		if provider, ok := vcrProviders.Load(t.Name()); ok {
+			return provider.(*schema.Provider).Meta().(*config.Config)
+		}
+	}
+	return TestAccProvider.Meta().(*config.Config)
+}
+
+// vcrProvider returns the provider of the test t in VCR mode, the cassette name is passed to its configuration
+// by the context.
+func vcrProvider(t *testing.T) *schema.Provider {
+	name := t.Name()
+	if provider, ok := vcrProviders.Load(name); ok {
+		return provider.(*schema.Provider)
+	}
+
+	provider := huaweicloud.Provider()
+	configure := provider.ConfigureContextFunc
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configure(config.ContextWithCassetteName(ctx, name), d)
+	}
+
+	vcrProviders.Store(name, provider)
+	t.Cleanup(func() {
+		vcrProviders.Delete(name)
+		resetRandomSeqs(name)
+	})
+	return provider
+}
+
+// preCheckVcr skips the test in VCR mode if it doesn't use the provider factories of TestAccProviderFactoriesWithVcr,
+// because its API interactions can't be separated from the other tests. The VCR mode is enabled by setting the
+// HW_VCR_MODE environment variable to `record` or `replay`, and the cassettes are saved in the testdata/cassettes
+// directory of the test package.
+func preCheckVcr(t *testing.T) {
+	if config.GetVcrMode() == "" {
+		return
+	}
+	if _, ok := vcrProviders.Load(t.Name()); !ok {
+		t.Skip("the test does not support VCR mode, please use the provider factories of " +
+			"acceptance.TestAccProviderFactoriesWithVcr or acceptance.TestAccProtoV5ProviderFactoriesWithVcr")
+	}
+}
+
+// newRandomSource returns the source of the random helpers. In VCR mode, the source is seeded by the name of the
+// running test and the number of the values it has generated, so that the same test always generates the same
+// values and the requests can be matched in replay mode.
+func newRandomSource() randomSource {
+	if config.GetVcrMode() == "" {
+		return acctestSource{}
+	}
+
+	name := currentTestName()
+	vcrRandomLock.Lock()
+	seq := vcrRandomSeqs[name]
+	vcrRandomSeqs[name]++
+	vcrRandomLock.Unlock()
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s#%d", name, seq)))
+	//nolint:gosec
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// currentTestName returns the name of the test function which is running in the current goroutine, such as
+// TestAccVpcV1_basic, or TestAccVpcV1_basic.func1 for the sub-tests.
+func currentTestName() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var testFunc string
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "testing.tRunner" {
+			break
+		}
+		testFunc = frame.Function
+		if !more {
+			return "unknown"
+		}
+	}
+
+	// trim the package path, e.g. github.com/xxx/acceptance/vpc.TestAccVpcV1_basic
+	testFunc = testFunc[strings.LastIndex(testFunc, "/")+1:]
+	return testFunc[strings.Index(testFunc, ".")+1:]
+}
+
+// resetRandomSeqs resets the number of the random values generated by the test and its sub-tests, so the values are
+// the same when the test runs again, such as with the -count flag.
+func resetRandomSeqs(testName string) {
+	// the sub-tests are named by the function of the parent test
+	topName, _, _ := strings.Cut(testName, "/")
+
+	vcrRandomLock.Lock()
+	defer vcrRandomLock.Unlock()
+	for name := range vcrRandomSeqs {
+		if name == topName || strings.HasPrefix(name, topName+".") {
+			delete(vcrRandomSeqs, name)
+		}
+	}
+}
+
+func randomString(src randomSource, length int, charSet string) string {
+	result := make([]byte, length)
+	for i := 0; i < length; i++ {
+		result[i] = charSet[src.Intn(len(charSet))]
+	}
+	return string(result)
+}
+
+func randomIntRange(src randomSource, minVal, maxVal int) int {
+	return src.Intn(maxVal-minVal) + minVal
+}
@@ -0,0 +1,55 @@
+package acceptance
+
+import (
+	"testing"
+)
+
+func TestVcr_randomNames(t *testing.T) {
+	t.Setenv("HW_VCR_MODE", "replay")
+
+	AssertEquals(t, currentTestName(), "TestVcr_randomNames")
+
+	first := RandomAccResourceName()
+	second := RandomAccResourceName()
+	if first == second {
+		t.Fatalf("the random names generated by the same test are the same: %s", first)
+	}
+
+	// the names only depend on the test, they are generated again after the test ends
+	resetRandomSeqs(t.Name())
+	AssertEquals(t, RandomAccResourceName(), first)
+	AssertEquals(t, RandomAccResourceName(), second)
+
+	t.Run("sub", func(t *testing.T) {
+		AssertEquals(t, currentTestName(), "TestVcr_randomNames.func1")
+		if name := RandomAccResourceName(); name == first {
+			t.Fatalf("the random name of the sub-test is the same as the parent test: %s", name)
+		}
+	})
+}
+
+func TestVcr_providerFactories(t *testing.T) {
+	t.Setenv("HW_VCR_MODE", "record")
+
+	var first, second interface{}
+	t.Run("first", func(t *testing.T) {
+		p, err := TestAccProviderFactoriesWithVcr(t)["huaweicloud"]()
+		AssertNoErr(t, err)
+		first = p
+		preCheckVcr(t)
+	})
+	t.Run("second", func(t *testing.T) {
+		p, err := TestAccProviderFactoriesWithVcr(t)["huaweicloud"]()
+		AssertNoErr(t, err)
+		second = p
+	})
+	if first == second || first == TestAccProvider {
+		t.Fatalf("the tests share the same provider in VCR mode")
+	}
+
+	// the tests which use the shared provider are skipped in VCR mode
+	t.Run("shared", func(t *testing.T) {
+		preCheckVcr(t)
+		t.Fatalf("the test using the shared provider is not skipped")
+	})
+}
@@ -37,11 +37,11 @@ func TestAccVpcAddressGroup_basic(t *testing.T) {
 		resourceName,
 		&group,
 		getVpcAddressGroupResourceFunc,
-	)
+	).WithTest(t)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
-		ProviderFactories: acceptance.TestAccProviderFactories,
+		ProviderFactories: acceptance.TestAccProviderFactoriesWithVcr(t),
 		CheckDestroy:      rc.CheckResourceDestroy(),
 		Steps: []resource.TestStep{
 			{
@@ -733,9 +733,9 @@ func resourceASGroupUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		// remove oldTag tags and set newTag tags
-		oldTag, newTag := d.GetChange("tags")
+		oldTag, newTag := utils.GetTagsChange(d)
 		oldRaw := oldTag.(map[string]interface{})
 		if len(oldRaw) > 0 {
 			taglist := expandGroupsTags(oldRaw)
@@ -0,0 +1,597 @@
+package bss
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	// the measurement units of the BSS pricing APIs
+	measureHour = 4
+	measureGB   = 17
+	measureMbps = 15
+
+	// the period types of the yearly/monthly products
+	periodTypeMonth = 2
+	periodTypeYear  = 3
+)
+
+// priceProduct is a product to be priced, the resources are priced as one or more products, e.g. the ECS instance
+// is priced as the VM and the system disk.
+type priceProduct struct {
+	// ID identifies the product in the request and the response, the format is <resource_type>.<index>.<part>
+	ID               string
+	CloudServiceType string
+	ResourceType     string
+	ResourceSpec     string
+	AvailabilityZone string
+	ResourceSize     int
+	SizeMeasureID    int
+}
+
+// priceItem is a resource block of the data source, which is priced as the products.
+type priceItem struct {
+	ResourceType  string
+	Index         int
+	ChargingMode  string
+	PeriodUnit    string
+	Period        int
+	Quantity      int
+	Products      []priceProduct
+	Amount        float64
+	OfficialPrice float64
+}
+
+// @API BSS POST /v2/bills/ratings/on-demand-resources
+// @API BSS POST /v2/bills/ratings/period-resources/subscribe-rate
+func DataSourcePriceEstimate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePriceEstimateRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"compute_instance": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: priceResourceSchema(map[string]*schema.Schema{
+					"flavor_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"availability_zone": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"os_type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "linux",
+						ValidateFunc: validation.StringInSlice([]string{"linux", "windows"}, false),
+					},
+					"system_disk_type": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"system_disk_size": {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Default:  40,
+					},
+				}),
+			},
+			"evs_volume": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: priceResourceSchema(map[string]*schema.Schema{
+					"volume_type": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"size": {
+						Type:     schema.TypeInt,
+						Required: true,
+					},
+					"availability_zone": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				}),
+			},
+			"vpc_eip": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: priceResourceSchema(map[string]*schema.Schema{
+					"publicip_type": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Default:  "5_bgp",
+					},
+					"bandwidth_size": {
+						Type:     schema.TypeInt,
+						Required: true,
+					},
+				}),
+			},
+			"rds_instance": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: priceResourceSchema(map[string]*schema.Schema{
+					"flavor": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"availability_zone": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"volume_type": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"volume_size": {
+						Type:     schema.TypeInt,
+						Required: true,
+					},
+				}),
+			},
+
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"prices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"charging_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"official_amount": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"amount": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"discount_amount": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"on_demand_official_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"on_demand_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"period_official_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"period_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// priceResourceSchema returns the schema of a resource block, the billing arguments are the same as the resources.
+func priceResourceSchema(sm map[string]*schema.Schema) *schema.Resource {
+	sm["charging_mode"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "postPaid",
+		ValidateFunc: validation.StringInSlice([]string{"prePaid", "postPaid"}, false),
+	}
+	sm["period_unit"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"month", "year"}, false),
+	}
+	sm["period"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(1, 9),
+	}
+	sm["quantity"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	return &schema.Resource{Schema: sm}
+}
+
+func dataSourcePriceEstimateRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.BssV2Client(region)
+	if err != nil {
+		return diag.Errorf("error creating BSS v2 client: %s", err)
+	}
+
+	items, err := buildPriceItems(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(items) == 0 {
+		return diag.Errorf("at least one of compute_instance, evs_volume, vpc_eip and rds_instance must be specified")
+	}
+
+	var onDemandItems, periodItems []*priceItem
+	for _, item := range items {
+		if item.ChargingMode == "prePaid" {
+			periodItems = append(periodItems, item)
+		} else {
+			onDemandItems = append(onDemandItems, item)
+		}
+	}
+
+	projectID := cfg.GetProjectID(region)
+	var onDemandCurrency, periodCurrency string
+	if len(onDemandItems) > 0 {
+		if onDemandCurrency, err = rateOnDemandItems(client, projectID, region, onDemandItems); err != nil {
+			return diag.Errorf("error querying the prices of the pay-per-use resources: %s", err)
+		}
+	}
+	if len(periodItems) > 0 {
+		if periodCurrency, err = ratePeriodItems(client, projectID, region, periodItems); err != nil {
+			return diag.Errorf("error querying the prices of the yearly/monthly resources: %s", err)
+		}
+	}
+	// the amounts of both charging modes are in the same currency, so that they can be added up
+	if onDemandCurrency != "" && periodCurrency != "" && onDemandCurrency != periodCurrency {
+		return diag.Errorf("the prices of the pay-per-use resources (%s) and the yearly/monthly resources (%s) are in "+
+			"different currencies", onDemandCurrency, periodCurrency)
+	}
+	currency := onDemandCurrency
+	if currency == "" {
+		currency = periodCurrency
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("currency", currency),
+		d.Set("prices", flattenPriceItems(items)),
+		d.Set("on_demand_official_amount", sumOfficialPrices(onDemandItems)),
+		d.Set("on_demand_amount", sumAmounts(onDemandItems)),
+		d.Set("period_official_amount", sumOfficialPrices(periodItems)),
+		d.Set("period_amount", sumAmounts(periodItems)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+// buildPriceItems builds the items of the resource blocks, the items are ordered by the resource types and indexes.
+func buildPriceItems(d *schema.ResourceData) ([]*priceItem, error) {
+	builders := map[string]func(prefix string, raw map[string]interface{}) []priceProduct{
+		"compute_instance": buildComputeInstanceProducts,
+		"evs_volume":       buildEvsVolumeProducts,
+		"vpc_eip":          buildVpcEipProducts,
+		"rds_instance":     buildRdsInstanceProducts,
+	}
+	resourceTypes := make([]string, 0, len(builders))
+	for resourceType := range builders {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	result := make([]*priceItem, 0)
+	for _, resourceType := range resourceTypes {
+		for i, v := range d.Get(resourceType).([]interface{}) {
+			raw := v.(map[string]interface{})
+			item := &priceItem{
+				ResourceType: resourceType,
+				Index:        i,
+				ChargingMode: raw["charging_mode"].(string),
+				PeriodUnit:   raw["period_unit"].(string),
+				Period:       raw["period"].(int),
+				Quantity:     raw["quantity"].(int),
+				Products:     builders[resourceType](fmt.Sprintf("%s.%d", resourceType, i), raw),
+			}
+			if item.ChargingMode == "prePaid" && (item.PeriodUnit == "" || item.Period == 0) {
+				return nil, fmt.Errorf("both of `period, period_unit` must be specified in prePaid charging mode (%s.%d)",
+					resourceType, i)
+			}
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func buildComputeInstanceProducts(prefix string, raw map[string]interface{}) []priceProduct {
+	osSuffix := "linux"
+	if raw["os_type"].(string) == "windows" {
+		osSuffix = "win"
+	}
+	az := raw["availability_zone"].(string)
+	products := []priceProduct{
+		{
+			ID:               prefix + ".vm",
+			CloudServiceType: "hws.service.type.ec2",
+			ResourceType:     "hws.resource.type.vm",
+			ResourceSpec:     fmt.Sprintf("%s.%s", raw["flavor_id"], osSuffix),
+			AvailabilityZone: az,
+		},
+	}
+	if diskType := raw["system_disk_type"].(string); diskType != "" {
+		products = append(products, priceProduct{
+			ID:               prefix + ".system_disk",
+			CloudServiceType: "hws.service.type.ebs",
+			ResourceType:     "hws.resource.type.volume",
+			ResourceSpec:     diskType,
+			AvailabilityZone: az,
+			ResourceSize:     raw["system_disk_size"].(int),
+			SizeMeasureID:    measureGB,
+		})
+	}
+	return products
+}
+
+func buildEvsVolumeProducts(prefix string, raw map[string]interface{}) []priceProduct {
+	return []priceProduct{
+		{
+			ID:               prefix + ".volume",
+			CloudServiceType: "hws.service.type.ebs",
+			ResourceType:     "hws.resource.type.volume",
+			ResourceSpec:     raw["volume_type"].(string),
+			AvailabilityZone: raw["availability_zone"].(string),
+			ResourceSize:     raw["size"].(int),
+			SizeMeasureID:    measureGB,
+		},
+	}
+}
+
+// buildVpcEipProducts builds the products of the EIP which is charged by the bandwidth, the price includes the IP
+// address and the dedicated bandwidth. The line of the bandwidth is the same as the IP address, e.g. the bandwidth
+// of the IP address 5_sbgp is 19_sbgp.
+func buildVpcEipProducts(prefix string, raw map[string]interface{}) []priceProduct {
+	publicipType := raw["publicip_type"].(string)
+	_, line, _ := strings.Cut(publicipType, "_")
+	return []priceProduct{
+		{
+			ID:               prefix + ".ip",
+			CloudServiceType: "hws.service.type.vpc",
+			ResourceType:     "hws.resource.type.ip",
+			ResourceSpec:     publicipType,
+		},
+		{
+			ID:               prefix + ".bandwidth",
+			CloudServiceType: "hws.service.type.vpc",
+			ResourceType:     "hws.resource.type.bandwidth",
+			ResourceSpec:     "19_" + line,
+			ResourceSize:     raw["bandwidth_size"].(int),
+			SizeMeasureID:    measureMbps,
+		},
+	}
+}
+
+func buildRdsInstanceProducts(prefix string, raw map[string]interface{}) []priceProduct {
+	az := raw["availability_zone"].(string)
+	return []priceProduct{
+		{
+			ID:               prefix + ".vm",
+			CloudServiceType: "hws.service.type.rds",
+			ResourceType:     "hws.resource.type.rds.vm",
+			ResourceSpec:     raw["flavor"].(string),
+			AvailabilityZone: az,
+		},
+		{
+			ID:               prefix + ".volume",
+			CloudServiceType: "hws.service.type.rds",
+			ResourceType:     "hws.resource.type.rds.volume",
+			ResourceSpec:     raw["volume_type"].(string),
+			AvailabilityZone: az,
+			ResourceSize:     raw["volume_size"].(int),
+			SizeMeasureID:    measureGB,
+		},
+	}
+}
+
+func buildProductInfo(product priceProduct, region string, quantity int) map[string]interface{} {
+	info := map[string]interface{}{
+		"id":                 product.ID,
+		"cloud_service_type": product.CloudServiceType,
+		"resource_type":      product.ResourceType,
+		"resource_spec":      product.ResourceSpec,
+		"region":             region,
+		"subscription_num":   quantity,
+	}
+	if product.AvailabilityZone != "" {
+		info["available_zone"] = product.AvailabilityZone
+	}
+	if product.ResourceSize > 0 {
+		info["resource_size"] = product.ResourceSize
+		info["size_measure_id"] = product.SizeMeasureID
+	}
+	return info
+}
+
+// rateOnDemandItems queries the hourly prices of the pay-per-use items and returns the currency.
+func rateOnDemandItems(client *golangsdk.ServiceClient, projectID, region string, items []*priceItem) (string, error) {
+	productInfos := make([]interface{}, 0)
+	for _, item := range items {
+		for _, product := range item.Products {
+			info := buildProductInfo(product, region, item.Quantity)
+			info["usage_factor"] = "Duration"
+			info["usage_value"] = 1
+			info["usage_measure_id"] = measureHour
+			productInfos = append(productInfos, info)
+		}
+	}
+
+	respBody, err := requestPrices(client, "v2/bills/ratings/on-demand-resources", map[string]interface{}{
+		"project_id":    projectID,
+		"product_infos": productInfos,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	results := utils.PathSearch("product_rating_results", respBody, make([]interface{}, 0)).([]interface{})
+	for _, item := range items {
+		item.OfficialPrice = sumProductAmounts(results, item, "official_website_amount")
+		item.Amount = sumProductAmounts(results, item, "amount")
+	}
+	return utils.PathSearch("currency", respBody, "").(string), nil
+}
+
+// ratePeriodItems queries the prices of the yearly/monthly items for the whole periods and returns the currency.
+// The discounted prices are the lowest ones of the optional discounts.
+func ratePeriodItems(client *golangsdk.ServiceClient, projectID, region string, items []*priceItem) (string, error) {
+	productInfos := make([]interface{}, 0)
+	for _, item := range items {
+		periodType := periodTypeMonth
+		if item.PeriodUnit == "year" {
+			periodType = periodTypeYear
+		}
+		for _, product := range item.Products {
+			info := buildProductInfo(product, region, item.Quantity)
+			info["period_type"] = periodType
+			info["period_num"] = item.Period
+			productInfos = append(productInfos, info)
+		}
+	}
+
+	respBody, err := requestPrices(client, "v2/bills/ratings/period-resources/subscribe-rate", map[string]interface{}{
+		"project_id":    projectID,
+		"product_infos": productInfos,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	officialResults := utils.PathSearch("official_website_rating_result.product_rating_results", respBody,
+		make([]interface{}, 0)).([]interface{})
+	discounts := utils.PathSearch("optional_discount_rating_results", respBody, make([]interface{}, 0)).([]interface{})
+	var discountResults []interface{}
+	lowest := math.MaxFloat64
+	for _, discount := range discounts {
+		if amount := normalizeAmount(discount, "amount"); amount < lowest {
+			lowest = amount
+			discountResults = utils.PathSearch("product_rating_results", discount, make([]interface{}, 0)).([]interface{})
+		}
+	}
+
+	for _, item := range items {
+		item.OfficialPrice = sumProductAmounts(officialResults, item, "official_website_amount")
+		item.Amount = item.OfficialPrice
+		if discountResults != nil {
+			item.Amount = sumProductAmounts(discountResults, item, "amount")
+		}
+	}
+	return utils.PathSearch("currency", respBody, "").(string), nil
+}
+
+func requestPrices(client *golangsdk.ServiceClient, httpUrl string, body map[string]interface{}) (interface{}, error) {
+	requestOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         body,
+	}
+	resp, err := client.Request("POST", client.Endpoint+httpUrl, &requestOpt)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FlattenResponse(resp)
+}
+
+// sumProductAmounts sums the amounts of the products of the item, the amounts are converted to the main currency
+// unit, e.g. yuan.
+func sumProductAmounts(results []interface{}, item *priceItem, key string) float64 {
+	var sum float64
+	for _, product := range item.Products {
+		for _, result := range results {
+			if utils.PathSearch("id", result, "").(string) == product.ID {
+				sum += normalizeAmount(result, key)
+			}
+		}
+	}
+	return roundAmount(sum)
+}
+
+// normalizeAmount converts the amount to the main currency unit by the measure ID: 1 (yuan), 2 (jiao) and 3 (fen).
+func normalizeAmount(result interface{}, key string) float64 {
+	amount := utils.PathSearch(key, result, float64(0)).(float64)
+	switch utils.PathSearch("measure_id", result, float64(1)).(float64) {
+	case 2:
+		return amount / 10
+	case 3:
+		return amount / 100
+	default:
+		return amount
+	}
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*10000) / 10000
+}
+
+func sumOfficialPrices(items []*priceItem) float64 {
+	var sum float64
+	for _, item := range items {
+		sum += item.OfficialPrice
+	}
+	return roundAmount(sum)
+}
+
+func sumAmounts(items []*priceItem) float64 {
+	var sum float64
+	for _, item := range items {
+		sum += item.Amount
+	}
+	return roundAmount(sum)
+}
+
+func flattenPriceItems(items []*priceItem) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, map[string]interface{}{
+			"resource_type":   item.ResourceType,
+			"index":           item.Index,
+			"charging_mode":   item.ChargingMode,
+			"official_amount": item.OfficialPrice,
+			"amount":          item.Amount,
+			"discount_amount": roundAmount(item.OfficialPrice - item.Amount),
+		})
+	}
+	return result
+}
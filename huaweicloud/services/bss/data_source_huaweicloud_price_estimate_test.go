@@ -0,0 +1,89 @@
+package bss
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestPriceEstimate(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+
+	res := DataSourcePriceEstimate()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"compute_instance": []interface{}{
+			map[string]interface{}{
+				"flavor_id":        "s6.small.1",
+				"system_disk_type": "SSD",
+				"quantity":         2,
+			},
+		},
+		"evs_volume": []interface{}{
+			map[string]interface{}{
+				"volume_type":   "SAS",
+				"size":          100,
+				"charging_mode": "prePaid",
+				"period_unit":   "month",
+				"period":        1,
+			},
+		},
+		"vpc_eip": []interface{}{
+			map[string]interface{}{
+				"bandwidth_size": 5,
+				"charging_mode":  "prePaid",
+				"period_unit":    "year",
+				"period":         1,
+			},
+		},
+	})
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "CNY", d.Get("currency"))
+
+	// the hourly price of the instances and the system disks
+	th.AssertEquals(t, 0.28, d.Get("on_demand_official_amount"))
+	th.AssertEquals(t, 0.252, d.Get("on_demand_amount"))
+	th.AssertEquals(t, "compute_instance", d.Get("prices.0.resource_type"))
+	th.AssertEquals(t, 0.028, d.Get("prices.0.discount_amount"))
+
+	// the official prices in fen are converted, and the lowest optional discount is chosen
+	th.AssertEquals(t, "evs_volume", d.Get("prices.1.resource_type"))
+	th.AssertEquals(t, 36.0, d.Get("prices.1.official_amount"))
+	th.AssertEquals(t, 29.88, d.Get("prices.1.amount"))
+	th.AssertEquals(t, "vpc_eip", d.Get("prices.2.resource_type"))
+	th.AssertEquals(t, 3628.8, d.Get("prices.2.official_amount"))
+	th.AssertEquals(t, 3664.8, d.Get("period_official_amount"))
+	th.AssertEquals(t, 3041.784, d.Get("period_amount"))
+
+	// the bandwidth is of the same line as the EIP
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"vpc_eip": []interface{}{
+			map[string]interface{}{
+				"publicip_type":  "5_sbgp",
+				"bandwidth_size": 5,
+			},
+		},
+	})
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, 0.53, d.Get("on_demand_official_amount"))
+
+	// the unknown specifications are reported
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"evs_volume": []interface{}{
+			map[string]interface{}{
+				"volume_type": "ESSD3",
+				"size":        100,
+			},
+		},
+	})
+	diags := res.ReadContext(ctx, d, cfg)
+	th.AssertEquals(t, true, diags.HasError())
+	th.AssertEquals(t, true, strings.Contains(diags[0].Summary, "ESSD3"))
+}
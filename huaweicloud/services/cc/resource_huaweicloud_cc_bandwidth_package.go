@@ -320,7 +320,7 @@ func resourceBandwidthPackageUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = updateBandwidthPackageTags(client, d, cfg.DomainID)
 		if err != nil {
 			return diag.FromErr(err)
@@ -458,7 +458,7 @@ func updateBandwidthPackageTags(client *golangsdk.ServiceClient, d *schema.Resou
 		},
 	}
 
-	oRaw, nRaw := d.GetChange("tags")
+	oRaw, nRaw := utils.GetTagsChange(d)
 	oMap := oRaw.(map[string]interface{})
 	nMap := nRaw.(map[string]interface{})
 
@@ -1013,9 +1013,9 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		// remove old tags and set new tags
-		oldTags, newTags := d.GetChange("tags")
+		oldTags, newTags := utils.GetTagsChange(d)
 		oldTagsRaw := oldTags.(map[string]interface{})
 		if len(oldTagsRaw) > 0 {
 			taglist := utils.ExpandResourceTags(oldTagsRaw)
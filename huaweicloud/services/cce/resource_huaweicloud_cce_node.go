@@ -33,6 +33,7 @@ import (
 // @API ECS POST /v1/{project_id}/cloudservers/{id}/tags/action
 // @API ECS GET /v1/{project_id}/cloudservers/{id}/tags
 // @API ECS GET /v1/{project_id}/cloudservers/{serverID}
+// @API ECS GET /v1/{project_id}/cloudservers/flavors
 func ResourceNode() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNodeCreate,
@@ -43,6 +44,13 @@ func ResourceNode() *schema.Resource {
 			StateContext: resourceNodeImport,
 		},
 
+		CustomizeDiff: common.CheckFlavorAvailability(common.FlavorChecker{
+			FlavorKey:           "flavor_id",
+			AvailabilityZoneKey: "availability_zone",
+			ListFlavors:         common.ListComputeFlavors,
+			CheckQuota:          common.CheckComputeQuota(""),
+		}),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -692,7 +700,7 @@ func resourceNodeUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	serverId := d.Get("server_id").(string)
 
 	// update node tags with ECS API
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(computeClient, d, "cloudservers", serverId)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of cce node %s: %s", d.Id(), tagErr)
@@ -26,6 +26,7 @@ import (
 // @API CCE DELETE /api/v3/projects/{project_id}/clusters/{clusterid}/nodepools/{nodepoolid}
 // @API CCE GET /api/v3/projects/{project_id}/clusters/{clusterid}/nodepools/{nodepoolid}
 // @API CCE PUT /api/v3/projects/{project_id}/clusters/{clusterid}/nodepools/{nodepoolid}
+// @API ECS GET /v1/{project_id}/cloudservers/flavors
 func ResourceNodePool() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNodePoolCreate,
@@ -37,6 +38,13 @@ func ResourceNodePool() *schema.Resource {
 			StateContext: resourceNodePoolImport,
 		},
 
+		CustomizeDiff: common.CheckFlavorAvailability(common.FlavorChecker{
+			FlavorKey:           "flavor_id",
+			AvailabilityZoneKey: "availability_zone",
+			ListFlavors:         common.ListComputeFlavors,
+			CheckQuota:          common.CheckComputeQuota("initial_node_count"),
+		}),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
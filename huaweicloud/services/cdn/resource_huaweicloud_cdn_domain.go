@@ -1087,8 +1087,8 @@ func resourceCdnDomainV1Update(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	if d.HasChange("tags") {
-		oTagsRaw, nTagsRaw := d.GetChange("tags")
+	if utils.HasTagsChange(d) {
+		oTagsRaw, nTagsRaw := utils.GetTagsChange(d)
 		oTagsMap := oTagsRaw.(map[string]interface{})
 		nTagsMap := nTagsRaw.(map[string]interface{})
 
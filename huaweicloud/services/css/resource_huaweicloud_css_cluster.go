@@ -1054,8 +1054,8 @@ func resourceCssClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	if d.HasChange("tags") {
-		oRaw, nRaw := d.GetChange("tags")
+	if utils.HasTagsChange(d) {
+		oRaw, nRaw := utils.GetTagsChange(d)
 		err = updateCssTags(cssV1Client, d.Id(), oRaw.(map[string]interface{}), nRaw.(map[string]interface{}))
 		if err != nil {
 			return diag.Errorf("error updating tags of CSS cluster= %s, err:%s", d.Id(), err)
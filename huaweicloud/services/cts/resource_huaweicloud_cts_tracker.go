@@ -240,7 +240,7 @@ func resourceCTSTrackerUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("error updating CTS tracker: %s", err)
 		}
 
-		if d.HasChange("tags") {
+		if utils.HasTagsChange(d) {
 			err = updateResourceTags(ctsClient, d)
 			if err != nil {
 				return diag.Errorf("error updating CTS tracker tags: %s", err)
@@ -357,7 +357,7 @@ func resourceCTSTrackerDelete(_ context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("falied to reset CTS system tracker: %s", err)
 	}
 
-	oldRaw, _ := d.GetChange("tags")
+	oldRaw, _ := utils.GetTagsChange(d)
 	if oldTags := oldRaw.(map[string]interface{}); len(oldTags) > 0 {
 		oldTagList := expandResourceTags(oldTags)
 		_, err = ctsClient.BatchDeleteResourceTags(buildDeleteTagOpt(oldTagList, d.Id()))
@@ -102,6 +102,12 @@ func ResourceDcsInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+
+		CustomizeDiff: common.CheckFlavorAvailability(common.FlavorChecker{
+			FlavorKey:           "flavor",
+			AvailabilityZoneKey: "availability_zones",
+			ListFlavors:         listDcsInstanceFlavors,
+		}),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(120 * time.Minute),
 			Update: schema.DefaultTimeout(120 * time.Minute),
@@ -1091,8 +1097,8 @@ func resourceDcsInstancesUpdate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	// update tags
-	if d.HasChange("tags") {
-		oldVal, newVal := d.GetChange("tags")
+	if utils.HasTagsChange(d) {
+		oldVal, newVal := utils.GetTagsChange(d)
 		err = updateDcsTags(client, d.Id(), oldVal.(map[string]interface{}), newVal.(map[string]interface{}))
 		if err != nil {
 			return diag.FromErr(err)
@@ -1318,6 +1324,41 @@ func getFlavorChangeType(oldFlavor, newFlavor *flavors.Flavor) string {
 	return ""
 }
 
+// listDcsInstanceFlavors lists the flavors of the engine which are available in all the zones, the same as the data
+// source huaweicloud_dcs_flavors.
+func listDcsInstanceFlavors(_ context.Context, d *schema.ResourceDiff, cfg *config.Config, zones []string) ([]string, error) {
+	region := common.GetDiffRegion(d, cfg)
+	opts := flavors.ListOpts{
+		Engine:        d.Get("engine").(string),
+		EngineVersion: d.Get("engine_version").(string),
+	}
+	// the flavors are cached, so they're listed once for all the instances of the same engine in a plan
+	cacheKey := fmt.Sprintf("dcs_flavors/%s/%s/%s", region, opts.Engine, opts.EngineVersion)
+	cached, err := cfg.LoadOrStoreList(cacheKey, func() (interface{}, error) {
+		client, err := cfg.DcsV2Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating DCS Client(v2): %s", err)
+		}
+		return flavors.List(client, opts).Extract()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := cached.([]flavors.Flavor)
+	result := make([]string, 0, len(list))
+	for _, flavor := range list {
+		azCodes := make([]string, 0)
+		for _, az := range flavor.AvailableZones {
+			azCodes = append(azCodes, az.AzCodes...)
+		}
+		if utils.StrSliceContainsAnother(azCodes, zones) {
+			result = append(result, flavor.SpecCode)
+		}
+	}
+	return result, nil
+}
+
 func getFlavorBySpecCode(client *golangsdk.ServiceClient, specCode string) (*flavors.Flavor, error) {
 	list, err := flavors.List(client, &flavors.ListOpts{SpecCode: specCode}).Extract()
 	if err != nil {
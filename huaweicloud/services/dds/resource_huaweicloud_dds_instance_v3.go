@@ -106,9 +106,21 @@ func ResourceDdsInstanceV3() *schema.Resource {
 				Computed: true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
 				Type:      schema.TypeString,
 				Sensitive: true,
-				Required:  true,
+				Optional:  true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"disk_encryption_id": {
 				Type:     schema.TypeString,
@@ -394,7 +406,7 @@ func resourceDdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 	}
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.Password = d.Get("password").(string)
+	createOpts.Password = getDdsPassword(d)
 
 	if val, ok := d.GetOk("port"); ok {
 		createOpts.Port = strconv.Itoa(val.(int))
@@ -590,10 +602,10 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		opts = append(opts, opt)
 	}
 
-	if d.HasChange("password") {
+	if d.HasChanges("password", "password_wo_version") {
 		opt := instances.UpdateOpt{
 			Param:  "user_pwd",
-			Value:  d.Get("password").(string),
+			Value:  getDdsPassword(d),
 			Action: "reset-password",
 			Method: "put",
 		}
@@ -701,7 +713,7 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("Error updating tags of DDS instance:%s, err:%s", d.Id(), tagErr)
@@ -1161,3 +1173,11 @@ func flavorSpecCodeUpdate(ctx context.Context, conf *config.Config, client *gola
 
 	return nil
 }
+
+// getDdsPassword returns the administrator password of the instance, the write-only argument takes precedence.
+func getDdsPassword(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, "password_wo"); v != "" {
+		return v
+	}
+	return d.Get("password").(string)
+}
@@ -341,7 +341,7 @@ func resourceDmsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
 		if err != nil {
 			return fmtp.Errorf("Error updating HuaweiCloud dms instance v2 client: %s", err)
@@ -49,7 +49,7 @@ func setAutoRecoveryForInstance(d *schema.ResourceData, meta interface{}, instan
 	err = resource.Retry(timeout, func() *resource.RetryError {
 		err := auto_recovery.Update(client, rId, updateOpts)
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
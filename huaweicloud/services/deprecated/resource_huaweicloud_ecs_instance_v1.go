@@ -474,7 +474,7 @@ func resourceEcsInstanceV1Update(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		ecsClient, err := config.ComputeV1Client(config.GetRegion(d))
 		if err != nil {
 			return fmtp.Errorf("Error creating HuaweiCloud compute v1 client: %s", err)
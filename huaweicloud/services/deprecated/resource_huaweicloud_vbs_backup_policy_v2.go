@@ -296,7 +296,7 @@ func resourceVBSBackupPolicyV2Update(d *schema.ResourceData, meta interface{}) e
 			return fmtp.Errorf("Error updating Huaweicloud backup policy: %s", err)
 		}
 	}
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		oldTags, _ := tags.Get(vbsClient, d.Id()).Extract()
 		deleteopts := tags.BatchOpts{Action: tags.ActionDelete, Tags: oldTags.Tags}
 		deleteTags := tags.BatchAction(vbsClient, d.Id(), deleteopts)
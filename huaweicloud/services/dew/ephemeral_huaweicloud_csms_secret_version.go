@@ -0,0 +1,152 @@
+package dew
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/chnsz/golangsdk/openstack/csms/v1/secrets"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &csmsSecretVersionEphemeral{}
+
+// @API DEW GET /v1/{project_id}/secrets/{secret_name}/versions
+// @API DEW GET /v1/{project_id}/secrets/{secret_name}/versions/{version_id}
+func NewCsmsSecretVersionEphemeral() ephemeral.EphemeralResource {
+	return &csmsSecretVersionEphemeral{}
+}
+
+type csmsSecretVersionEphemeral struct {
+	cfg *config.Config
+}
+
+type csmsSecretVersionModel struct {
+	Region     types.String `tfsdk:"region"`
+	SecretName types.String `tfsdk:"secret_name"`
+	Version    types.String `tfsdk:"version"`
+	SecretText types.String `tfsdk:"secret_text"`
+	KmsKeyId   types.String `tfsdk:"kms_key_id"`
+	Status     types.List   `tfsdk:"status"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+func (e *csmsSecretVersionEphemeral) Metadata(_ context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_csms_secret_version"
+}
+
+func (e *csmsSecretVersionEphemeral) Schema(_ context.Context, _ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this ephemeral resource to get the secret value of a CSMS secret version, " +
+			"the value is never persisted to the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The region in which to query the secret version.",
+			},
+			"secret_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the secret.",
+			},
+			"version": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The version of the secret, defaults to the latest version.",
+			},
+			"secret_text": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The plaintext of the secret version.",
+			},
+			"kms_key_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the KMS key used to encrypt the secret.",
+			},
+			"status": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The status list of the secret version.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The creation time of the secret version.",
+			},
+		},
+	}
+}
+
+func (e *csmsSecretVersionEphemeral) Configure(_ context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("expected *config.Config, but got %T", req.ProviderData))
+		return
+	}
+	e.cfg = cfg
+}
+
+func (e *csmsSecretVersionEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse) {
+	if e.cfg == nil {
+		resp.Diagnostics.AddError("Unconfigured provider",
+			"the provider has not been configured, please report this issue to the provider developers")
+		return
+	}
+
+	var data csmsSecretVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region := e.cfg.Region
+	if v := data.Region.ValueString(); v != "" {
+		region = v
+	}
+
+	var (
+		version *secrets.Version
+		err     error
+	)
+	secretName := data.SecretName.ValueString()
+	if v := data.Version.ValueString(); v != "" {
+		version, err = queryVersion(e.cfg, region, secretName, v)
+	} else {
+		version, err = queryLatestVersion(e.cfg, region, secretName)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error querying CSMS secret version", err.Error())
+		return
+	}
+
+	vMetadata := version.VersionMetadata
+	status, diags := types.ListValueFrom(ctx, types.StringType, vMetadata.VersionStages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Region = types.StringValue(region)
+	data.SecretName = types.StringValue(vMetadata.SecretName)
+	data.Version = types.StringValue(vMetadata.ID)
+	data.SecretText = types.StringValue(version.SecretString)
+	data.KmsKeyId = types.StringValue(vMetadata.KmsKeyID)
+	data.Status = status
+	data.CreatedAt = types.StringValue(
+		time.Unix(int64(vMetadata.CreateTime)/1000, 0).UTC().Format("2006-01-02 15:04:05 MST"))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,130 @@
+package dew
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/chnsz/golangsdk/openstack/kms/v1/keys"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &kmsDataKeyEphemeral{}
+
+// @API DEW POST /v1.0/{project_id}/kms/create-datakey
+func NewKmsDataKeyEphemeral() ephemeral.EphemeralResource {
+	return &kmsDataKeyEphemeral{}
+}
+
+type kmsDataKeyEphemeral struct {
+	cfg *config.Config
+}
+
+type kmsDataKeyModel struct {
+	Region            types.String `tfsdk:"region"`
+	KeyId             types.String `tfsdk:"key_id"`
+	EncryptionContext types.String `tfsdk:"encryption_context"`
+	DatakeyLength     types.String `tfsdk:"datakey_length"`
+	PlainText         types.String `tfsdk:"plain_text"`
+	CipherText        types.String `tfsdk:"cipher_text"`
+}
+
+func (e *kmsDataKeyEphemeral) Metadata(_ context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kms_data_key"
+}
+
+func (e *kmsDataKeyEphemeral) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this ephemeral resource to create a KMS data key, " +
+			"the plaintext of the data key is never persisted to the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The region in which to create the data key.",
+			},
+			"key_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the KMS key used to encrypt the data key.",
+			},
+			"encryption_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "The key/value pairs in JSON format used for the authentication.",
+			},
+			"datakey_length": schema.StringAttribute{
+				Required:    true,
+				Description: "The bit length of the data key.",
+			},
+			"plain_text": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The plaintext of the data key in hexadecimal.",
+			},
+			"cipher_text": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ciphertext of the data key in hexadecimal.",
+			},
+		},
+	}
+}
+
+func (e *kmsDataKeyEphemeral) Configure(_ context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("expected *config.Config, but got %T", req.ProviderData))
+		return
+	}
+	e.cfg = cfg
+}
+
+func (e *kmsDataKeyEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if e.cfg == nil {
+		resp.Diagnostics.AddError("Unconfigured provider",
+			"the provider has not been configured, please report this issue to the provider developers")
+		return
+	}
+
+	var data kmsDataKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region := e.cfg.Region
+	if v := data.Region.ValueString(); v != "" {
+		region = v
+	}
+	client, err := e.cfg.KmsKeyV1Client(region)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating KMS client", err.Error())
+		return
+	}
+
+	opts := &keys.DataEncryptOpts{
+		KeyID:             data.KeyId.ValueString(),
+		EncryptionContext: data.EncryptionContext.ValueString(),
+		DatakeyLength:     data.DatakeyLength.ValueString(),
+	}
+	v, err := keys.DataEncryptGet(client, opts).ExtractDataKey()
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating KMS data key", err.Error())
+		return
+	}
+
+	data.Region = types.StringValue(region)
+	data.PlainText = types.StringValue(v.PlainText)
+	data.CipherText = types.StringValue(v.CipherText)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -320,7 +320,7 @@ func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		streamId := d.Get("stream_id").(string)
 		tagErr := utils.UpdateResourceTags(client, d, "stream", streamId)
 		if tagErr != nil {
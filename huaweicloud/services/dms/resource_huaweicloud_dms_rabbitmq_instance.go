@@ -681,7 +681,7 @@ func resourceDmsRabbitmqInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		// update tags
 		tagErr := utils.UpdateResourceTags(client, d, engineRabbitMQ, d.Id())
 		if tagErr != nil {
@@ -591,7 +591,7 @@ func resourceDmsRocketMQInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(updateRocketmqInstanceClient, d, "rocketmq", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of RocketMQ:%s, err:%s", d.Id(), tagErr)
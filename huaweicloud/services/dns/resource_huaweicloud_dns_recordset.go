@@ -370,7 +370,7 @@ func resourceDNSRecordsetUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		resourceType, err := utils.GetDNSRecordSetTagType(zoneType)
 		if err != nil {
 			return diag.FromErr(err)
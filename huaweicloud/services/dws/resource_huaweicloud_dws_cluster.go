@@ -1006,7 +1006,7 @@ func resourceDwsClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// change tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = updateClusterTags(clusterClient, d, d.Id())
 		if err != nil {
 			return diag.Errorf("error updating tags of DWS cluster:%s, err:%s", d.Id(), err)
@@ -1281,7 +1281,7 @@ func deleteClusterTags(client *golangsdk.ServiceClient, clusterId string, rawTag
 }
 
 func updateClusterTags(client *golangsdk.ServiceClient, d *schema.ResourceData, id string) error {
-	oRaw, nRaw := d.GetChange("tags")
+	oRaw, nRaw := utils.GetTagsChange(d)
 	oMap := oRaw.(map[string]interface{})
 	nMap := nRaw.(map[string]interface{})
 
@@ -59,6 +59,7 @@ var (
 // @API EVS POST /v2.1/{project_id}/cloudvolumes/{id}/action
 // @API VPC GET /v1/{project_id}/security-groups
 // @API VPC GET /v1/{project_id}/subnets/{id}
+// @API ECS GET /v1/{project_id}/cloudservers/flavors
 func ResourceComputeInstance() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceComputeInstanceCreate,
@@ -70,6 +71,13 @@ func ResourceComputeInstance() *schema.Resource {
 			StateContext: resourceComputeInstanceImportState,
 		},
 
+		CustomizeDiff: common.CheckFlavorAvailability(common.FlavorChecker{
+			FlavorKey:           "flavor_id",
+			AvailabilityZoneKey: "availability_zone",
+			ListFlavors:         common.ListComputeFlavors,
+			CheckQuota:          common.CheckComputeQuota(""),
+		}),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -132,9 +140,22 @@ func ResourceComputeInstance() *schema.Resource {
 				Description: "schema: Computed",
 			},
 			"admin_pass": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Optional:  true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ConflictsWith: []string{"admin_pass_wo"},
+			},
+			"admin_pass_wo": {
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"admin_pass"},
+			},
+			"admin_pass_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"admin_pass_wo"},
 			},
 			"key_pair": {
 				Type:     schema.TypeString,
@@ -683,7 +704,7 @@ func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] ECS create options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.AdminPass = d.Get("admin_pass").(string)
+	createOpts.AdminPass = getAdminPass(d)
 
 	if d.Get("charging_mode") == "prePaid" {
 		// prePaid.
@@ -1058,8 +1079,8 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("admin_pass") {
-		if newPwd, ok := d.Get("admin_pass").(string); ok {
+	if d.HasChanges("admin_pass", "admin_pass_wo_version") {
+		if newPwd := getAdminPass(d); newPwd != "" {
 			err := cloudservers.ChangeAdminPassword(ecsClient, serverID, newPwd).ExtractErr()
 			if err != nil {
 				return diag.Errorf("error changing admin password of server (%s): %s", serverID, err)
@@ -1104,7 +1125,7 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", serverID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of instance:%s, err:%s", serverID, err)
@@ -1193,7 +1214,7 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 			InUsedKeyPair:    o.(string),
 			NewKeyPair:       n.(string),
 			InUsedPrivateKey: d.Get("private_key").(string),
-			Password:         d.Get("admin_pass").(string),
+			Password:         getAdminPass(d),
 			Timeout:          d.Timeout(schema.TimeoutUpdate),
 		}
 		if err := common.UpdateEcsInstanceKeyPair(ctx, ecsClient, kmsClient, keyPairOpts); err != nil {
@@ -1899,3 +1920,11 @@ func buildInstanceDataVolumes(d *schema.ResourceData) []cloudservers.DataVolume
 	}
 	return volRequests
 }
+
+// getAdminPass returns the administrator password of the instance, the write-only argument takes precedence.
+func getAdminPass(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, "admin_pass_wo"); v != "" {
+		return v
+	}
+	return d.Get("admin_pass").(string)
+}
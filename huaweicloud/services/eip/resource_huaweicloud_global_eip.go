@@ -326,7 +326,7 @@ func resourceGlobalEIPUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := updateTags(client, d, "global-eip", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of global EIP (%s): %s", d.Id(), tagErr)
@@ -250,7 +250,7 @@ func resourceInternetBandwidthUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := updateTags(client, d, "internet-bandwidth", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of global internet bandwidth (%s): %s", d.Id(), tagErr)
@@ -276,7 +276,7 @@ func buildUpdateInternetBandwidthBodyParams(d *schema.ResourceData) map[string]i
 }
 
 func updateTags(client *golangsdk.ServiceClient, d *schema.ResourceData, tagsType string, id string) error {
-	oRaw, nRaw := d.GetChange("tags")
+	oRaw, nRaw := utils.GetTagsChange(d)
 	oMap := oRaw.(map[string]interface{})
 	nMap := nRaw.(map[string]interface{})
 
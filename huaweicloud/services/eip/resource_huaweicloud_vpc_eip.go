@@ -669,7 +669,7 @@ func resourceVpcEipUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		vpcV2Client, err := cfg.NetworkingV2Client(region)
 		if err != nil {
 			return diag.Errorf("error creating VPC v2 client: %s", err)
@@ -437,7 +437,7 @@ func resourceListenerV3Update(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		elbV2Client, err := cfg.ElbV2Client(cfg.GetRegion(d))
 		if err != nil {
 			return diag.Errorf("error creating ELB 2.0 client: %s", err)
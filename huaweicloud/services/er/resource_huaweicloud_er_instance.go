@@ -452,7 +452,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = utils.UpdateResourceTags(client, d, "instance", d.Id())
 		if err != nil {
 			return diag.Errorf("error updating instance tags: %s", err)
@@ -251,7 +251,7 @@ func resourceRouteTableUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = utils.UpdateResourceTags(client, d, "route-table", d.Id())
 		if err != nil {
 			return diag.Errorf("error updating route table tags: %s", err)
@@ -255,7 +255,7 @@ func resourceVpcAttachmentUpdate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = utils.UpdateResourceTags(client, d, "vpc-attachment", d.Id())
 		if err != nil {
 			return diag.Errorf("error updating VPC attachment tags: %s", err)
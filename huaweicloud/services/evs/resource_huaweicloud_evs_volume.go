@@ -529,7 +529,7 @@ func resourceEvsVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(evsV2Client, d, "cloudvolumes", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of volume:%s, err:%s", d.Id(), tagErr)
@@ -671,7 +671,7 @@ func resourceGeminiDBInstanceV3Update(d *schema.ResourceData, meta interface{},
 		return fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
 	}
 	//update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of GeminiDB %q: %s", d.Id(), tagErr)
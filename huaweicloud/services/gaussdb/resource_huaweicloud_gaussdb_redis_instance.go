@@ -609,7 +609,7 @@ func resourceGaussRedisInstanceV3Update(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("error creating HuaweiCloud bss V2 client: %s", err)
 	}
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of GaussDB for Redis %q: %s", d.Id(), tagErr)
@@ -748,7 +748,7 @@ func resourceIAMAgencyV3Update(ctx context.Context, d *schema.ResourceData, meta
 		err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 			_, err := agency.Update(iamClient, agencyID, updateOpts).Extract()
 			if err != nil {
-				return common.CheckForRetryableError(err)
+				return common.CheckForRetryableError(cfg, err)
 			}
 			return nil
 		})
@@ -799,7 +799,7 @@ func resourceIAMAgencyV3Delete(ctx context.Context, d *schema.ResourceData, meta
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err := agency.Delete(iamClient, rID).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(cfg, err)
 		}
 		return nil
 	})
@@ -23,6 +23,7 @@ import (
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 // @API IMS POST /v2/cloudimages/action
@@ -498,7 +499,7 @@ func resourceImsImageUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		oldTags, err := tags.Get(imsClient, d.Id()).Extract()
 		if err != nil {
 			return diag.Errorf("error fetching image tags: %s", err)
@@ -202,7 +202,7 @@ func resourceCertificateV2Update(ctx context.Context, d *schema.ResourceData, me
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		_, err := certificates.Update(elbClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
@@ -226,7 +226,7 @@ func resourceCertificateV2Delete(ctx context.Context, d *schema.ResourceData, me
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err := certificates.Delete(elbClient, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
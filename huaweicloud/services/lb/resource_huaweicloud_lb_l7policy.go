@@ -307,7 +307,7 @@ func resourceL7PolicyV2Update(ctx context.Context, d *schema.ResourceData, meta
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		_, err = l7policies.Update(lbClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
@@ -358,7 +358,7 @@ func resourceL7PolicyV2Delete(ctx context.Context, d *schema.ResourceData, meta
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = l7policies.Delete(lbClient, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
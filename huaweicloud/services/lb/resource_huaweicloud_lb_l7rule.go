@@ -299,7 +299,7 @@ func resourceL7RuleV2Update(ctx context.Context, d *schema.ResourceData, meta in
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		_, err := l7policies.UpdateRule(lbClient, l7policyID, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
@@ -358,7 +358,7 @@ func resourceL7RuleV2Delete(ctx context.Context, d *schema.ResourceData, meta in
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = l7policies.DeleteRule(lbClient, l7policyID, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
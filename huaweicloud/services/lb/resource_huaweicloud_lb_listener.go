@@ -315,7 +315,7 @@ func resourceListenerV2Update(ctx context.Context, d *schema.ResourceData, meta
 		err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 			_, err = listeners.Update(lbClient, d.Id(), updateOpts).Extract()
 			if err != nil {
-				return common.CheckForRetryableError(err)
+				return common.CheckForRetryableError(cfg, err)
 			}
 			return nil
 		})
@@ -332,7 +332,7 @@ func resourceListenerV2Update(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(lbv2Client, d, "listeners", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of ELB listener:%s, err:%s", d.Id(), tagErr)
@@ -362,7 +362,7 @@ func resourceListenerV2Delete(ctx context.Context, d *schema.ResourceData, meta
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = listeners.Delete(lbClient, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(cfg, err)
 		}
 		return nil
 	})
@@ -315,7 +315,7 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 		err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 			_, err = loadbalancers.Update(elbClient, d.Id(), updateOpts).Extract()
 			if err != nil {
-				return common.CheckForRetryableError(err)
+				return common.CheckForRetryableError(cfg, err)
 			}
 			return nil
 		})
@@ -343,7 +343,7 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		elbV2Client, err := cfg.ElbV2Client(region)
 		if err != nil {
 			return diag.Errorf("error creating ELB v2.0 client: %s", err)
@@ -371,7 +371,7 @@ func resourceLoadBalancerV2Delete(ctx context.Context, d *schema.ResourceData, m
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = loadbalancers.Delete(elbClient, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(cfg, err)
 		}
 		return nil
 	})
@@ -218,7 +218,7 @@ func resourceMemberV2Update(ctx context.Context, d *schema.ResourceData, meta in
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		_, err = pools.UpdateMember(lbClient, poolID, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
@@ -255,7 +255,7 @@ func resourceMemberV2Delete(ctx context.Context, d *schema.ResourceData, meta in
 	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = pools.DeleteMember(lbClient, poolID, d.Id()).ExtractErr()
 		if err != nil {
-			return common.CheckForRetryableError(err)
+			return common.CheckForRetryableError(config, err)
 		}
 		return nil
 	})
@@ -1289,7 +1289,7 @@ func resourceMRSClusterV2Update(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := updateResourceTagsWithSleep(client, d, "clusters", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of MRS cluster:%s, err:%s", d.Id(), tagErr)
@@ -1383,8 +1383,8 @@ func queryMrsClusterHosts(d *schema.ResourceData, mrsV1Client *golangsdk.Service
 }
 
 func updateResourceTagsWithSleep(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
-	if d.HasChange("tags") {
-		oRaw, nRaw := d.GetChange("tags")
+	if utils.HasTagsChange(d) {
+		oRaw, nRaw := utils.GetTagsChange(d)
 		oMap := oRaw.(map[string]interface{})
 		nMap := nRaw.(map[string]interface{})
 
@@ -208,7 +208,7 @@ func resourcePrivateGatewayUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = utils.UpdateResourceTags(natClient, d, "private-nat-gateways", gatewayId)
 		if err != nil {
 			return diag.Errorf("error updating tags of the private NAT gateway (%s): %s", gatewayId, err)
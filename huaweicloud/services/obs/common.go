@@ -0,0 +1,73 @@
+package obs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+)
+
+// The sub-resources which are not supported by the SDK.
+const (
+	subResourceObjectLock obs.SubResourceType = "object-lock"
+	subResourceRetention  obs.SubResourceType = "retention"
+)
+
+// putSubResource sets the sub-resource of the bucket, or the object if the key is not empty, by the signed URL. It is
+// used for the sub-resources which are not supported by the SDK, the errors are still parsed as the obs.ObsError.
+func putSubResource(obsClient *obs.ObsClient, bucket, key string, subResource obs.SubResourceType, data []byte) error {
+	signedURL, err := obsClient.CreateSignedUrl(&obs.CreateSignedUrlInput{
+		Method:      obs.HttpMethodPut,
+		Bucket:      bucket,
+		Key:         key,
+		SubResource: subResource,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = obsClient.PutObjectWithSignedUrl(signedURL.SignedUrl, signedURL.ActualSignedRequestHeaders,
+		bytes.NewReader(data))
+	return err
+}
+
+// getSubResource returns the raw body of the sub-resource of the bucket, or the object if the key is not empty, by the
+// signed URL.
+func getSubResource(obsClient *obs.ObsClient, bucket, key string, subResource obs.SubResourceType) ([]byte, error) {
+	signedURL, err := obsClient.CreateSignedUrl(&obs.CreateSignedUrlInput{
+		Method:      obs.HttpMethodGet,
+		Bucket:      bucket,
+		Key:         key,
+		SubResource: subResource,
+	})
+	if err != nil {
+		return nil, err
+	}
+	output, err := obsClient.GetObjectWithSignedUrl(signedURL.SignedUrl, signedURL.ActualSignedRequestHeaders)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the %s configuration: %s", subResource, err)
+	}
+	return data, nil
+}
+
+// isObsNotFoundError returns whether the error is the 404 error of OBS, the configurations which are not set are also
+// reported as 404, such as NoSuchLifecycleConfiguration and NoSuchCORSConfiguration.
+func isObsNotFoundError(err error) bool {
+	obsErr, ok := err.(obs.ObsError)
+	return ok && obsErr.StatusCode == 404
+}
+
+// bucketConfigurationConflictError returns the error of creating a standalone resource for the configuration which
+// already exists in the bucket, it is usually managed by the inline argument of huaweicloud_obs_bucket, and the two
+// forms overwrite each other if they are used together.
+func bucketConfigurationConflictError(bucket, configuration, inlineArgument string) error {
+	return fmt.Errorf("the %s of OBS bucket %s already exists, it may be managed by the '%s' of huaweicloud_obs_bucket "+
+		"or by another resource, please remove it from there and import it into this resource", configuration, bucket,
+		inlineArgument)
+}
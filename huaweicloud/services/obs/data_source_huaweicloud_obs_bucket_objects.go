@@ -0,0 +1,178 @@
+package obs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+// The maximum number of the objects returned by one request.
+const listObjectsPageSize = 1000
+
+// @API OBS GET /
+func DataSourceObsBucketObjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceObsBucketObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"common_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listBucketObjects lists the objects and the common prefixes of the bucket page by page, the results are limited by
+// maxKeys if it is positive.
+func listBucketObjects(obsClient *obs.ObsClient, bucket, prefix, delimiter string,
+	maxKeys int) ([]obs.Content, []string, error) {
+	var contents []obs.Content
+	var commonPrefixes []string
+
+	input := &obs.ListObjectsInput{
+		Bucket: bucket,
+	}
+	input.Prefix = prefix
+	input.Delimiter = delimiter
+	for {
+		input.MaxKeys = listObjectsPageSize
+		if remain := maxKeys - len(contents) - len(commonPrefixes); maxKeys > 0 && remain < listObjectsPageSize {
+			input.MaxKeys = remain
+		}
+
+		resp, err := obsClient.ListObjects(input)
+		if err != nil {
+			// the error is wrapped, so that the callers can check the status code by errors.As
+			return nil, nil, fmt.Errorf("Error listing objects of OBS bucket %s: %w", bucket, err)
+		}
+		contents = append(contents, resp.Contents...)
+		commonPrefixes = append(commonPrefixes, resp.CommonPrefixes...)
+		if !resp.IsTruncated || (maxKeys > 0 && len(contents)+len(commonPrefixes) >= maxKeys) {
+			break
+		}
+
+		// The next marker is returned only if the delimiter is specified.
+		marker := resp.NextMarker
+		if marker == "" && len(resp.Contents) > 0 {
+			marker = resp.Contents[len(resp.Contents)-1].Key
+		}
+		if marker == "" || marker == input.Marker {
+			break
+		}
+		input.Marker = marker
+	}
+	return contents, commonPrefixes, nil
+}
+
+func dataSourceObsBucketObjectsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	region := conf.GetRegion(d)
+	obsClient, err := conf.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("Error creating OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	contents, commonPrefixes, err := listBucketObjects(obsClient, bucket, d.Get("prefix").(string),
+		d.Get("delimiter").(string), d.Get("max_keys").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := make([]string, len(contents))
+	objects := make([]map[string]interface{}, len(contents))
+	for i, content := range contents {
+		class := "STANDARD"
+		if content.StorageClass != "" {
+			class = normalizeStorageClass(string(content.StorageClass))
+		}
+		keys[i] = content.Key
+		objects[i] = map[string]interface{}{
+			"key":           content.Key,
+			"size":          content.Size,
+			"etag":          strings.Trim(content.ETag, `"`),
+			"storage_class": class,
+			"last_modified": content.LastModified.UTC().Format(time.RFC3339),
+		}
+	}
+
+	d.SetId(hashcode.Strings(append([]string{bucket}, keys...)))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("keys", keys),
+		d.Set("common_prefixes", commonPrefixes),
+		d.Set("objects", objects),
+	)
+	if mErr.ErrorOrNil() != nil {
+		return diag.Errorf("error setting OBS bucket objects attributes: %s", mErr)
+	}
+	return nil
+}
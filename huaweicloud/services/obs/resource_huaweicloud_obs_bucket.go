@@ -68,6 +68,8 @@ func ResourceObsBucket() *schema.Resource {
 			StateContext: resourceObsBucketImport,
 		},
 
+		CustomizeDiff: resourceObsBucketCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -110,6 +112,7 @@ func ResourceObsBucket() *schema.Resource {
 			"logging": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"target_bucket": {
@@ -158,95 +161,14 @@ func ResourceObsBucket() *schema.Resource {
 			"lifecycle_rule": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"enabled": {
-							Type:     schema.TypeBool,
-							Required: true,
-						},
-						"prefix": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"expiration": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
-								},
-							},
-						},
-						"transition": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
-									"storage_class": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
-						},
-						"noncurrent_version_expiration": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
-								},
-							},
-						},
-						"abort_incomplete_multipart_upload": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
-								},
-							},
-						},
-						"noncurrent_version_transition": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
-									"storage_class": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
-						},
-					},
-				},
+				Computed: true,
+				Elem:     lifecycleRuleSchema(),
 			},
 
 			"website": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -286,36 +208,23 @@ func ResourceObsBucket() *schema.Resource {
 			"cors_rule": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"allowed_origins": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"allowed_methods": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"allowed_headers": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"expose_headers": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"max_age_seconds": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  100,
-						},
-					},
+				Computed: true,
+				Elem:     corsRuleSchema(),
+			},
+
+			"standalone_configurations": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(inlineBucketConfigurations, false),
 				},
 			},
+			"inline_configurations": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 
 			"tags": common.TagsSchema(),
 			"force_destroy": {
@@ -384,6 +293,186 @@ func ResourceObsBucket() *schema.Resource {
 	}
 }
 
+// lifecycleRuleSchema returns the schema of the lifecycle rule, which is shared with the standalone lifecycle
+// configuration resource.
+func lifecycleRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expiration": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"transition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"noncurrent_version_expiration": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"abort_incomplete_multipart_upload": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"noncurrent_version_transition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// corsRuleSchema returns the schema of the CORS rule, which is shared with the standalone CORS configuration resource.
+func corsRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"allowed_origins": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"allowed_methods": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"allowed_headers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"expose_headers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"max_age_seconds": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  100,
+			},
+		},
+	}
+}
+
+// inlineBucketConfigurations are the arguments which can also be managed by the standalone resources.
+var inlineBucketConfigurations = []string{"logging", "lifecycle_rule", "website", "cors_rule"}
+
+// resourceObsBucketCustomizeDiff deletes the inline configurations which are removed from the configuration, unless
+// they are listed in standalone_configurations. The arguments are computed, so that the configurations managed by the
+// standalone resources are exported without any diff.
+// The configurations which are specified inline are saved to inline_configurations, and removing a configuration
+// which was not specified inline fails the plan, since it's managed outside of the bucket resource, e.g. by a
+// standalone resource in another state, and the bucket would delete it silently.
+func resourceObsBucketCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	inline := make([]interface{}, 0)
+	for _, key := range inlineBucketConfigurations {
+		if v := rawConfig.GetAttr(key); !v.IsKnown() || (!v.IsNull() && v.LengthInt() > 0) {
+			inline = append(inline, key)
+		}
+	}
+	oldInline, _ := d.GetChange("inline_configurations")
+	if !schema.NewSet(schema.HashString, inline).Equal(oldInline) {
+		if err := d.SetNew("inline_configurations", inline); err != nil {
+			return fmt.Errorf("error setting inline_configurations: %s", err)
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	// the states saved before inline_configurations is introduced have no record of the inline configurations
+	legacyState := d.GetRawState().IsNull() || d.GetRawState().GetAttr("inline_configurations").IsNull()
+	standalone := d.Get("standalone_configurations").(*schema.Set)
+	for _, key := range inlineBucketConfigurations {
+		if standalone.Contains(key) || utils.StrSliceContains(utils.ExpandToStringList(inline), key) {
+			continue
+		}
+
+		oldValue, _ := d.GetChange(key)
+		if set, ok := oldValue.(*schema.Set); ok {
+			oldValue = set.List()
+		}
+		if values, _ := oldValue.([]interface{}); len(values) == 0 {
+			continue
+		}
+		if !legacyState && !oldInline.(*schema.Set).Contains(key) {
+			return fmt.Errorf("the %s of the bucket %s is not specified by this resource, it may be managed by a "+
+				"standalone resource or outside of Terraform: add %q to standalone_configurations to keep it, or "+
+				"delete it where it's managed", key, d.Id(), key)
+		}
+		if err := d.SetNew(key, []interface{}{}); err != nil {
+			return fmt.Errorf("error deleting %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
 func resourceObsBucketCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conf := meta.(*config.Config)
 	region := conf.GetRegion(d)
@@ -454,7 +543,7 @@ func resourceObsBucketUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		if err := resourceObsBucketTagsUpdate(obsClient, d); err != nil {
 			return diag.FromErr(err)
 		}
@@ -815,23 +904,11 @@ func resourceObsBucketEncryptionUpdate(config *config.Config, obsClient *obs.Obs
 func resourceObsBucketLoggingUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
 	rawLogging := d.Get("logging").(*schema.Set).List()
-	loggingStatus := &obs.SetBucketLoggingConfigurationInput{}
-	loggingStatus.Bucket = bucket
-
+	logging := make(map[string]interface{})
 	if len(rawLogging) > 0 {
-		c := rawLogging[0].(map[string]interface{})
-		if val := c["target_bucket"].(string); val != "" {
-			loggingStatus.TargetBucket = val
-		}
-
-		if val := c["target_prefix"].(string); val != "" {
-			loggingStatus.TargetPrefix = val
-		}
-
-		if val := c["agency"].(string); val != "" {
-			loggingStatus.Agency = val
-		}
+		logging = rawLogging[0].(map[string]interface{})
 	}
+	loggingStatus := buildObsBucketLoggingInput(bucket, logging)
 	log.Printf("[DEBUG] set logging of OBS bucket %s: %#v", bucket, loggingStatus)
 
 	_, err := obsClient.SetBucketLoggingConfiguration(loggingStatus)
@@ -842,6 +919,24 @@ func resourceObsBucketLoggingUpdate(obsClient *obs.ObsClient, d *schema.Resource
 	return nil
 }
 
+// buildObsBucketLoggingInput builds the logging configuration from the raw logging map, the logging is disabled if the
+// map is empty.
+func buildObsBucketLoggingInput(bucket string, logging map[string]interface{}) *obs.SetBucketLoggingConfigurationInput {
+	loggingStatus := &obs.SetBucketLoggingConfigurationInput{}
+	loggingStatus.Bucket = bucket
+
+	if val, ok := logging["target_bucket"].(string); ok && val != "" {
+		loggingStatus.TargetBucket = val
+	}
+	if val, ok := logging["target_prefix"].(string); ok && val != "" {
+		loggingStatus.TargetPrefix = val
+	}
+	if val, ok := logging["agency"].(string); ok && val != "" {
+		loggingStatus.Agency = val
+	}
+	return loggingStatus
+}
+
 func resourceObsBucketQuotaUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
 	quota := d.Get("quota").(int)
@@ -871,6 +966,20 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		return nil
 	}
 
+	opts := &obs.SetBucketLifecycleConfigurationInput{}
+	opts.Bucket = bucket
+	opts.LifecycleRules = buildObsBucketLifecycleRules(lifecycleRules)
+	log.Printf("[DEBUG] set lifecycle configurations of OBS bucket %s: %#v", bucket, opts)
+
+	_, err := obsClient.SetBucketLifecycleConfiguration(opts)
+	if err != nil {
+		return getObsError("Error setting lifecycle rules of OBS bucket", bucket, err)
+	}
+
+	return nil
+}
+
+func buildObsBucketLifecycleRules(lifecycleRules []interface{}) []obs.LifecycleRule {
 	rules := make([]obs.LifecycleRule, len(lifecycleRules))
 	for i, lifecycleRule := range lifecycleRules {
 		r := lifecycleRule.(map[string]interface{})
@@ -889,7 +998,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		rules[i].Prefix = r["prefix"].(string)
 
 		// Expiration
-		expiration := d.Get(fmt.Sprintf("lifecycle_rule.%d.expiration", i)).(*schema.Set).List()
+		expiration := r["expiration"].(*schema.Set).List()
 		if len(expiration) > 0 {
 			raw := expiration[0].(map[string]interface{})
 			exp := &rules[i].Expiration
@@ -900,7 +1009,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		}
 
 		// Transition
-		transitions := d.Get(fmt.Sprintf("lifecycle_rule.%d.transition", i)).([]interface{})
+		transitions := r["transition"].([]interface{})
 		list := make([]obs.Transition, len(transitions))
 		for j, tran := range transitions {
 			raw := tran.(map[string]interface{})
@@ -915,7 +1024,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		rules[i].Transitions = list
 
 		// NoncurrentVersionExpiration
-		ncExpiration := d.Get(fmt.Sprintf("lifecycle_rule.%d.noncurrent_version_expiration", i)).(*schema.Set).List()
+		ncExpiration := r["noncurrent_version_expiration"].(*schema.Set).List()
 		if len(ncExpiration) > 0 {
 			raw := ncExpiration[0].(map[string]interface{})
 			ncExp := &rules[i].NoncurrentVersionExpiration
@@ -926,8 +1035,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		}
 
 		// AbortIncompleteMultipartUpload
-		abortIncompleteMultipartUpload := d.Get(fmt.Sprintf("lifecycle_rule.%d.abort_incomplete_multipart_upload",
-			i)).(*schema.Set).List()
+		abortIncompleteMultipartUpload := r["abort_incomplete_multipart_upload"].(*schema.Set).List()
 		if len(abortIncompleteMultipartUpload) > 0 {
 			raw := abortIncompleteMultipartUpload[0].(map[string]interface{})
 			abincomMultipartUpload := &rules[i].AbortIncompleteMultipartUpload
@@ -938,7 +1046,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		}
 
 		// NoncurrentVersionTransition
-		ncTransitions := d.Get(fmt.Sprintf("lifecycle_rule.%d.noncurrent_version_transition", i)).([]interface{})
+		ncTransitions := r["noncurrent_version_transition"].([]interface{})
 		ncList := make([]obs.NoncurrentVersionTransition, len(ncTransitions))
 		for j, ncTran := range ncTransitions {
 			raw := ncTran.(map[string]interface{})
@@ -952,18 +1060,7 @@ func resourceObsBucketLifecycleUpdate(obsClient *obs.ObsClient, d *schema.Resour
 		}
 		rules[i].NoncurrentVersionTransitions = ncList
 	}
-
-	opts := &obs.SetBucketLifecycleConfigurationInput{}
-	opts.Bucket = bucket
-	opts.LifecycleRules = rules
-	log.Printf("[DEBUG] set lifecycle configurations of OBS bucket %s: %#v", bucket, opts)
-
-	_, err := obsClient.SetBucketLifecycleConfiguration(opts)
-	if err != nil {
-		return getObsError("Error setting lifecycle rules of OBS bucket", bucket, err)
-	}
-
-	return nil
+	return rules
 }
 
 func resourceObsBucketWebsiteUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
@@ -998,7 +1095,19 @@ func resourceObsBucketCorsUpdate(obsClient *obs.ObsClient, d *schema.ResourceDat
 		return nil
 	}
 
-	// set CORS
+	corsInput := &obs.SetBucketCorsInput{}
+	corsInput.Bucket = bucket
+	corsInput.CorsRules = buildObsBucketCorsRules(rawCors)
+	log.Printf("[DEBUG] OBS bucket: %s, put CORS: %#v", bucket, corsInput)
+
+	_, err := obsClient.SetBucketCors(corsInput)
+	if err != nil {
+		return getObsError("Error setting CORS rules of OBS bucket", bucket, err)
+	}
+	return nil
+}
+
+func buildObsBucketCorsRules(rawCors []interface{}) []obs.CorsRule {
 	rules := make([]obs.CorsRule, 0, len(rawCors))
 	for _, cors := range rawCors {
 		corsMap := cors.(map[string]interface{})
@@ -1023,20 +1132,9 @@ func resourceObsBucketCorsUpdate(obsClient *obs.ObsClient, d *schema.ResourceDat
 				}
 			}
 		}
-		log.Printf("[DEBUG] set CORS of OBS bucket %s: %#v", bucket, r)
 		rules = append(rules, r)
 	}
-
-	corsInput := &obs.SetBucketCorsInput{}
-	corsInput.Bucket = bucket
-	corsInput.CorsRules = rules
-	log.Printf("[DEBUG] OBS bucket: %s, put CORS: %#v", bucket, corsInput)
-
-	_, err := obsClient.SetBucketCors(corsInput)
-	if err != nil {
-		return getObsError("Error setting CORS rules of OBS bucket", bucket, err)
-	}
-	return nil
+	return rules
 }
 
 func resourceObsBucketUserDomainNamesUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
@@ -1134,7 +1232,22 @@ func waitForOBSEnterpriseProjectIdChanged(obsClient *obs.ObsClient, bucket strin
 
 func resourceObsBucketWebsitePut(obsClient *obs.ObsClient, d *schema.ResourceData, website map[string]interface{}) error {
 	bucket := d.Get("bucket").(string)
+	websiteConfiguration, err := buildObsBucketWebsiteConfiguration(bucket, website)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] set website configuration of OBS bucket %s: %#v", bucket, websiteConfiguration)
+	_, err = obsClient.SetBucketWebsiteConfiguration(websiteConfiguration)
+	if err != nil {
+		return getObsError("Error updating website configuration of OBS bucket", bucket, err)
+	}
+
+	return nil
+}
 
+func buildObsBucketWebsiteConfiguration(bucket string,
+	website map[string]interface{}) (*obs.SetBucketWebsiteConfigurationInput, error) {
 	var indexDocument, errorDocument, redirectAllRequestsTo, routingRules string
 	if v, ok := website["index_document"]; ok {
 		indexDocument = v.(string)
@@ -1150,7 +1263,7 @@ func resourceObsBucketWebsitePut(obsClient *obs.ObsClient, d *schema.ResourceDat
 	}
 
 	if indexDocument == "" && redirectAllRequestsTo == "" {
-		return fmt.Errorf("must specify either index_document or redirect_all_requests_to")
+		return nil, fmt.Errorf("must specify either index_document or redirect_all_requests_to")
 	}
 
 	websiteConfiguration := &obs.SetBucketWebsiteConfigurationInput{}
@@ -1190,18 +1303,11 @@ func resourceObsBucketWebsitePut(obsClient *obs.ObsClient, d *schema.ResourceDat
 	if routingRules != "" {
 		var unmarshalRules []obs.RoutingRule
 		if err := json.Unmarshal([]byte(routingRules), &unmarshalRules); err != nil {
-			return err
+			return nil, err
 		}
 		websiteConfiguration.RoutingRules = unmarshalRules
 	}
-
-	log.Printf("[DEBUG] set website configuration of OBS bucket %s: %#v", bucket, websiteConfiguration)
-	_, err := obsClient.SetBucketWebsiteConfiguration(websiteConfiguration)
-	if err != nil {
-		return getObsError("Error updating website configuration of OBS bucket", bucket, err)
-	}
-
-	return nil
+	return websiteConfiguration, nil
 }
 
 func resourceObsBucketWebsiteDelete(obsClient *obs.ObsClient, d *schema.ResourceData) error {
@@ -1423,6 +1529,16 @@ func setObsBucketLifecycleConfiguration(obsClient *obs.ObsClient, d *schema.Reso
 	rawRules := output.LifecycleRules
 	log.Printf("[DEBUG] getting original lifecycle configuration of OBS bucket %s, lifecycle: %#v", bucket, rawRules)
 
+	rules := flattenObsBucketLifecycleRules(rawRules)
+	log.Printf("[DEBUG] saving lifecycle configuration of OBS bucket %s, lifecycle: %#v", bucket, rules)
+	if err := d.Set("lifecycle_rule", rules); err != nil {
+		return fmt.Errorf("error saving lifecycle configuration of OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func flattenObsBucketLifecycleRules(rawRules []obs.LifecycleRule) []map[string]interface{} {
 	rules := make([]map[string]interface{}, 0, len(rawRules))
 	for _, lifecycleRule := range rawRules {
 		rule := make(map[string]interface{})
@@ -1485,13 +1601,7 @@ func setObsBucketLifecycleConfiguration(obsClient *obs.ObsClient, d *schema.Reso
 
 		rules = append(rules, rule)
 	}
-
-	log.Printf("[DEBUG] saving lifecycle configuration of OBS bucket %s, lifecycle: %#v", bucket, rules)
-	if err := d.Set("lifecycle_rule", rules); err != nil {
-		return fmt.Errorf("error saving lifecycle configuration of OBS bucket %s: %s", bucket, err)
-	}
-
-	return nil
+	return rules
 }
 
 func setObsBucketWebsiteConfiguration(obsClient *obs.ObsClient, d *schema.ResourceData) error {
@@ -1511,7 +1621,20 @@ func setObsBucketWebsiteConfiguration(obsClient *obs.ObsClient, d *schema.Resour
 	}
 
 	log.Printf("[DEBUG] getting original website configuration of OBS bucket %s, output: %#v", bucket, output.BucketWebsiteConfiguration)
-	var websites []map[string]interface{}
+	w, err := flattenObsBucketWebsiteConfiguration(output)
+	if err != nil {
+		return err
+	}
+
+	websites := []map[string]interface{}{w}
+	log.Printf("[DEBUG] saving website configuration of OBS bucket %s, website: %#v", bucket, websites)
+	if err := d.Set("website", websites); err != nil {
+		return fmt.Errorf("error saving website configuration of OBS bucket %s: %s", bucket, err)
+	}
+	return nil
+}
+
+func flattenObsBucketWebsiteConfiguration(output *obs.GetBucketWebsiteConfigurationOutput) (map[string]interface{}, error) {
 	w := make(map[string]interface{})
 
 	w["index_document"] = output.IndexDocument.Suffix
@@ -1545,17 +1668,11 @@ func setObsBucketWebsiteConfiguration(obsClient *obs.ObsClient, d *schema.Resour
 	if len(rawRules) > 0 {
 		rr, err := normalizeWebsiteRoutingRules(rawRules)
 		if err != nil {
-			return fmt.Errorf("error while marshaling website routing rules: %s", err)
+			return nil, fmt.Errorf("error while marshaling website routing rules: %s", err)
 		}
 		w["routing_rules"] = rr
 	}
-
-	websites = append(websites, w)
-	log.Printf("[DEBUG] saving website configuration of OBS bucket %s, website: %#v", bucket, websites)
-	if err := d.Set("website", websites); err != nil {
-		return fmt.Errorf("error saving website configuration of OBS bucket %s: %s", bucket, err)
-	}
-	return nil
+	return w, nil
 }
 
 func setObsBucketCorsRules(obsClient *obs.ObsClient, d *schema.ResourceData) error {
@@ -1577,6 +1694,16 @@ func setObsBucketCorsRules(obsClient *obs.ObsClient, d *schema.ResourceData) err
 	corsRules := output.CorsRules
 	log.Printf("[DEBUG] getting original CORS rules of OBS bucket %s, CORS: %#v", bucket, corsRules)
 
+	rules := flattenObsBucketCorsRules(corsRules)
+	log.Printf("[DEBUG] saving CORS rules of OBS bucket %s, CORS: %#v", bucket, rules)
+	if err := d.Set("cors_rule", rules); err != nil {
+		return fmt.Errorf("error saving CORS rules of OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func flattenObsBucketCorsRules(corsRules []obs.CorsRule) []map[string]interface{} {
 	rules := make([]map[string]interface{}, 0, len(corsRules))
 	for _, ruleObject := range corsRules {
 		rule := make(map[string]interface{})
@@ -1592,13 +1719,7 @@ func setObsBucketCorsRules(obsClient *obs.ObsClient, d *schema.ResourceData) err
 
 		rules = append(rules, rule)
 	}
-
-	log.Printf("[DEBUG] saving CORS rules of OBS bucket %s, CORS: %#v", bucket, rules)
-	if err := d.Set("cors_rule", rules); err != nil {
-		return fmt.Errorf("error saving CORS rules of OBS bucket %s: %s", bucket, err)
-	}
-
-	return nil
+	return rules
 }
 
 func setObsBucketTags(obsClient *obs.ObsClient, d *schema.ResourceData) error {
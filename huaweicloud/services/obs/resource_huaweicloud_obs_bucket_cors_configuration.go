@@ -0,0 +1,159 @@
+package obs
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// @API OBS PUT ?cors
+// @API OBS GET ?cors
+// @API OBS DELETE ?cors
+func ResourceObsBucketCorsConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketCorsConfigurationCreate,
+		UpdateContext: resourceObsBucketCorsConfigurationUpdate,
+		ReadContext:   resourceObsBucketCorsConfigurationRead,
+		DeleteContext: resourceObsBucketCorsConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     corsRuleSchema(),
+			},
+		},
+	}
+}
+
+func putObsBucketCorsRules(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	opts := &obs.SetBucketCorsInput{}
+	opts.Bucket = bucket
+	opts.CorsRules = buildObsBucketCorsRules(d.Get("rule").([]interface{}))
+	log.Printf("[DEBUG] set CORS configurations of OBS bucket %s: %#v", bucket, opts)
+
+	_, err := obsClient.SetBucketCors(opts)
+	if err != nil {
+		return getObsError("Error setting CORS rules of OBS bucket", bucket, err)
+	}
+	return nil
+}
+
+func resourceObsBucketCorsConfigurationCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	// The CORS rules of the bucket are replaced as a whole, make sure they are not managed by others.
+	bucket := d.Get("bucket").(string)
+	_, err = obsClient.GetBucketCors(bucket)
+	if err == nil {
+		return diag.FromErr(bucketConfigurationConflictError(bucket, "CORS configuration", "cors_rule"))
+	}
+	if !isObsNotFoundError(err) {
+		return diag.FromErr(getObsError("Error getting CORS configuration of OBS bucket", bucket, err))
+	}
+
+	if err := putObsBucketCorsRules(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketCorsConfigurationRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketCors(d.Id())
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket CORS configuration")
+		}
+		return diag.FromErr(getObsError("Error getting CORS configuration of OBS bucket", d.Id(), err))
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("rule", flattenObsBucketCorsRules(output.CorsRules)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket CORS configuration fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketCorsConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	if err := putObsBucketCorsRules(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketCorsConfigurationDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] delete CORS rules of OBS bucket: %s", bucket)
+	_, err = obsClient.DeleteBucketCors(bucket)
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket CORS configuration")
+		}
+		return diag.FromErr(getObsError("Error deleting CORS rules of OBS bucket", bucket, err))
+	}
+	return nil
+}
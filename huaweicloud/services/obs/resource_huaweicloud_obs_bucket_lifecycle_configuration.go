@@ -0,0 +1,159 @@
+package obs
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// @API OBS PUT ?lifecycle
+// @API OBS GET ?lifecycle
+// @API OBS DELETE ?lifecycle
+func ResourceObsBucketLifecycleConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketLifecycleConfigurationCreate,
+		UpdateContext: resourceObsBucketLifecycleConfigurationUpdate,
+		ReadContext:   resourceObsBucketLifecycleConfigurationRead,
+		DeleteContext: resourceObsBucketLifecycleConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     lifecycleRuleSchema(),
+			},
+		},
+	}
+}
+
+func putObsBucketLifecycleRules(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	opts := &obs.SetBucketLifecycleConfigurationInput{}
+	opts.Bucket = bucket
+	opts.LifecycleRules = buildObsBucketLifecycleRules(d.Get("rule").([]interface{}))
+	log.Printf("[DEBUG] set lifecycle configurations of OBS bucket %s: %#v", bucket, opts)
+
+	_, err := obsClient.SetBucketLifecycleConfiguration(opts)
+	if err != nil {
+		return getObsError("Error setting lifecycle rules of OBS bucket", bucket, err)
+	}
+	return nil
+}
+
+func resourceObsBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	// The lifecycle rules of the bucket are replaced as a whole, make sure they are not managed by others.
+	bucket := d.Get("bucket").(string)
+	_, err = obsClient.GetBucketLifecycleConfiguration(bucket)
+	if err == nil {
+		return diag.FromErr(bucketConfigurationConflictError(bucket, "lifecycle configuration", "lifecycle_rule"))
+	}
+	if !isObsNotFoundError(err) {
+		return diag.FromErr(getObsError("Error getting lifecycle configuration of OBS bucket", bucket, err))
+	}
+
+	if err := putObsBucketLifecycleRules(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketLifecycleConfigurationRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketLifecycleConfiguration(d.Id())
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket lifecycle configuration")
+		}
+		return diag.FromErr(getObsError("Error getting lifecycle configuration of OBS bucket", d.Id(), err))
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("rule", flattenObsBucketLifecycleRules(output.LifecycleRules)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket lifecycle configuration fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	if err := putObsBucketLifecycleRules(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketLifecycleConfigurationDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] remove all lifecycle rules of bucket %s", bucket)
+	_, err = obsClient.DeleteBucketLifecycleConfiguration(bucket)
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket lifecycle configuration")
+		}
+		return diag.FromErr(getObsError("Error deleting lifecycle rules of OBS bucket", bucket, err))
+	}
+	return nil
+}
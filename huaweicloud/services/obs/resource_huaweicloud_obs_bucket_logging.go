@@ -0,0 +1,177 @@
+package obs
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// @API OBS PUT ?logging
+// @API OBS GET ?logging
+func ResourceObsBucketLogging() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketLoggingConfigurationCreate,
+		UpdateContext: resourceObsBucketLoggingConfigurationUpdate,
+		ReadContext:   resourceObsBucketLoggingConfigurationRead,
+		DeleteContext: resourceObsBucketLoggingConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target_bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"agency": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"target_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "logs/",
+			},
+		},
+	}
+}
+
+func putObsBucketLogging(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	loggingStatus := buildObsBucketLoggingInput(bucket, map[string]interface{}{
+		"target_bucket": d.Get("target_bucket"),
+		"target_prefix": d.Get("target_prefix"),
+		"agency":        d.Get("agency"),
+	})
+	log.Printf("[DEBUG] set logging of OBS bucket %s: %#v", bucket, loggingStatus)
+
+	_, err := obsClient.SetBucketLoggingConfiguration(loggingStatus)
+	if err != nil {
+		return getObsError("Error setting logging configuration of OBS bucket", bucket, err)
+	}
+	return nil
+}
+
+func resourceObsBucketLoggingConfigurationCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	// The logging configuration of the bucket is replaced as a whole, make sure it is not managed by others.
+	bucket := d.Get("bucket").(string)
+	output, err := obsClient.GetBucketLoggingConfiguration(bucket)
+	if err != nil {
+		return diag.FromErr(getObsError("Error getting logging configuration of OBS bucket", bucket, err))
+	}
+	if output.TargetBucket != "" {
+		return diag.FromErr(bucketConfigurationConflictError(bucket, "logging configuration", "logging"))
+	}
+
+	if err := putObsBucketLogging(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketLoggingConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketLoggingConfigurationRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketLoggingConfiguration(d.Id())
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket logging")
+		}
+		return diag.FromErr(getObsError("Error getting logging configuration of OBS bucket", d.Id(), err))
+	}
+	if output.TargetBucket == "" {
+		// The logging of the bucket is disabled
+		log.Printf("[WARN] the logging of OBS bucket %s is disabled, remove it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("target_bucket", output.TargetBucket),
+		d.Set("target_prefix", output.TargetPrefix),
+		d.Set("agency", output.Agency),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket logging fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketLoggingConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	if err := putObsBucketLogging(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketLoggingConfigurationRead(ctx, d, meta)
+}
+
+// resourceObsBucketLoggingConfigurationDelete disables the logging of the bucket by an empty logging configuration.
+func resourceObsBucketLoggingConfigurationDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] disable logging of OBS bucket %s", bucket)
+	_, err = obsClient.SetBucketLoggingConfiguration(buildObsBucketLoggingInput(bucket, nil))
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket logging")
+		}
+		return diag.FromErr(getObsError("Error deleting logging configuration of OBS bucket", bucket, err))
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package obs
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// bucketNotificationConfiguration is the notification configuration of the bucket, the SDK only supports the SMN
+// topics, so the configuration is sent and received as the raw sub-resource to manage the FunctionGraph functions.
+type bucketNotificationConfiguration struct {
+	XMLName                     xml.Name                    `xml:"NotificationConfiguration"`
+	TopicConfigurations         []notificationConfiguration `xml:"TopicConfiguration"`
+	FunctionGraphConfigurations []notificationConfiguration `xml:"FunctionGraphConfiguration"`
+}
+
+type notificationConfiguration struct {
+	ID            string           `xml:"Id,omitempty"`
+	Topic         string           `xml:"Topic,omitempty"`
+	FunctionGraph string           `xml:"FunctionGraph,omitempty"`
+	Events        []string         `xml:"Event"`
+	FilterRules   []obs.FilterRule `xml:"Filter>Object>FilterRule"`
+}
+
+var notificationEvents = []string{
+	string(obs.ObjectCreatedAll), string(obs.ObjectCreatedPut), string(obs.ObjectCreatedPost),
+	string(obs.ObjectCreatedCopy), string(obs.ObjectCreatedCompleteMultipartUpload),
+	string(obs.ObjectRemovedAll), string(obs.ObjectRemovedDelete), string(obs.ObjectRemovedDeleteMarkerCreated),
+}
+
+// @API OBS PUT ?notification
+// @API OBS GET ?notification
+func ResourceObsBucketNotification() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketNotificationCreate,
+		UpdateContext: resourceObsBucketNotificationCreate,
+		ReadContext:   resourceObsBucketNotificationRead,
+		DeleteContext: resourceObsBucketNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"topic_configuration": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         notificationConfigurationSchema("topic_urn"),
+				AtLeastOneOf: []string{"topic_configuration", "function_configuration"},
+			},
+			"function_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     notificationConfigurationSchema("function_urn"),
+			},
+		},
+	}
+}
+
+// notificationConfigurationSchema returns the schema of the notification configuration, the target key is the
+// argument of the URN which the events are sent to.
+func notificationConfigurationSchema(targetKey string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			targetKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(notificationEvents, false),
+				},
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func buildNotificationConfigurations(rawArray []interface{}, targetKey string) []notificationConfiguration {
+	configurations := make([]notificationConfiguration, 0, len(rawArray))
+	for _, raw := range rawArray {
+		rawMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		configuration := notificationConfiguration{
+			ID: rawMap["id"].(string),
+		}
+		if targetKey == "topic_urn" {
+			configuration.Topic = rawMap[targetKey].(string)
+		} else {
+			configuration.FunctionGraph = rawMap[targetKey].(string)
+		}
+		for _, event := range rawMap["events"].([]interface{}) {
+			configuration.Events = append(configuration.Events, event.(string))
+		}
+		for _, name := range []string{"prefix", "suffix"} {
+			if v := rawMap[name].(string); v != "" {
+				configuration.FilterRules = append(configuration.FilterRules, obs.FilterRule{Name: name, Value: v})
+			}
+		}
+		configurations = append(configurations, configuration)
+	}
+	return configurations
+}
+
+func getBucketNotification(obsClient *obs.ObsClient, bucket string) (*bucketNotificationConfiguration, error) {
+	data, err := getSubResource(obsClient, bucket, "", obs.SubResourceNotification)
+	if err != nil {
+		return nil, err
+	}
+	var configuration bucketNotificationConfiguration
+	if err := xml.Unmarshal(data, &configuration); err != nil {
+		return nil, fmt.Errorf("error parsing the notification configuration: %s", err)
+	}
+	return &configuration, nil
+}
+
+func resourceObsBucketNotificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	configuration := bucketNotificationConfiguration{
+		TopicConfigurations: buildNotificationConfigurations(d.Get("topic_configuration").([]interface{}),
+			"topic_urn"),
+		FunctionGraphConfigurations: buildNotificationConfigurations(d.Get("function_configuration").([]interface{}),
+			"function_urn"),
+	}
+	data, err := xml.Marshal(configuration)
+	if err != nil {
+		return diag.Errorf("error building notification configuration of OBS bucket %s: %s", bucket, err)
+	}
+	log.Printf("[DEBUG] set notification configuration of OBS bucket %s: %s", bucket, data)
+	if err := putSubResource(obsClient, bucket, "", obs.SubResourceNotification, data); err != nil {
+		return diag.FromErr(getObsError("Error setting notification configuration of OBS bucket", bucket, err))
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketNotificationRead(ctx, d, meta)
+}
+
+func flattenNotificationConfigurations(configurations []notificationConfiguration,
+	targetKey string) []map[string]interface{} {
+	if len(configurations) == 0 {
+		return nil
+	}
+
+	result := make([]map[string]interface{}, len(configurations))
+	for i, configuration := range configurations {
+		target := configuration.Topic
+		if targetKey == "function_urn" {
+			target = configuration.FunctionGraph
+		}
+		result[i] = map[string]interface{}{
+			targetKey: target,
+			"events":  configuration.Events,
+			"id":      configuration.ID,
+		}
+		for _, rule := range configuration.FilterRules {
+			if rule.Name == "prefix" || rule.Name == "suffix" {
+				result[i][rule.Name] = rule.Value
+			}
+		}
+	}
+	return result
+}
+
+func resourceObsBucketNotificationRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	configuration, err := getBucketNotification(obsClient, d.Id())
+	if err != nil {
+		if obsErr, ok := err.(obs.ObsError); ok && obsErr.StatusCode == 404 {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket notification")
+		}
+		return diag.FromErr(getObsError("Error retrieving notification configuration of OBS bucket", d.Id(), err))
+	}
+	if len(configuration.TopicConfigurations) == 0 && len(configuration.FunctionGraphConfigurations) == 0 {
+		// The bucket does not have notification configurations
+		log.Printf("[WARN] the notification configuration of OBS bucket %s is empty, remove it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("topic_configuration", flattenNotificationConfigurations(configuration.TopicConfigurations,
+			"topic_urn")),
+		d.Set("function_configuration", flattenNotificationConfigurations(configuration.FunctionGraphConfigurations,
+			"function_urn")),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket notification fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketNotificationDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] delete notification configuration of OBS bucket %s", bucket)
+	_, err = obsClient.SetBucketNotification(&obs.SetBucketNotificationInput{Bucket: bucket})
+	if err != nil {
+		return diag.FromErr(getObsError("Error deleting notification configuration of OBS bucket", bucket, err))
+	}
+	return nil
+}
@@ -3,29 +3,40 @@ package obs
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk/openstack/obs"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 // @API OBS HEAD /
 // @API OBS HEAD /{ObjectName}
 // @API OBS PUT /{ObjectName}
+// @API OBS PUT /{ObjectName}?retention
+// @API OBS POST /{ObjectName}?uploads
+// @API OBS PUT /{ObjectName}?partNumber={partNumber}&uploadId={uploadId}
+// @API OBS POST /{ObjectName}?uploadId={uploadId}
+// @API OBS DELETE /{ObjectName}?uploadId={uploadId}
 // @API OBS DELETE /{ObjectName}
 func ResourceObsBucketObject() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceObsBucketObjectPut,
 		ReadContext:   resourceObsBucketObjectRead,
-		UpdateContext: resourceObsBucketObjectPut,
+		UpdateContext: resourceObsBucketObjectUpdate,
 		DeleteContext: resourceObsBucketObjectDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceObsBucketObjectImport,
@@ -88,12 +99,74 @@ func ResourceObsBucketObject() *schema.Resource {
 				Computed: true,
 			},
 
+			"object_lock_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{objectLockModeCompliance}, false),
+				RequiredWith: []string{"object_lock_retain_until_date"},
+			},
+
+			"object_lock_retain_until_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: utils.SuppressEquivilentTimeDiffs,
+				RequiredWith:     []string{"object_lock_mode"},
+			},
+
+			"source_hash": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"part_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 5120),
+			},
+
+			"concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"cache_control": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"content_disposition": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"content_encoding": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"expires": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"etag": {
 				Type: schema.TypeString,
-				// This will conflict with server-side-encryption and multi-part upload
-				// if/when it's actually implemented. The Etag then won't match raw-file MD5.
-				Optional: true,
-				Computed: true,
+				// This will conflict with server-side-encryption and multi-part upload.
+				// The Etag then won't match raw-file MD5, use source_hash instead.
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressMultipartEtagDiffs,
 			},
 
 			"version_id": {
@@ -110,9 +183,6 @@ func ResourceObsBucketObject() *schema.Resource {
 }
 
 func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var resp *obs.PutObjectOutput
-	var err error
-
 	conf := meta.(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
 	if err != nil {
@@ -129,11 +199,12 @@ func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error reading OBS bucket %s: %s", bucket, err)
 	}
 
+	var versionId string
 	source := d.Get("source").(string)
 	content := d.Get("content").(string)
 	if source != "" {
 		// check source file whether exist
-		_, err = os.Stat(source)
+		fileInfo, err := os.Stat(source)
 		if err != nil {
 			if os.IsNotExist(err) {
 				return diag.Errorf("source file %s is not exist", source)
@@ -142,98 +213,259 @@ func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, met
 		}
 
 		// put source file
-		resp, err = putFileToObject(obsClient, d)
+		versionId, err = putFileToObject(obsClient, d, fileInfo.Size())
+		if err != nil {
+			return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+		}
 	}
 
 	if content != "" {
 		// put content
-		resp, err = putContentToObject(obsClient, d)
+		versionId, err = putContentToObject(obsClient, d)
+		if err != nil {
+			return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+		}
 	}
 
-	if err != nil {
-		return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+	log.Printf("[DEBUG] the version ID of %s in OBS Bucket %s: %s", key, bucket, versionId)
+	if versionId == "null" {
+		versionId = ""
 	}
-	if resp == nil {
-		return diag.Errorf("putting object to OBS bucket %s without null response", bucket)
+	if err := d.Set("version_id", versionId); err != nil {
+		return diag.Errorf("error saving versionId of OBS bucket %s: %s", bucket, err)
 	}
+	d.SetId(key)
 
-	log.Printf("[DEBUG] Response of putting %s to OBS Bucket %s: %#v", key, bucket, resp)
-	mErr := &multierror.Error{}
-	if resp.VersionId != "null" {
-		mErr = multierror.Append(mErr, d.Set("version_id", resp.VersionId))
-	} else {
-		mErr = multierror.Append(mErr, d.Set("version_id", ""))
+	if _, ok := d.GetOk("object_lock_mode"); ok {
+		if err := putObjectRetention(conf, d); err != nil {
+			return diag.FromErr(getObsError("Error setting WORM retention of object in OBS bucket", bucket, err))
+		}
 	}
-	if mErr.ErrorOrNil() != nil {
-		return diag.Errorf("error saving versionId of OBS bucket %s: %s", bucket, mErr)
+
+	return resourceObsBucketObjectRead(ctx, d, meta)
+}
+
+// maxObjectPartCount is the maximum number of the parts of a multipart upload.
+const maxObjectPartCount = 10000
+
+// objectRetentionKeys are the arguments which are updated by the retention API without uploading the object again.
+var objectRetentionKeys = []string{"object_lock_mode", "object_lock_retain_until_date"}
+
+// objectUploadOptionKeys are the options of the upload, which take effect in the next upload only.
+var objectUploadOptionKeys = []string{"part_size", "concurrency"}
+
+func resourceObsBucketObjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChangesExcept(append(objectRetentionKeys, objectUploadOptionKeys...)...) {
+		return resourceObsBucketObjectPut(ctx, d, meta)
 	}
-	d.SetId(key)
 
+	conf := meta.(*config.Config)
+	if _, ok := d.GetOk("object_lock_mode"); ok && d.HasChanges(objectRetentionKeys...) {
+		if err := putObjectRetention(conf, d); err != nil {
+			return diag.FromErr(getObsError("Error setting WORM retention of object in OBS bucket", d.Get("bucket").(string),
+				err))
+		}
+	}
 	return resourceObsBucketObjectRead(ctx, d, meta)
 }
 
-func putContentToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (*obs.PutObjectOutput, error) {
-	bucket := d.Get("bucket").(string)
-	key := d.Get("key").(string)
-	content := d.Get("content").(string)
+// putObjectRetention sets the WORM retention of the uploaded object, the retention of the objects can only be
+// extended, and the object can not be deleted or overwritten until the retention expires.
+func putObjectRetention(conf *config.Config, d *schema.ResourceData) error {
+	obsClient, err := conf.ObjectStorageClientWithSignature(conf.GetRegion(d))
+	if err != nil {
+		return fmt.Errorf("error creating OBS client: %s", err)
+	}
 
-	putInput := &obs.PutObjectInput{}
-	putInput.Bucket = bucket
-	putInput.Key = key
+	// The format has been checked by the schema.
+	retainUntilDate, _ := time.Parse(time.RFC3339, d.Get("object_lock_retain_until_date").(string))
+	data, err := xml.Marshal(objectRetention{
+		Mode:            d.Get("object_lock_mode").(string),
+		RetainUntilDate: retainUntilDate.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	return putSubResource(obsClient, d.Get("bucket").(string), d.Get("key").(string), subResourceRetention, data)
+}
+
+// suppressMultipartEtagDiffs suppresses the differences if the ETag of the object is in the format of the multipart
+// uploads, e.g. "<md5>-<part count>". The changes of such object are detected by the source_hash only.
+func suppressMultipartEtagDiffs(_, old, new string, _ *schema.ResourceData) bool {
+	return new != "" && strings.Contains(old, "-")
+}
+
+func buildObjectOperationInput(d *schema.ResourceData) obs.ObjectOperationInput {
+	input := obs.ObjectOperationInput{
+		Bucket:   d.Get("bucket").(string),
+		Key:      d.Get("key").(string),
+		Metadata: utils.ExpandToStringMap(d.Get("metadata").(map[string]interface{})),
+		HttpHeader: obs.HttpHeader{
+			CacheControl:       d.Get("cache_control").(string),
+			ContentDisposition: d.Get("content_disposition").(string),
+			ContentEncoding:    d.Get("content_encoding").(string),
+			ContentType:        d.Get("content_type").(string),
+			HttpExpires:        d.Get("expires").(string),
+		},
+	}
 
 	if v, ok := d.GetOk("acl"); ok {
-		putInput.ACL = obs.AclType(v.(string))
+		input.ACL = obs.AclType(v.(string))
 	}
 	if v, ok := d.GetOk("storage_class"); ok {
-		putInput.StorageClass = obs.StorageClassType(v.(string))
+		input.StorageClass = obs.StorageClassType(v.(string))
 	}
-	if v, ok := d.GetOk("content_type"); ok {
-		putInput.ContentType = v.(string)
-	}
-
-	var sseKmsHeader = obs.SseKmsHeader{}
 	if d.Get("encryption").(bool) {
-		sseKmsHeader.Encryption = obs.DEFAULT_SSE_KMS_ENCRYPTION
-		sseKmsHeader.Key = d.Get("kms_key_id").(string)
-		putInput.SseHeader = sseKmsHeader
+		input.SseHeader = obs.SseKmsHeader{
+			Encryption: obs.DEFAULT_SSE_KMS_ENCRYPTION,
+			Key:        d.Get("kms_key_id").(string),
+		}
 	}
+	return input
+}
 
-	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", key, bucket, putInput)
+func putContentToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (string, error) {
+	putInput := &obs.PutObjectInput{}
+	putInput.ObjectOperationInput = buildObjectOperationInput(d)
+
+	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", putInput.Key, putInput.Bucket, putInput)
 	// do not log content
-	body := bytes.NewReader([]byte(content))
-	putInput.Body = body
+	putInput.Body = bytes.NewReader([]byte(d.Get("content").(string)))
 
-	return obsClient.PutObject(putInput)
+	resp, err := obsClient.PutObject(putInput)
+	if err != nil {
+		return "", err
+	}
+	return resp.VersionId, nil
 }
 
-func putFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (*obs.PutObjectOutput, error) {
-	bucket := d.Get("bucket").(string)
-	key := d.Get("key").(string)
+func putFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData, fileSize int64) (string, error) {
+	// The large files are uploaded by parts in parallel if the part size is specified.
+	partSize := int64(d.Get("part_size").(int)) * 1024 * 1024
+	if partSize > 0 && fileSize > partSize {
+		return uploadFileToObject(obsClient, d, fileSize, partSize)
+	}
 
 	putInput := &obs.PutFileInput{}
-	putInput.Bucket = bucket
-	putInput.Key = key
+	putInput.ObjectOperationInput = buildObjectOperationInput(d)
 	putInput.SourceFile = d.Get("source").(string)
 
-	if v, ok := d.GetOk("acl"); ok {
-		putInput.ACL = obs.AclType(v.(string))
+	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", putInput.Key, putInput.Bucket, putInput)
+	resp, err := obsClient.PutFile(putInput)
+	if err != nil {
+		return "", err
 	}
-	if v, ok := d.GetOk("storage_class"); ok {
-		putInput.StorageClass = obs.StorageClassType(v.(string))
+	return resp.VersionId, nil
+}
+
+// uploadFileToObject uploads the file by parts in parallel. The UploadFile of the SDK does not send the HTTP headers
+// of the object, so the multipart upload is initiated with them, and it is aborted if any part fails.
+func uploadFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData, fileSize, partSize int64) (string, error) {
+	input := buildObjectOperationInput(d)
+	partCount := int((fileSize + partSize - 1) / partSize)
+	if partCount > maxObjectPartCount {
+		return "", fmt.Errorf("the file is uploaded by %d parts, which exceeds the limit %d, please increase the part_size",
+			partCount, maxObjectPartCount)
 	}
-	if v, ok := d.GetOk("content_type"); ok {
-		putInput.ContentType = v.(string)
+
+	log.Printf("[DEBUG] uploading %s to OBS Bucket %s by %d parts, opts: %#v", input.Key, input.Bucket, partCount,
+		input)
+	initInput := &obs.InitiateMultipartUploadInput{
+		ObjectOperationInput: input,
+		ContentType:          input.ContentType,
+	}
+	initOutput, err := obsClient.InitiateMultipartUpload(initInput,
+		obs.WithCustomHeader(obs.HEADER_CACHE_CONTROL_CAMEL, input.CacheControl),
+		obs.WithCustomHeader(obs.HEADER_CONTENT_DISPOSITION_CAMEL, input.ContentDisposition),
+		obs.WithCustomHeader(obs.HEADER_CONTENT_ENCODING_CAMEL, input.ContentEncoding),
+		obs.WithCustomHeader(obs.HEADER_EXPIRES_CAMEL, input.HttpExpires),
+	)
+	if err != nil {
+		return "", err
 	}
 
-	var sseKmsHeader = obs.SseKmsHeader{}
-	if d.Get("encryption").(bool) {
-		sseKmsHeader.Encryption = obs.DEFAULT_SSE_KMS_ENCRYPTION
-		sseKmsHeader.Key = d.Get("kms_key_id").(string)
-		putInput.SseHeader = sseKmsHeader
+	uploadID := initOutput.UploadId
+	// the options of the parts are built before uploading, the resource data is not accessed in parallel
+	partInput := obs.UploadPartInput{
+		Bucket:     input.Bucket,
+		Key:        input.Key,
+		UploadId:   uploadID,
+		SourceFile: d.Get("source").(string),
+		PartSize:   partSize,
+	}
+	parts, err := uploadObjectParts(obsClient, partInput, partCount, d.Get("concurrency").(int))
+	if err == nil {
+		var output *obs.CompleteMultipartUploadOutput
+		output, err = obsClient.CompleteMultipartUpload(&obs.CompleteMultipartUploadInput{
+			Bucket:   input.Bucket,
+			Key:      input.Key,
+			UploadId: uploadID,
+			Parts:    parts,
+		})
+		if err == nil {
+			return output.VersionId, nil
+		}
+	}
+
+	_, abortErr := obsClient.AbortMultipartUpload(&obs.AbortMultipartUploadInput{
+		Bucket:   input.Bucket,
+		Key:      input.Key,
+		UploadId: uploadID,
+	})
+	if abortErr != nil {
+		log.Printf("[WARN] error aborting the multipart upload (%s) of %s: %s", uploadID, input.Key, abortErr)
+	}
+	return "", err
+}
+
+// uploadObjectParts uploads the parts of the source file by the workers, the first error stops the upload. The
+// partInput is the options shared by all the parts, the part number and the offset of each part are set by the workers.
+func uploadObjectParts(obsClient *obs.ObsClient, partInput obs.UploadPartInput, partCount,
+	concurrency int) ([]obs.Part, error) {
+	var (
+		parts    = make([]obs.Part, partCount)
+		partNums = make(chan int, partCount)
+		errs     = make(chan error, concurrency)
+		stop     = make(chan struct{})
+		stopOnce sync.Once
+		wg       sync.WaitGroup
+	)
+	for i := 1; i <= partCount; i++ {
+		partNums <- i
+	}
+	close(partNums)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partNum := range partNums {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				input := partInput
+				input.PartNumber = partNum
+				input.Offset = int64(partNum-1) * input.PartSize
+				output, err := obsClient.UploadPart(&input)
+				if err != nil {
+					errs <- fmt.Errorf("error uploading part %d: %s", partNum, err)
+					stopOnce.Do(func() { close(stop) })
+					return
+				}
+				parts[partNum-1] = obs.Part{PartNumber: partNum, ETag: output.ETag}
+			}
+		}()
 	}
+	wg.Wait()
+	close(errs)
 
-	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", key, bucket, putInput)
-	return obsClient.PutFile(putInput)
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return parts, nil
 }
 
 func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -274,8 +506,15 @@ func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta
 
 	mErr := multierror.Append(
 		d.Set("region", region),
+		d.Set("object_lock_mode", flattenObjectResponseHeader(objectMeta.ResponseHeaders, "object-lock-mode")),
+		d.Set("object_lock_retain_until_date", flattenObjectRetainUntilDate(objectMeta.ResponseHeaders)),
 		d.Set("storage_class", class),
 		d.Set("content_type", objectMeta.ContentType),
+		d.Set("metadata", objectMeta.Metadata),
+		d.Set("cache_control", objectMeta.CacheControl),
+		d.Set("content_disposition", objectMeta.ContentDisposition),
+		d.Set("content_encoding", objectMeta.ContentEncoding),
+		d.Set("expires", objectMeta.HttpExpires),
 		d.Set("version_id", objectMeta.VersionId),
 		d.Set("size", objectMeta.ContentLength),
 		d.Set("etag", strings.Trim(objectMeta.ETag, `"`)),
@@ -288,6 +527,29 @@ func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+func flattenObjectResponseHeader(headers map[string][]string, key string) string {
+	if values := headers[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// flattenObjectRetainUntilDate returns the retention expiration time of the object in RFC3339 format, the time is
+// returned as either the ISO8601 time or the timestamp in milliseconds.
+func flattenObjectRetainUntilDate(headers map[string][]string) string {
+	value := flattenObjectResponseHeader(headers, "object-lock-retain-until-date")
+	if value == "" {
+		return ""
+	}
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
+	}
+	if retainUntilDate, err := time.Parse(time.RFC3339, value); err == nil {
+		return retainUntilDate.UTC().Format(time.RFC3339)
+	}
+	return value
+}
+
 func resourceObsBucketObjectDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conf := meta.(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
@@ -0,0 +1,216 @@
+package obs
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const objectLockModeCompliance = "COMPLIANCE"
+
+// objectLockConfiguration is the WORM configuration of the bucket, the default retention is applied to the objects
+// which are uploaded without the retention settings.
+type objectLockConfiguration struct {
+	XMLName           xml.Name          `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string            `xml:"ObjectLockEnabled"`
+	DefaultRetention  *defaultRetention `xml:"Rule>DefaultRetention,omitempty"`
+}
+
+type defaultRetention struct {
+	Mode  string `xml:"Mode"`
+	Days  int    `xml:"Days,omitempty"`
+	Years int    `xml:"Years,omitempty"`
+}
+
+// objectRetention is the WORM retention of the object, the retention expiration time is the timestamp in milliseconds.
+type objectRetention struct {
+	XMLName         xml.Name `xml:"Retention"`
+	Mode            string   `xml:"Mode"`
+	RetainUntilDate int64    `xml:"RetainUntilDate"`
+}
+
+// @API OBS PUT ?object-lock
+// @API OBS GET ?object-lock
+func ResourceObsBucketObjectLockConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketObjectLockConfigurationPut,
+		UpdateContext: resourceObsBucketObjectLockConfigurationPut,
+		ReadContext:   resourceObsBucketObjectLockConfigurationRead,
+		DeleteContext: resourceObsBucketObjectLockConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{objectLockModeCompliance}, false),
+			},
+			"days": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntBetween(1, 36500),
+				ConflictsWith: []string{"years"},
+				RequiredWith:  []string{"mode"},
+			},
+			"years": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+				RequiredWith: []string{"mode"},
+			},
+		},
+	}
+}
+
+func buildObjectLockConfiguration(d *schema.ResourceData) (*objectLockConfiguration, error) {
+	configuration := objectLockConfiguration{
+		ObjectLockEnabled: "Enabled",
+	}
+	mode := d.Get("mode").(string)
+	if mode == "" {
+		return &configuration, nil
+	}
+
+	days := d.Get("days").(int)
+	years := d.Get("years").(int)
+	if days == 0 && years == 0 {
+		return nil, fmt.Errorf("one of days and years must be specified with the default retention mode")
+	}
+	configuration.DefaultRetention = &defaultRetention{
+		Mode:  mode,
+		Days:  days,
+		Years: years,
+	}
+	return &configuration, nil
+}
+
+func setObjectLockConfiguration(obsClient *obs.ObsClient, bucket string, configuration *objectLockConfiguration) error {
+	data, err := xml.Marshal(configuration)
+	if err != nil {
+		return fmt.Errorf("error building WORM configuration of OBS bucket %s: %s", bucket, err)
+	}
+	log.Printf("[DEBUG] set WORM configuration of OBS bucket %s: %s", bucket, data)
+	return putSubResource(obsClient, bucket, "", subResourceObjectLock, data)
+}
+
+func resourceObsBucketObjectLockConfigurationPut(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	configuration, err := buildObjectLockConfiguration(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setObjectLockConfiguration(obsClient, bucket, configuration); err != nil {
+		return diag.FromErr(getObsError("Error setting WORM configuration of OBS bucket", bucket, err))
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketObjectLockConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketObjectLockConfigurationRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	data, err := getSubResource(obsClient, d.Id(), "", subResourceObjectLock)
+	if err != nil {
+		if obsErr, ok := err.(obs.ObsError); ok && obsErr.StatusCode == 404 {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket WORM configuration")
+		}
+		return diag.FromErr(getObsError("Error retrieving WORM configuration of OBS bucket", d.Id(), err))
+	}
+
+	var configuration objectLockConfiguration
+	if err := xml.Unmarshal(data, &configuration); err != nil {
+		return diag.Errorf("error parsing WORM configuration of OBS bucket %s: %s", d.Id(), err)
+	}
+	if configuration.ObjectLockEnabled != "Enabled" {
+		log.Printf("[WARN] the WORM of OBS bucket %s is not enabled, remove it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	retention := defaultRetention{}
+	if configuration.DefaultRetention != nil {
+		retention = *configuration.DefaultRetention
+	}
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("mode", retention.Mode),
+		d.Set("days", retention.Days),
+		d.Set("years", retention.Years),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket WORM configuration fields: %s", err)
+	}
+	return nil
+}
+
+// resourceObsBucketObjectLockConfigurationDelete removes the default retention only, the WORM of the bucket can not
+// be disabled once it is enabled, and the retention of the existing objects is not changed.
+func resourceObsBucketObjectLockConfigurationDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClientWithSignature(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] delete the default retention of OBS bucket %s", bucket)
+	err = setObjectLockConfiguration(obsClient, bucket, &objectLockConfiguration{ObjectLockEnabled: "Enabled"})
+	if err != nil {
+		return diag.FromErr(getObsError("Error deleting WORM configuration of OBS bucket", bucket, err))
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The WORM of the bucket is still enabled",
+			Detail: fmt.Sprintf("the default retention of OBS bucket %s is removed, but the WORM can not be "+
+				"disabled and the retention of the existing objects is not changed", bucket),
+		},
+	}
+}
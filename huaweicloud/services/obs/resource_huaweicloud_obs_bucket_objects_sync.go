@@ -0,0 +1,480 @@
+package obs
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// The maximum number of the objects deleted by one request.
+const deleteObjectsBatchSize = 1000
+
+// @API OBS GET /
+// @API OBS HEAD /
+// @API OBS PUT /{ObjectName}
+// @API OBS POST /?delete
+func ResourceObsBucketObjectsSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketObjectsSyncCreate,
+		ReadContext:   resourceObsBucketObjectsSyncRead,
+		UpdateContext: resourceObsBucketObjectsSyncUpdate,
+		DeleteContext: resourceObsBucketObjectsSyncDelete,
+
+		CustomizeDiff: resourceObsBucketObjectsSyncCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cache_control": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"override": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateSyncPattern,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"cache_control": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"acl": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func validateSyncPattern(v interface{}, k string) ([]string, []error) {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid pattern: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// syncFile is the local file which is synchronized to the object.
+type syncFile struct {
+	Path         string
+	RelativePath string
+	Hash         string
+}
+
+// listSyncFiles returns the regular files in the directory, indexed by the object keys.
+func listSyncFiles(sourceDir, prefix string) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	err := filepath.WalkDir(sourceDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		hash, err := fileMD5(filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		files[prefix+relativePath] = syncFile{
+			Path:         filePath,
+			RelativePath: relativePath,
+			Hash:         hash,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading the source directory %s: %s", sourceDir, err)
+	}
+	return files, nil
+}
+
+func fileMD5(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func flattenSyncFileHashes(files map[string]syncFile) map[string]interface{} {
+	result := make(map[string]interface{}, len(files))
+	for key, file := range files {
+		result[key] = file.Hash
+	}
+	return result
+}
+
+// resourceObsBucketObjectsSyncCustomizeDiff plans the files by their hashes, so that the changes of the local files are
+// shown in the plan and only the changed files are uploaded.
+func resourceObsBucketObjectsSyncCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_dir") || !d.NewValueKnown("prefix") {
+		return d.SetNewComputed("files")
+	}
+
+	files, err := listSyncFiles(d.Get("source_dir").(string), d.Get("prefix").(string))
+	if err != nil {
+		return err
+	}
+	hashes := flattenSyncFileHashes(files)
+	old := d.Get("files").(map[string]interface{})
+	if len(old) == len(hashes) {
+		changed := false
+		for key, hash := range hashes {
+			if old[key] != hash {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	return d.SetNew("files", hashes)
+}
+
+// buildSyncObjectInput returns the upload options of the file, the first override whose pattern matches the file is
+// applied. The pattern is matched against the file name if it does not contain a slash (/), or the relative path.
+func buildSyncObjectInput(d *schema.ResourceData, key string, file syncFile) *obs.PutFileInput {
+	input := &obs.PutFileInput{
+		SourceFile: file.Path,
+	}
+	input.Bucket = d.Get("bucket").(string)
+	input.Key = key
+	input.ACL = obs.AclType(d.Get("acl").(string))
+	input.CacheControl = d.Get("cache_control").(string)
+	input.ContentType = mime.TypeByExtension(path.Ext(file.RelativePath))
+	if input.ContentType == "" {
+		input.ContentType = "application/octet-stream"
+	}
+
+	for _, raw := range d.Get("override").([]interface{}) {
+		override, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pattern := override["pattern"].(string)
+		name := file.RelativePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(name)
+		}
+		if matched, _ := path.Match(pattern, name); !matched {
+			continue
+		}
+
+		if v := override["content_type"].(string); v != "" {
+			input.ContentType = v
+		}
+		if v := override["cache_control"].(string); v != "" {
+			input.CacheControl = v
+		}
+		if v := override["acl"].(string); v != "" {
+			input.ACL = obs.AclType(v)
+		}
+		break
+	}
+	return input
+}
+
+// uploadSyncFiles uploads the files in parallel, the keys of the failed files and their errors are returned.
+func uploadSyncFiles(obsClient *obs.ObsClient, d *schema.ResourceData, keys []string,
+	files map[string]syncFile) ([]string, error) {
+	var failedKeys []string
+	var mErr *multierror.Error
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	// The options are built before uploading, the resource data is not accessed in parallel.
+	inputs := make([]*obs.PutFileInput, len(keys))
+	for i, key := range keys {
+		inputs[i] = buildSyncObjectInput(d, key, files[key])
+	}
+
+	inputCh := make(chan *obs.PutFileInput)
+	for i := 0; i < d.Get("concurrency").(int); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for input := range inputCh {
+				log.Printf("[DEBUG] uploading %s to OBS bucket %s, opts: %#v", input.Key, input.Bucket, input)
+				if _, err := obsClient.PutFile(input); err != nil {
+					mutex.Lock()
+					failedKeys = append(failedKeys, input.Key)
+					mErr = multierror.Append(mErr, fmt.Errorf("error uploading %s: %s", input.Key, err))
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	for _, input := range inputs {
+		inputCh <- input
+	}
+	close(inputCh)
+	wg.Wait()
+	return failedKeys, mErr.ErrorOrNil()
+}
+
+func deleteSyncObjects(obsClient *obs.ObsClient, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += deleteObjectsBatchSize {
+		end := start + deleteObjectsBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := make([]obs.ObjectToDelete, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, obs.ObjectToDelete{Key: key})
+		}
+		log.Printf("[DEBUG] objects of %s will be deleted: %v", bucket, keys[start:end])
+		output, err := obsClient.DeleteObjects(&obs.DeleteObjectsInput{
+			Bucket:  bucket,
+			Quiet:   true,
+			Objects: objects,
+		})
+		if err != nil {
+			return getObsError("Error deleting objects of OBS bucket", bucket, err)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("error deleting objects of OBS bucket %s: %#v", bucket, output.Errors)
+		}
+	}
+	return nil
+}
+
+// syncBucketObjects uploads the local files whose hashes are different from the old ones, or all the files if the
+// upload options are changed, and deletes the objects whose files are removed.
+func syncBucketObjects(obsClient *obs.ObsClient, d *schema.ResourceData, old map[string]interface{},
+	uploadAll bool) error {
+	bucket := d.Get("bucket").(string)
+	files, err := listSyncFiles(d.Get("source_dir").(string), d.Get("prefix").(string))
+	if err != nil {
+		return err
+	}
+
+	uploadKeys := make([]string, 0, len(files))
+	for key, file := range files {
+		if uploadAll || old[key] != file.Hash {
+			uploadKeys = append(uploadKeys, key)
+		}
+	}
+	deleteKeys := make([]string, 0)
+	for key := range old {
+		if _, ok := files[key]; !ok {
+			deleteKeys = append(deleteKeys, key)
+		}
+	}
+	sort.Strings(uploadKeys)
+	sort.Strings(deleteKeys)
+
+	log.Printf("[DEBUG] synchronizing %d files to OBS bucket %s, %d to upload and %d to delete", len(files), bucket,
+		len(uploadKeys), len(deleteKeys))
+	if failedKeys, err := uploadSyncFiles(obsClient, d, uploadKeys, files); err != nil {
+		// Save the files which are uploaded successfully, the others are uploaded in the next apply.
+		uploaded := flattenSyncFileHashes(files)
+		for _, key := range failedKeys {
+			if hash, ok := old[key]; ok {
+				uploaded[key] = hash
+			} else {
+				delete(uploaded, key)
+			}
+		}
+		for _, key := range deleteKeys {
+			uploaded[key] = old[key]
+		}
+		if err := d.Set("files", uploaded); err != nil {
+			log.Printf("[WARN] error saving the uploaded files: %s", err)
+		}
+		return fmt.Errorf("error uploading objects to OBS bucket %s: %s", bucket, err)
+	}
+	if err := deleteSyncObjects(obsClient, bucket, deleteKeys); err != nil {
+		// Keep the objects which may not be deleted, they are deleted in the next apply.
+		remaining := flattenSyncFileHashes(files)
+		for _, key := range deleteKeys {
+			remaining[key] = old[key]
+		}
+		if err := d.Set("files", remaining); err != nil {
+			log.Printf("[WARN] error saving the remaining files: %s", err)
+		}
+		return err
+	}
+	return d.Set("files", flattenSyncFileHashes(files))
+}
+
+func resourceObsBucketObjectsSyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("Error creating OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	_, err = obsClient.HeadBucket(bucket)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == 404 {
+			return diag.Errorf("OBS bucket(%s) not found", bucket)
+		}
+		return diag.Errorf("error reading OBS bucket %s: %s", bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, d.Get("prefix").(string)))
+	if err := syncBucketObjects(obsClient, d, nil, true); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketObjectsSyncRead(ctx, d, meta)
+}
+
+// resourceObsBucketObjectsSyncRead removes the objects which are deleted or changed in the bucket from the files, so
+// that they are uploaded again.
+func resourceObsBucketObjectsSyncRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	region := conf.GetRegion(d)
+	obsClient, err := conf.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("Error creating OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	contents, _, err := listBucketObjects(obsClient, bucket, d.Get("prefix").(string), "", 0)
+	if err != nil {
+		var obsErr obs.ObsError
+		if errors.As(err, &obsErr) && obsErr.StatusCode == 404 {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket objects sync")
+		}
+		return diag.FromErr(err)
+	}
+
+	etags := make(map[string]string, len(contents))
+	for _, content := range contents {
+		etags[content.Key] = strings.Trim(content.ETag, `"`)
+	}
+	files := d.Get("files").(map[string]interface{})
+	for key, hash := range files {
+		etag, ok := etags[key]
+		if !ok {
+			delete(files, key)
+		} else if etag != hash {
+			files[key] = etag
+		}
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("files", files),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket objects sync fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketObjectsSyncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("Error creating OBS client: %s", err)
+	}
+
+	old, _ := d.GetChange("files")
+	uploadAll := d.HasChanges("source_dir", "acl", "cache_control", "override")
+	if err := syncBucketObjects(obsClient, d, old.(map[string]interface{}), uploadAll); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketObjectsSyncRead(ctx, d, meta)
+}
+
+func resourceObsBucketObjectsSyncDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("Error creating OBS client: %s", err)
+	}
+
+	files := d.Get("files").(map[string]interface{})
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if err := deleteSyncObjects(obsClient, d.Get("bucket").(string), keys); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
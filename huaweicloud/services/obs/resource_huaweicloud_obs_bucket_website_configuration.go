@@ -0,0 +1,192 @@
+package obs
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// @API OBS PUT ?website
+// @API OBS GET ?website
+// @API OBS DELETE ?website
+func ResourceObsBucketWebsiteConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObsBucketWebsiteConfigurationCreate,
+		UpdateContext: resourceObsBucketWebsiteConfigurationUpdate,
+		ReadContext:   resourceObsBucketWebsiteConfigurationRead,
+		DeleteContext: resourceObsBucketWebsiteConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"index_document": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"index_document", "redirect_all_requests_to"},
+			},
+			"error_document": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"redirect_all_requests_to": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"index_document", "error_document", "routing_rules"},
+			},
+			"routing_rules": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: utils.ValidateJsonString,
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := utils.NormalizeJsonString(v)
+					return jsonString
+				},
+			},
+		},
+	}
+}
+
+func putObsBucketWebsiteConfiguration(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	website := map[string]interface{}{
+		"index_document":           d.Get("index_document"),
+		"error_document":           d.Get("error_document"),
+		"redirect_all_requests_to": d.Get("redirect_all_requests_to"),
+		"routing_rules":            d.Get("routing_rules"),
+	}
+	websiteConfiguration, err := buildObsBucketWebsiteConfiguration(bucket, website)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] set website configuration of OBS bucket %s: %#v", bucket, websiteConfiguration)
+	_, err = obsClient.SetBucketWebsiteConfiguration(websiteConfiguration)
+	if err != nil {
+		return getObsError("Error updating website configuration of OBS bucket", bucket, err)
+	}
+	return nil
+}
+
+func resourceObsBucketWebsiteConfigurationCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	// The website configuration of the bucket is replaced as a whole, make sure it is not managed by others.
+	bucket := d.Get("bucket").(string)
+	_, err = obsClient.GetBucketWebsiteConfiguration(bucket)
+	if err == nil {
+		return diag.FromErr(bucketConfigurationConflictError(bucket, "website configuration", "website"))
+	}
+	if !isObsNotFoundError(err) {
+		return diag.FromErr(getObsError("Error getting website configuration of OBS bucket", bucket, err))
+	}
+
+	if err := putObsBucketWebsiteConfiguration(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bucket)
+	return resourceObsBucketWebsiteConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketWebsiteConfigurationRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	output, err := obsClient.GetBucketWebsiteConfiguration(d.Id())
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket website configuration")
+		}
+		return diag.FromErr(getObsError("Error getting website configuration of OBS bucket", d.Id(), err))
+	}
+
+	website, err := flattenObsBucketWebsiteConfiguration(output)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("bucket", d.Id()),
+		d.Set("index_document", website["index_document"]),
+		d.Set("error_document", website["error_document"]),
+		d.Set("redirect_all_requests_to", website["redirect_all_requests_to"]),
+		d.Set("routing_rules", website["routing_rules"]),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting OBS bucket website configuration fields: %s", err)
+	}
+	return nil
+}
+
+func resourceObsBucketWebsiteConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	if err := putObsBucketWebsiteConfiguration(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceObsBucketWebsiteConfigurationRead(ctx, d, meta)
+}
+
+func resourceObsBucketWebsiteConfigurationDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS Client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] delete website configuration of OBS bucket %s", bucket)
+	_, err = obsClient.DeleteBucketWebsiteConfiguration(bucket)
+	if err != nil {
+		if isObsNotFoundError(err) {
+			return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "OBS bucket website configuration")
+		}
+		return diag.FromErr(getObsError("Error deleting website configuration of OBS bucket", bucket, err))
+	}
+	return nil
+}
@@ -211,7 +211,7 @@ func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = updateTags(d, updatePolicyClient, policiesType, d.Id(), "tags")
 		if err != nil {
 			return diag.Errorf("error updating tags of Organizations policy %s: %s", d.Id(), err)
@@ -364,7 +364,7 @@ func resourceRAMShareUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		err = updateRAMShareTags(updateRAMShareClient, d)
 		if err != nil {
 			return diag.Errorf("error updating RAM share tags: %s", err)
@@ -403,7 +403,7 @@ func updateRAMShareTags(client *golangsdk.ServiceClient, d *schema.ResourceData)
 	ramShareTagsPath := client.Endpoint + ramShareTagsHttpUrl
 	ramShareTagsPath = strings.ReplaceAll(ramShareTagsPath, "{resource_share_id}", d.Id())
 
-	oRaw, nRaw := d.GetChange("tags")
+	oRaw, nRaw := utils.GetTagsChange(d)
 	oMap := oRaw.(map[string]interface{})
 	nMap := nRaw.(map[string]interface{})
 
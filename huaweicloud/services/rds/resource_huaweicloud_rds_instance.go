@@ -16,6 +16,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/bss/v2/orders"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/rds/v3/backups"
+	"github.com/chnsz/golangsdk/openstack/rds/v3/flavors"
 	"github.com/chnsz/golangsdk/openstack/rds/v3/instances"
 	"github.com/chnsz/golangsdk/openstack/rds/v3/securities"
 
@@ -55,6 +56,7 @@ type ctxType string
 // @API BSS POST /v2/orders/subscriptions/resources/autorenew/{instance_id}
 // @API BSS DELETE /v2/orders/subscriptions/resources/autorenew/{instance_id}
 // @API BSS POST /v2/orders/subscriptions/resources/unsubscribe
+// @API RDS GET /v3/{project_id}/flavors/{database_name}
 func ResourceRdsInstance() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRdsInstanceCreate,
@@ -65,6 +67,12 @@ func ResourceRdsInstance() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: common.CheckFlavorAvailability(common.FlavorChecker{
+			FlavorKey:           "flavor",
+			AvailabilityZoneKey: "availability_zone",
+			ListFlavors:         listRdsInstanceFlavors,
+		}),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(30 * time.Minute),
 			Update:  schema.DefaultTimeout(30 * time.Minute),
@@ -118,9 +126,22 @@ func ResourceRdsInstance() *schema.Resource {
 							ForceNew: true,
 						},
 						"password": {
-							Type:      schema.TypeString,
-							Sensitive: true,
-							Optional:  true,
+							Type:          schema.TypeString,
+							Sensitive:     true,
+							Optional:      true,
+							ConflictsWith: []string{"db.0.password_wo"},
+						},
+						"password_wo": {
+							Type:          schema.TypeString,
+							Sensitive:     true,
+							Optional:      true,
+							WriteOnly:     true,
+							ConflictsWith: []string{"db.0.password"},
+						},
+						"password_wo_version": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							RequiredWith: []string{"db.0.password_wo"},
 						},
 						"port": {
 							Type:     schema.TypeInt,
@@ -403,6 +424,68 @@ func ResourceRdsInstance() *schema.Resource {
 	}
 }
 
+// listRdsInstanceFlavors lists the flavors of the database version which are on sale in all the zones, the same as
+// the data source huaweicloud_rds_flavors.
+func listRdsInstanceFlavors(_ context.Context, d *schema.ResourceDiff, cfg *config.Config, zones []string) ([]string, error) {
+	if !d.NewValueKnown("db") {
+		return nil, fmt.Errorf("the database is unknown until applying")
+	}
+	var (
+		region    = common.GetDiffRegion(d, cfg)
+		dbType    = d.Get("db.0.type").(string)
+		dbVersion = d.Get("db.0.version").(string)
+	)
+	// the flavors are cached, so they're listed once for all the instances of the same database in a plan
+	cacheKey := fmt.Sprintf("rds_flavors/%s/%s/%s", region, dbType, dbVersion)
+	cached, err := cfg.LoadOrStoreList(cacheKey, func() (interface{}, error) {
+		client, err := cfg.RdsV3Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating RDS client: %s", err)
+		}
+
+		pages, err := flavors.List(client, flavors.DbFlavorsOpts{Versionname: dbVersion}, dbType).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := flavors.ExtractDbFlavors(pages)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Flavorslist, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allFlavors := cached.([]flavors.Flavors)
+	result := make([]string, 0, len(allFlavors))
+	for _, flavor := range allFlavors {
+		if isRdsFlavorAvailable(flavor.Azstatus, zones) {
+			result = append(result, flavor.Speccode)
+		}
+	}
+	return result, nil
+}
+
+// isRdsFlavorAvailable returns true if the flavor is on sale in all the zones, or in any zone if the zones are empty.
+func isRdsFlavorAvailable(azStatus map[string]string, zones []string) bool {
+	if len(zones) == 0 {
+		for _, status := range azStatus {
+			if status == "normal" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, az := range zones {
+		if azStatus[az] != "normal" {
+			return false
+		}
+	}
+	return true
+}
+
 func buildRdsInstanceDBPort(d *schema.ResourceData) string {
 	if v, ok := d.GetOk("db.0.port"); ok {
 		return strconv.Itoa(v.(int))
@@ -473,7 +556,7 @@ func resourceRdsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.Password = d.Get("db.0.password").(string)
+	createOpts.Password = getRdsRootPassword(d)
 
 	res, err := instances.Create(client, createOpts).Extract()
 	if err != nil {
@@ -640,6 +723,7 @@ func resourceRdsInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 	}
 	if len(d.Get("db").([]interface{})) > 0 {
 		database["password"] = d.Get("db.0.password")
+		database["password_wo_version"] = d.Get("db.0.password_wo_version")
 	}
 	dbList[0] = database
 	if err := d.Set("db", dbList); err != nil {
@@ -788,7 +872,7 @@ func resourceRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of RDS instance (%s): %s", instanceID, tagErr)
@@ -1596,12 +1680,12 @@ func rdsInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID str
 
 func updateRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
 	instanceID string) error {
-	if !d.HasChange("db.0.password") {
+	if !d.HasChanges("db.0.password", "db.0.password_wo_version") {
 		return nil
 	}
 
 	updateOpts := instances.RestRootPasswordOpts{
-		DbUserPwd: d.Get("db.0.password").(string),
+		DbUserPwd: getRdsRootPassword(d),
 	}
 
 	retryFunc := func() (interface{}, bool, error) {
@@ -1624,6 +1708,14 @@ func updateRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *
 	return nil
 }
 
+// getRdsRootPassword returns the root password of the instance, the write-only argument takes precedence.
+func getRdsRootPassword(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, "db.0.password_wo"); v != "" {
+		return v
+	}
+	return d.Get("db.0.password").(string)
+}
+
 func updateRdsInstanceMaintainWindow(d *schema.ResourceData, client *golangsdk.ServiceClient, instanceID string) error {
 	if !d.HasChanges("maintain_begin", "maintain_end") {
 		return nil
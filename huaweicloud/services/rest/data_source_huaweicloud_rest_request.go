@@ -0,0 +1,328 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	paginationMarker = "marker"
+	paginationOffset = "offset"
+	paginationPage   = "page"
+)
+
+// DataSourceRestRequest sends a query request of any service and extracts the results from the response.
+func DataSourceRestRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRestRequestRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"service": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateServiceCatalog,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GET",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "POST"}, false),
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"pagination": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								paginationMarker, paginationOffset, paginationPage,
+							}, false),
+						},
+						"items_path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"limit": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      100,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"limit_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "limit",
+						},
+						"marker_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "marker",
+						},
+						"marker_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"offset_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "offset",
+						},
+						"page_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "page",
+						},
+						"start_page": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+						"max_pages": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1000,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"result_paths": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// restPagination is the configuration of the pagination, the parameters are sent as the query parameters.
+type restPagination struct {
+	Type       string
+	ItemsPath  string
+	Limit      int
+	LimitKey   string
+	MarkerKey  string
+	MarkerPath string
+	OffsetKey  string
+	PageKey    string
+	StartPage  int
+	MaxPages   int
+}
+
+func getRestPagination(d *schema.ResourceData) *restPagination {
+	rawList := d.Get("pagination").([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	return &restPagination{
+		Type:       raw["type"].(string),
+		ItemsPath:  raw["items_path"].(string),
+		Limit:      raw["limit"].(int),
+		LimitKey:   raw["limit_key"].(string),
+		MarkerKey:  raw["marker_key"].(string),
+		MarkerPath: raw["marker_path"].(string),
+		OffsetKey:  raw["offset_key"].(string),
+		PageKey:    raw["page_key"].(string),
+		StartPage:  raw["start_page"].(int),
+		MaxPages:   raw["max_pages"].(int),
+	}
+}
+
+// withQuery appends the query parameters to the path, the existing parameters of the same keys are replaced.
+// The existing parameters are kept as they are rather than re-encoded, so that the placeholders are kept.
+func withQuery(path string, params map[string]string) (string, error) {
+	path, rawQuery, _ := strings.Cut(path, "?")
+
+	pairs := make([]string, 0)
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return "", fmt.Errorf("error parsing the query parameters of the path: %s", err)
+		}
+		if _, ok := params[key]; !ok {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(params[k]))
+	}
+	return path + "?" + strings.Join(pairs, "&"), nil
+}
+
+// listAllItems queries all pages and returns the items of them. It stops when a page has no items, or the page is not
+// full for the offset and page styles, or the next marker is not found for the marker style. An error is returned if
+// a page is the same as the previous one, which means the API ignores the pagination parameters, or the number of
+// the pages exceeds the max_pages.
+func listAllItems(d *schema.ResourceData, cfg *config.Config, pagination *restPagination) ([]interface{}, error) {
+	var (
+		method    = d.Get("method").(string)
+		body      = d.Get("body").(string)
+		params    = map[string]string{pagination.LimitKey: strconv.Itoa(pagination.Limit)}
+		result    = make([]interface{}, 0)
+		lastItems string
+	)
+
+	switch pagination.Type {
+	case paginationOffset:
+		params[pagination.OffsetKey] = "0"
+	case paginationPage:
+		params[pagination.PageKey] = strconv.Itoa(pagination.StartPage)
+	}
+
+	for page := pagination.StartPage; ; page++ {
+		if page-pagination.StartPage >= pagination.MaxPages {
+			return nil, fmt.Errorf("the number of the pages exceeds the max_pages (%d)", pagination.MaxPages)
+		}
+
+		path, err := withQuery(d.Get("path").(string), params)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := doRestRequest(d, cfg, method, path, body)
+		if err != nil {
+			return nil, err
+		}
+
+		items, _ := utils.PathSearch(pagination.ItemsPath, respBody, make([]interface{}, 0)).([]interface{})
+		if len(items) == 0 {
+			return result, nil
+		}
+		currentItems, err := json.Marshal(items)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling the items of the page: %s", err)
+		}
+		if string(currentItems) == lastItems {
+			return nil, fmt.Errorf("the page %s is the same as the previous one, please check the pagination parameters",
+				path)
+		}
+		lastItems = string(currentItems)
+		result = append(result, items...)
+
+		switch pagination.Type {
+		case paginationMarker:
+			var marker interface{}
+			if pagination.MarkerPath != "" {
+				marker = utils.PathSearch(pagination.MarkerPath, respBody, nil)
+			} else {
+				marker = utils.PathSearch("id", items[len(items)-1], nil)
+			}
+			// stop if the API ignores the marker and returns the same page again
+			if marker == nil || fmt.Sprint(marker) == "" || fmt.Sprint(marker) == params[pagination.MarkerKey] {
+				return result, nil
+			}
+			params[pagination.MarkerKey] = fmt.Sprint(marker)
+		case paginationOffset:
+			if len(items) < pagination.Limit {
+				return result, nil
+			}
+			params[pagination.OffsetKey] = strconv.Itoa(len(result))
+		case paginationPage:
+			if len(items) < pagination.Limit {
+				return result, nil
+			}
+			params[pagination.PageKey] = strconv.Itoa(page + 1)
+		}
+	}
+}
+
+func dataSourceRestRequestRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+
+	var (
+		respBody interface{}
+		err      error
+	)
+	if pagination := getRestPagination(d); pagination != nil {
+		respBody, err = listAllItems(d, cfg, pagination)
+	} else {
+		respBody, err = doRestRequest(d, cfg, d.Get("method").(string), d.Get("path").(string), d.Get("body").(string))
+	}
+	if err != nil {
+		return diag.Errorf("error requesting %s API: %s", d.Get("service"), err)
+	}
+
+	output, err := json.Marshal(respBody)
+	if err != nil {
+		return diag.Errorf("error marshaling the response of %s API: %s", d.Get("service"), err)
+	}
+
+	resultPaths := utils.ExpandToStringList(d.Get("result_paths").([]interface{}))
+	results := make([]string, len(resultPaths))
+	for i, expression := range resultPaths {
+		result, err := json.Marshal(utils.PathSearch(expression, respBody, nil))
+		if err != nil {
+			return diag.Errorf("error marshaling the result of %q: %s", expression, err)
+		}
+		results[i] = string(result)
+	}
+
+	randUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(randUUID)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", cfg.GetRegion(d)),
+		d.Set("output", string(output)),
+		d.Set("results", results),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestRestRequestPagination(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+
+	client, err := cfg.NetworkingV1Client(fakecloud.Region)
+	th.AssertNoErr(t, err)
+	for i := 0; i < 5; i++ {
+		_, err := vpcs.Create(client, vpcs.CreateOpts{Name: fmt.Sprintf("vpc-%d", i), CIDR: "192.168.0.0/16"}).Extract()
+		th.AssertNoErr(t, err)
+	}
+
+	res := DataSourceRestRequest()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service": "vpc",
+		"path":    "v1/{project_id}/vpcs",
+		"pagination": []interface{}{
+			map[string]interface{}{
+				"type":       "marker",
+				"items_path": "vpcs",
+				"limit":      2,
+			},
+		},
+		"result_paths": []interface{}{"length(@)", "[*].name"},
+	})
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "5", d.Get("results.0"))
+	th.AssertEquals(t, `["vpc-0","vpc-1","vpc-2","vpc-3","vpc-4"]`, d.Get("results.1"))
+
+	// the query fails if the pages exceed the max_pages
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service": "vpc",
+		"path":    "v1/{project_id}/vpcs",
+		"pagination": []interface{}{
+			map[string]interface{}{
+				"type":       "marker",
+				"items_path": "vpcs",
+				"limit":      2,
+				"max_pages":  2,
+			},
+		},
+	})
+	diags := res.ReadContext(ctx, d, cfg)
+	th.AssertEquals(t, true, diags.HasError())
+	th.AssertEquals(t, true, strings.Contains(diags[0].Summary, "exceeds the max_pages (2)"))
+
+	// the query fails instead of looping forever if the API ignores the pagination parameters
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service": "vpc",
+		"path":    "v1/{project_id}/vpcs?limit=2",
+		"pagination": []interface{}{
+			map[string]interface{}{
+				"type":       "offset",
+				"items_path": "vpcs",
+				"limit":      2,
+				"limit_key":  "ignored_limit",
+				"offset_key": "ignored_offset",
+			},
+		},
+	})
+	diags = res.ReadContext(ctx, d, cfg)
+	th.AssertEquals(t, true, diags.HasError())
+	th.AssertEquals(t, true, strings.Contains(diags[0].Summary, "is the same as the previous one"))
+
+	// without pagination, the raw response body is returned
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service":      "vpc",
+		"path":         "/v1/{project_id}/vpcs?limit=1",
+		"result_paths": []interface{}{"vpcs[0].name"},
+	})
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, `"vpc-0"`, d.Get("results.0"))
+	th.AssertEquals(t, true, strings.HasPrefix(d.Get("output").(string), `{"vpcs":[`))
+}
+
+func TestRestRequestWithQuery(t *testing.T) {
+	path, err := withQuery("v1/{project_id}/vpcs?enterprise_project_id={ep_id}&limit=10&name=a%20b",
+		map[string]string{"limit": "100", "marker": "{id}"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "v1/{project_id}/vpcs?enterprise_project_id={ep_id}&name=a%20b&limit=100&marker=%7Bid%7D", path)
+
+	path, err = withQuery("v1/{project_id}/vpcs", map[string]string{"limit": "100"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "v1/{project_id}/vpcs?limit=100", path)
+
+	_, err = withQuery("v1/vpcs?%zz=1", map[string]string{"limit": "100"})
+	th.AssertEquals(t, true, err != nil)
+}
@@ -0,0 +1,598 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/internal/httpclient_go"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceRestResource manages any resource through its REST APIs, the requests are sent by the service client of
+// the catalog, so they are signed, retried and traced the same as the modeled resources.
+func ResourceRestResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRestResourceCreate,
+		ReadContext:   resourceRestResourceRead,
+		UpdateContext: resourceRestResourceUpdate,
+		DeleteContext: resourceRestResourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRestResourceImportState,
+		},
+
+		CustomizeDiff: resourceRestResourceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"service": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateServiceCatalog,
+			},
+			// the changes of create and id_path replace the resource unless it's imported, see the CustomizeDiff
+			"create": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem:     restOperationSchema("POST", false),
+			},
+			"read": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     restOperationSchema("GET", false),
+			},
+			"update": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     restOperationSchema("PUT", true),
+			},
+			"delete": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     restOperationSchema("DELETE", true),
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+			"read_body_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"id_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "id",
+			},
+			"polling": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status_path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"target": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"pending": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"failed": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"job_id_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func restOperationSchema(defaultMethod string, withBody bool) *schema.Resource {
+	sc := map[string]*schema.Schema{
+		"path": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"method": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  defaultMethod,
+			ValidateFunc: validation.StringInSlice([]string{
+				"GET", "POST", "PUT", "PATCH", "DELETE",
+			}, false),
+		},
+	}
+	if withBody {
+		sc["body"] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressEquivalentJSONDiffs,
+		}
+	}
+	return &schema.Resource{Schema: sc}
+}
+
+func validateServiceCatalog(v interface{}, k string) ([]string, []error) {
+	if config.GetServiceCatalog(v.(string)) == nil {
+		return nil, []error{fmt.Errorf("%q is not a valid service catalog of %s, e.g. vpc, ecs and rds", v, k)}
+	}
+	return nil, nil
+}
+
+func suppressEquivalentJSONDiffs(_, old, new string, _ *schema.ResourceData) bool {
+	equal, _ := utils.CompareJsonTemplateAreEquivalent(old, new)
+	return equal
+}
+
+// resourceRestResourceCustomizeDiff replaces the resource when the create API or the id_path is changed, or the body is
+// changed but no update API is specified. The imported resources have no create API in the state, so the create API,
+// the id_path and the body of the configuration are saved to the state without replacing them.
+func resourceRestResourceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if oldCreate, _ := d.GetChange("create"); len(oldCreate.([]interface{})) == 0 {
+		return nil
+	}
+
+	for _, key := range []string{"create", "id_path"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	if d.HasChange("body") && len(d.Get("update").([]interface{})) == 0 {
+		return d.ForceNew("body")
+	}
+	return nil
+}
+
+// resourceRestResourceImportState imports the resource by the service catalog, the resource ID and the path of the
+// read API, e.g. vpc/<id>/v1/{project_id}/vpcs/{id}. The read API is saved to the state, the other APIs and the body
+// are taken from the configuration by the next apply.
+func resourceRestResourceImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid format specified for import ID, must be <service>/<id>/<read_path>")
+	}
+	if _, errs := validateServiceCatalog(parts[0], "service"); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	d.SetId(parts[1])
+	read := []interface{}{
+		map[string]interface{}{
+			"path":   parts[2],
+			"method": "GET",
+		},
+	}
+	mErr := multierror.Append(nil,
+		d.Set("service", parts[0]),
+		d.Set("read", read),
+		d.Set("id_path", "id"),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
+}
+
+// restOperation is a request template of the create, read, update or delete operation.
+type restOperation struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// getRestOperation returns the request template of the operation, the read, update and delete operations default to
+// the path of the created resource: the create path followed by the resource ID.
+func getRestOperation(d *schema.ResourceData, operation string) *restOperation {
+	defaultMethods := map[string]string{"create": "POST", "read": "GET", "update": "PUT", "delete": "DELETE"}
+
+	rawList := d.Get(operation).([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		if operation == "update" {
+			return nil
+		}
+		createPath := getRestOperation(d, "create").Path
+		return &restOperation{
+			Method: defaultMethods[operation],
+			Path:   strings.TrimSuffix(createPath, "/") + "/{id}",
+		}
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	op := &restOperation{
+		Method: raw["method"].(string),
+		Path:   raw["path"].(string),
+	}
+	if body, ok := raw["body"].(string); ok && body != "" {
+		op.Body = body
+	} else if operation == "create" || operation == "update" {
+		op.Body = d.Get("body").(string)
+	}
+	return op
+}
+
+// replacePlaceholders replaces the placeholders in the path or body template.
+func replacePlaceholders(client *golangsdk.ServiceClient, cfg *config.Config, template, id, jobID string) string {
+	replacer := strings.NewReplacer(
+		"{project_id}", client.ProjectID,
+		"{domain_id}", cfg.DomainID,
+		"{id}", id,
+		"{job_id}", jobID,
+	)
+	return replacer.Replace(template)
+}
+
+// buildRestPath replaces the placeholders in the path template, the leading slash is optional.
+func buildRestPath(client *golangsdk.ServiceClient, cfg *config.Config, template, id, jobID string) string {
+	return replacePlaceholders(client, cfg, strings.TrimPrefix(template, "/"), id, jobID)
+}
+
+// doRestRequest sends the request of the operation and returns the JSON response body, which is nil if it's empty.
+func doRestRequest(d *schema.ResourceData, cfg *config.Config, method, path, body string) (interface{}, error) {
+	client, err := httpclient_go.NewHttpClientGo(cfg, d.Get("service").(string), cfg.GetRegion(d))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s client: %s", d.Get("service"), err)
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
+	client.WithMethod(method).WithUrl(buildRestPath(client.Client, cfg, path, d.Id(), "")).WithHeader(headers)
+	if body != "" {
+		var jsonBody interface{}
+		body = replacePlaceholders(client.Client, cfg, body, d.Id(), "")
+		if err := json.Unmarshal([]byte(body), &jsonBody); err != nil {
+			return nil, fmt.Errorf("error parsing the request body: %s", err)
+		}
+		client.WithBody(jsonBody)
+	}
+
+	resp, err := client.Do()
+	if err != nil {
+		return nil, err
+	}
+	return parseRestResponse(resp)
+}
+
+func parseRestResponse(resp *http.Response) (interface{}, error) {
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, nil
+	}
+
+	var respBody interface{}
+	if err := json.Unmarshal(content, &respBody); err != nil {
+		return nil, fmt.Errorf("error parsing the response body: %s", err)
+	}
+	return respBody, nil
+}
+
+func resourceRestResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	op := getRestOperation(d, "create")
+
+	respBody, err := doRestRequest(d, cfg, op.Method, op.Path, op.Body)
+	if err != nil {
+		return diag.Errorf("error creating %s resource: %s", d.Get("service"), err)
+	}
+
+	idPath := d.Get("id_path").(string)
+	id := utils.PathSearch(idPath, respBody, nil)
+	if id == nil {
+		return diag.Errorf("error creating %s resource: unable to find the ID by %q in the API response",
+			d.Get("service"), idPath)
+	}
+	d.SetId(fmt.Sprint(id))
+
+	if err := waitForRestResource(ctx, d, cfg, respBody, schema.TimeoutCreate); err != nil {
+		return diag.Errorf("error waiting for the %s resource (%s) to be created: %s", d.Get("service"), d.Id(), err)
+	}
+	return resourceRestResourceRead(ctx, d, meta)
+}
+
+func resourceRestResourceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	op := getRestOperation(d, "read")
+
+	respBody, err := doRestRequest(d, cfg, op.Method, op.Path, "")
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error retrieving %s resource", d.Get("service")))
+	}
+
+	output, err := json.Marshal(respBody)
+	if err != nil {
+		return diag.Errorf("error marshaling the output of %s resource: %s", d.Get("service"), err)
+	}
+	mErr := multierror.Append(nil,
+		d.Set("region", cfg.GetRegion(d)),
+		d.Set("output", string(output)),
+	)
+	if bodyPath := d.Get("read_body_path").(string); bodyPath != "" {
+		mErr = multierror.Append(mErr, setRestBodyFromResponse(d, utils.PathSearch(bodyPath, respBody, nil)))
+	}
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+// setRestBodyFromResponse saves the fields of the body which are returned by the read API, so the changes of them
+// outside of Terraform are shown as the diff of the body. The fields which are not in the body are ignored, and the
+// values which contain the placeholders are kept as they're replaced before sending.
+func setRestBodyFromResponse(d *schema.ResourceData, actual interface{}) error {
+	body := d.Get("body").(string)
+	if body == "" || actual == nil {
+		return nil
+	}
+
+	var configured interface{}
+	if err := json.Unmarshal([]byte(body), &configured); err != nil {
+		return fmt.Errorf("error parsing the body: %s", err)
+	}
+	projected, err := json.Marshal(projectRestBody(configured, actual))
+	if err != nil {
+		return fmt.Errorf("error marshaling the body: %s", err)
+	}
+	return d.Set("body", string(projected))
+}
+
+// projectRestBody returns the actual value of the configured JSON value, the objects are compared by the configured
+// keys only, and the missing keys in the actual value are left out.
+func projectRestBody(configured, actual interface{}) interface{} {
+	switch v := configured.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if actualValue, ok := actualMap[key]; ok {
+				result[key] = projectRestBody(value, actualValue)
+			}
+		}
+		return result
+	case string:
+		if strings.Contains(v, "{") && strings.Contains(v, "}") {
+			return v
+		}
+	}
+	return actual
+}
+
+func resourceRestResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+
+	if d.HasChanges("body", "update") {
+		op := getRestOperation(d, "update")
+		if op == nil {
+			return resourceRestResourceRead(ctx, d, meta)
+		}
+
+		respBody, err := doRestRequest(d, cfg, op.Method, op.Path, op.Body)
+		if err != nil {
+			return diag.Errorf("error updating %s resource (%s): %s", d.Get("service"), d.Id(), err)
+		}
+		if err := waitForRestResource(ctx, d, cfg, respBody, schema.TimeoutUpdate); err != nil {
+			return diag.Errorf("error waiting for the %s resource (%s) to be updated: %s", d.Get("service"), d.Id(), err)
+		}
+	}
+	return resourceRestResourceRead(ctx, d, meta)
+}
+
+func resourceRestResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	op := getRestOperation(d, "delete")
+	if op.Path == "/{id}" {
+		// the imported resource has no create API in the state until it's applied
+		return diag.Errorf("the delete API of the %s resource (%s) is unknown, please apply the configuration first",
+			d.Get("service"), d.Id())
+	}
+
+	respBody, err := doRestRequest(d, cfg, op.Method, op.Path, op.Body)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error deleting %s resource", d.Get("service")))
+	}
+	if err := waitForRestResourceDeleted(ctx, d, cfg, respBody); err != nil {
+		return diag.Errorf("error waiting for the %s resource (%s) to be deleted: %s", d.Get("service"), d.Id(), err)
+	}
+	return nil
+}
+
+// restPolling is the configuration of the polling for the asynchronous operations.
+type restPolling struct {
+	StatusPath string
+	Target     []string
+	Pending    []string
+	Failed     []string
+	Path       string
+	JobID      string
+	Interval   time.Duration
+}
+
+// getRestPolling returns the polling configuration, nil is returned if no polling is specified. The job ID is
+// searched in the response of the operation, and the path defaults to the path of the read operation.
+func getRestPolling(d *schema.ResourceData, respBody interface{}) *restPolling {
+	rawList := d.Get("polling").([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	polling := &restPolling{
+		StatusPath: raw["status_path"].(string),
+		Target:     utils.ExpandToStringList(raw["target"].([]interface{})),
+		Pending:    utils.ExpandToStringList(raw["pending"].([]interface{})),
+		Failed:     utils.ExpandToStringList(raw["failed"].([]interface{})),
+		Path:       raw["path"].(string),
+		Interval:   time.Duration(raw["interval"].(int)) * time.Second,
+	}
+	if polling.Path == "" {
+		polling.Path = getRestOperation(d, "read").Path
+	}
+	if jobIdPath := raw["job_id_path"].(string); jobIdPath != "" {
+		if jobID := utils.PathSearch(jobIdPath, respBody, nil); jobID != nil {
+			polling.JobID = fmt.Sprint(jobID)
+		}
+	}
+	return polling
+}
+
+// pollRestStatus returns the refresh function which queries the status of the resource or the job.
+// The status "DELETED" is returned if the resource is not found.
+func pollRestStatus(d *schema.ResourceData, cfg *config.Config, polling *restPolling) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		client, err := httpclient_go.NewHttpClientGo(cfg, d.Get("service").(string), cfg.GetRegion(d))
+		if err != nil {
+			return nil, "ERROR", err
+		}
+
+		headers := map[string]string{"Content-Type": "application/json"}
+		for k, v := range d.Get("headers").(map[string]interface{}) {
+			headers[k] = v.(string)
+		}
+		client.WithMethod(httpclient_go.MethodGet).WithHeader(headers).
+			WithUrl(buildRestPath(client.Client, cfg, polling.Path, d.Id(), polling.JobID))
+		resp, err := client.Do()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return "deleted", "DELETED", nil
+			}
+			return nil, "ERROR", err
+		}
+		respBody, err := parseRestResponse(resp)
+		if err != nil {
+			return nil, "ERROR", err
+		}
+
+		status := fmt.Sprint(utils.PathSearch(polling.StatusPath, respBody, ""))
+		log.Printf("[DEBUG] the status of %s resource (%s) is: %s", d.Get("service"), d.Id(), status)
+		if utils.StrSliceContains(polling.Failed, status) {
+			return respBody, status, fmt.Errorf("unexpected status: %s", status)
+		}
+		if utils.StrSliceContains(polling.Target, status) {
+			return respBody, "COMPLETED", nil
+		}
+		if len(polling.Pending) > 0 && !utils.StrSliceContains(polling.Pending, status) {
+			return respBody, status, fmt.Errorf("unexpected status: %s", status)
+		}
+		return respBody, "PENDING", nil
+	}
+}
+
+func waitForRestResource(ctx context.Context, d *schema.ResourceData, cfg *config.Config, respBody interface{},
+	timeoutKey string) error {
+	polling := getRestPolling(d, respBody)
+	if polling == nil {
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"COMPLETED"},
+		Refresh:      pollRestStatus(d, cfg, polling),
+		Timeout:      d.Timeout(timeoutKey),
+		PollInterval: polling.Interval,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// waitForRestResourceDeleted waits for the job in the response to complete if the job_id_path is specified,
+// otherwise waits for the resource to be not found.
+func waitForRestResourceDeleted(ctx context.Context, d *schema.ResourceData, cfg *config.Config,
+	respBody interface{}) error {
+	polling := getRestPolling(d, respBody)
+	if polling == nil {
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"COMPLETED"},
+		Refresh:      pollRestStatus(d, cfg, polling),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		PollInterval: polling.Interval,
+	}
+	if polling.JobID == "" {
+		// the resource may be in the target status before it's deleted
+		polling.Path = getRestOperation(d, "read").Path
+		stateConf.Pending = []string{"PENDING", "COMPLETED"}
+		stateConf.Target = []string{"DELETED"}
+		stateConf.Refresh = pollRestStatus(d, cfg, polling)
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
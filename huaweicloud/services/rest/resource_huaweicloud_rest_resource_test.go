@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestRestResourceLifecycle(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+	res := ResourceRestResource()
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service": "vpc",
+		"create": []interface{}{
+			map[string]interface{}{"path": "v1/{project_id}/vpcs"},
+		},
+		"update": []interface{}{
+			map[string]interface{}{"path": "/v1/{project_id}/vpcs/{id}"},
+		},
+		"body":           `{"vpc": {"name": "vpc-rest", "cidr": "192.168.0.0/16", "description": "{project_id}"}}`,
+		"id_path":        "vpc.id",
+		"read_body_path": "@",
+		"polling": []interface{}{
+			map[string]interface{}{
+				"status_path": "vpc.status",
+				"target":      []interface{}{"OK"},
+				"interval":    1,
+			},
+		},
+	})
+	th.AssertEquals(t, false, res.CreateContext(ctx, d, cfg).HasError())
+
+	obj, ok := server.Object(fakecloud.KindVpc, d.Id())
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "vpc-rest", obj["name"])
+
+	var output map[string]interface{}
+	th.AssertNoErr(t, json.Unmarshal([]byte(d.Get("output").(string)), &output))
+	th.AssertEquals(t, "192.168.0.0/16", output["vpc"].(map[string]interface{})["cidr"])
+
+	// the output reflects the changes outside of Terraform, so that the drift is detected
+	obj["description"] = "updated outside"
+	server.SetObject(fakecloud.KindVpc, d.Id(), obj)
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, true, strings.Contains(d.Get("output").(string), "updated outside"))
+
+	// the fields of the body are refreshed by the read response, except the values with the placeholders
+	obj["name"] = "renamed-outside"
+	server.SetObject(fakecloud.KindVpc, d.Id(), obj)
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	var body map[string]interface{}
+	th.AssertNoErr(t, json.Unmarshal([]byte(d.Get("body").(string)), &body))
+	th.AssertDeepEquals(t, map[string]interface{}{
+		"vpc": map[string]interface{}{
+			"name":        "renamed-outside",
+			"cidr":        "192.168.0.0/16",
+			"description": "{project_id}",
+		},
+	}, body)
+
+	th.AssertEquals(t, false, res.DeleteContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, 0, server.Count(fakecloud.KindVpc))
+
+	// the resource is removed from the state if it's deleted outside of Terraform
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "", d.Id())
+}
+
+func TestRestResourceImport(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+	res := ResourceRestResource()
+
+	created := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"service": "vpc",
+		"create": []interface{}{
+			map[string]interface{}{"path": "v1/{project_id}/vpcs"},
+		},
+		"body":    `{"vpc": {"name": "vpc-rest", "cidr": "192.168.0.0/16"}}`,
+		"id_path": "vpc.id",
+	})
+	th.AssertEquals(t, false, res.CreateContext(ctx, created, cfg).HasError())
+
+	d := res.Data(nil)
+	d.SetId("vpc/" + created.Id() + "/v1/{project_id}/vpcs/{id}")
+	results, err := res.Importer.StateContext(ctx, d, cfg)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(results))
+	th.AssertEquals(t, created.Id(), d.Id())
+	th.AssertEquals(t, "vpc", d.Get("service"))
+	th.AssertEquals(t, "v1/{project_id}/vpcs/{id}", d.Get("read.0.path"))
+
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, created.Id(), d.Id())
+	th.AssertEquals(t, true, strings.Contains(d.Get("output").(string), "vpc-rest"))
+
+	for _, id := range []string{created.Id(), "vpc/" + created.Id(), "vpc//v1/vpcs", "unknown/" + created.Id() + "/v1/vpcs"} {
+		d := res.Data(nil)
+		d.SetId(id)
+		_, err := res.Importer.StateContext(ctx, d, cfg)
+		th.AssertEquals(t, true, err != nil)
+	}
+}
@@ -408,7 +408,7 @@ func resourceSFSFileSystemV2Update(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagErr := utils.UpdateResourceTags(sfsClient, d, "sfs", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of sfs:%s, err:%s", d.Id(), tagErr)
@@ -510,7 +510,7 @@ func resourceSFSTurboUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		if err := updateSFSTurboTags(sfsClient, d); err != nil {
 			return diag.Errorf("error updating tags of SFS Turbo %s: %s", resourceId, err)
 		}
@@ -572,7 +572,7 @@ func updateSFSTurboTags(client *golangsdk.ServiceClient, d *schema.ResourceData)
 }
 
 func getOldTagKeys(d *schema.ResourceData) []string {
-	oRaw, _ := d.GetChange("tags")
+	oRaw, _ := utils.GetTagsChange(d)
 	var tagKeys []string
 	if oMap := oRaw.(map[string]interface{}); len(oMap) > 0 {
 		for k := range oMap {
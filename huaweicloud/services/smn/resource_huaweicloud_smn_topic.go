@@ -279,7 +279,7 @@ func resourceTopicUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if utils.HasTagsChange(d) {
 		tagClient, err := cfg.SmnV2TagClient(region)
 		if err != nil {
 			return diag.Errorf("error creating SMN tag client: %s", err)
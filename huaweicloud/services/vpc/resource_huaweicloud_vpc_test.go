@@ -0,0 +1,88 @@
+package vpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/internal/fakecloud"
+)
+
+func TestVpcResourceLifecycle(t *testing.T) {
+	server := fakecloud.NewServer(t)
+	cfg := server.Config(t)
+	ctx := context.Background()
+	res := ResourceVirtualPrivateCloudV1()
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "vpc-fake",
+		"cidr": "192.168.0.0/16",
+		"tags": map[string]interface{}{"foo": "bar"},
+	})
+	th.AssertEquals(t, false, res.CreateContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "vpc-fake", d.Get("name"))
+	th.AssertEquals(t, "OK", d.Get("status"))
+	th.AssertEquals(t, "bar", d.Get("tags.foo"))
+
+	obj, ok := server.Object(fakecloud.KindVpc, d.Id())
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "192.168.0.0/16", obj["cidr"])
+
+	// the changes outside of Terraform are detected by the next refresh
+	obj["description"] = "updated outside"
+	server.SetObject(fakecloud.KindVpc, d.Id(), obj)
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "updated outside", d.Get("description"))
+
+	imported := res.Data(nil)
+	imported.SetId(d.Id())
+	states, err := res.Importer.StateContext(ctx, imported, cfg)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(states))
+	th.AssertEquals(t, false, res.ReadContext(ctx, states[0], cfg).HasError())
+	th.AssertEquals(t, "vpc-fake", states[0].Get("name"))
+
+	th.AssertEquals(t, false, res.DeleteContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, 0, server.Count(fakecloud.KindVpc))
+
+	// the resource is removed from the state if it's deleted outside of Terraform
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "", d.Id())
+}
+
+// TestVpcResourceReplay replays the lifecycle of the VPC resource from testdata/cassettes/TestVpcResourceReplay.json
+// without any network access. Run it with HW_VCR_MODE=record to record the cassette again with the fake cloud.
+func TestVpcResourceReplay(t *testing.T) {
+	const serverAddr = "127.0.0.1:18080"
+	if config.GetVcrMode() == config.VcrModeRecord {
+		fakecloud.NewServerAt(t, serverAddr)
+	} else {
+		t.Setenv("HW_VCR_MODE", config.VcrModeReplay)
+	}
+	cfg := fakecloud.NewConfig(t, "http://"+serverAddr, func(c *config.Config) {
+		c.CassetteName = t.Name()
+	})
+	ctx := context.Background()
+	res := ResourceVirtualPrivateCloudV1()
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "vpc-replay",
+		"cidr": "192.168.0.0/16",
+	})
+	th.AssertEquals(t, false, res.CreateContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "vpc-replay", d.Get("name"))
+	th.AssertEquals(t, "OK", d.Get("status"))
+
+	imported := res.Data(nil)
+	imported.SetId(d.Id())
+	th.AssertEquals(t, false, res.ReadContext(ctx, imported, cfg).HasError())
+	th.AssertEquals(t, "192.168.0.0/16", imported.Get("cidr"))
+
+	th.AssertEquals(t, false, res.DeleteContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, false, res.ReadContext(ctx, d, cfg).HasError())
+	th.AssertEquals(t, "", d.Id())
+}
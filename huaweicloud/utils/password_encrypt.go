@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/GehirnInc/crypt"
 
@@ -13,6 +14,9 @@ import (
 
 var letters = []byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
+// saltCharacters are the characters allowed in the salt of SHA512 crypt
+const saltCharacters = "./0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
 // Salt generates a random salt according to given size
 func Salt(size int) ([]byte, error) {
 	salt := make([]byte, size)
@@ -36,10 +40,23 @@ func PasswordEncrypt(password string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	salt := "$6$" + string(saltBytes) + "$"
+	return PasswordEncryptWithSalt(password, string(saltBytes))
+}
+
+// PasswordEncryptWithSalt encrypts given password with sha512 and the given salt, the result is always the same for
+// the same password and salt. The salt contains 1 to 16 characters of letters, digits, dots (.) and slashes (/).
+func PasswordEncryptWithSalt(password, salt string) (string, error) {
+	if len(salt) == 0 || len(salt) > 16 {
+		return "", fmt.Errorf("the length of the salt must be 1 to 16, but got %d", len(salt))
+	}
+	for _, c := range salt {
+		if !strings.ContainsRune(saltCharacters, c) {
+			return "", fmt.Errorf("the salt contains the invalid character %q", c)
+		}
+	}
 
 	sha512crypt := crypt.SHA512.New()
-	passwordEncrypted, err := sha512crypt.Generate([]byte(password), []byte(salt))
+	passwordEncrypted, err := sha512crypt.Generate([]byte(password), []byte("$6$"+salt+"$"))
 	if err != nil {
 		return "", fmt.Errorf("error encrypting the password: %s", err)
 	}
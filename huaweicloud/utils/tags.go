@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -25,8 +26,8 @@ func CreateResourceTags(client *golangsdk.ServiceClient, d *schema.ResourceData,
 // UpdateResourceTags is a helper to update the tags for a resource.
 // It expects the tags field to be named "tags"
 func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
-	if d.HasChange("tags") {
-		oRaw, nRaw := d.GetChange("tags")
+	if HasTagsChange(d) {
+		oRaw, nRaw := GetTagsChange(d)
 		oMap := oRaw.(map[string]interface{})
 		nMap := nRaw.(map[string]interface{})
 
@@ -52,6 +53,77 @@ func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, r
 	return nil
 }
 
+// HasTagsChange returns whether the tags of the resource have been changed, including the provider-level default tags
+// saved in `tags_all`.
+func HasTagsChange(d *schema.ResourceData) bool {
+	return d.HasChanges("tags", "tags_all")
+}
+
+// GetTagsChange returns the old and new values of the tags of the resource. The old value is taken from `tags_all` if
+// the resource has it, because the provider-level default tags which are removed from the provider configuration are
+// only saved there.
+func GetTagsChange(d *schema.ResourceData) (interface{}, interface{}) {
+	oRaw, nRaw := d.GetChange("tags")
+	oAllRaw, _ := d.GetChange("tags_all")
+	if oAllMap, ok := oAllRaw.(map[string]interface{}); ok && len(oAllMap) > 0 {
+		oRaw = oAllMap
+	}
+	return oRaw, nRaw
+}
+
+// MergeDefaultTags returns the result of merging the provider-level default tags and the resource-level tags,
+// the resource-level tags take precedence over the default tags with the same key.
+func MergeDefaultTags(defaultTags, resourceTags map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(defaultTags)+len(resourceTags))
+	for k, v := range defaultTags {
+		result[k] = v
+	}
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	return result
+}
+
+// IgnoreTagsConfig is the provider-level configuration of the tags which are managed outside of terraform.
+type IgnoreTagsConfig struct {
+	Keys        []string
+	KeyPrefixes []string
+}
+
+// IsIgnored returns whether the tag key matches any of the ignored keys or key prefixes.
+func (c *IgnoreTagsConfig) IsIgnored(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	for _, k := range c.Keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range c.KeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTags returns a copy of the tags without the ignored ones.
+func (c *IgnoreTagsConfig) FilterTags(tagmap map[string]interface{}) map[string]interface{} {
+	if c == nil {
+		return tagmap
+	}
+
+	result := make(map[string]interface{}, len(tagmap))
+	for k, v := range tagmap {
+		if !c.IsIgnored(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 // DeleteResourceTagsWithKeys is a helper to delete the tags with tagKeys for a resource.
 func DeleteResourceTagsWithKeys(client *golangsdk.ServiceClient, tagKeys []string, resourceType, id string) error {
 	for _, key := range tagKeys {
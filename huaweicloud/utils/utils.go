@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jmespath/go-jmespath"
@@ -583,3 +584,23 @@ func IsUUID(uuid string) bool {
 	match, _ := regexp.MatchString(pattern, uuid)
 	return match
 }
+
+// GetWriteOnlyString returns the string value of the write-only argument from the raw config, the nested key likes
+// "db.0.password_wo" is supported. The write-only arguments are never persisted to the plan or state, so they can only
+// be got from the raw config during the apply.
+func GetWriteOnlyString(d *schema.ResourceData, key string) string {
+	path := cty.Path{}
+	for _, part := range strings.Split(key, ".") {
+		if index, err := strconv.Atoi(part); err == nil {
+			path = path.IndexInt(index)
+		} else {
+			path = path.GetAttr(part)
+		}
+	}
+
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
@@ -131,3 +131,59 @@ func TestAccFunction_IsUUID(t *testing.T) {
 		t.Logf("The processing result of IsUUID method meets expectation: %s", green(expected[i]))
 	}
 }
+
+func TestAccFunction_MergeDefaultTags(t *testing.T) {
+	var (
+		defaultTags = map[string]interface{}{
+			"owner": "platform",
+			"env":   "dev",
+		}
+		resourceTags = map[string]interface{}{
+			"env":  "prod",
+			"name": "demo",
+		}
+		expected = map[string]interface{}{
+			"owner": "platform",
+			"env":   "prod",
+			"name":  "demo",
+		}
+	)
+
+	testOutput := MergeDefaultTags(defaultTags, resourceTags)
+	if !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of MergeDefaultTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+	t.Logf("The processing result of MergeDefaultTags method meets expectation: %s", green(expected))
+}
+
+func TestAccFunction_IgnoreTagsConfig(t *testing.T) {
+	var (
+		ignoreTags = &IgnoreTagsConfig{
+			Keys:        []string{"CreatedBy"},
+			KeyPrefixes: []string{"finops:", "cbr_"},
+		}
+		testInput = map[string]interface{}{
+			"CreatedBy":        "tms",
+			"finops:cost_unit": "1001",
+			"cbr_policy":       "daily",
+			"owner":            "platform",
+		}
+		expected = map[string]interface{}{
+			"owner": "platform",
+		}
+	)
+
+	testOutput := ignoreTags.FilterTags(testInput)
+	if !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of FilterTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+
+	var nilConfig *IgnoreTagsConfig
+	if testOutput = nilConfig.FilterTags(testInput); !reflect.DeepEqual(testOutput, testInput) {
+		t.Fatalf("The processing result of FilterTags method with nil config is not as expected, want %s, but %s",
+			green(testInput), yellow(testOutput))
+	}
+	t.Logf("The processing result of FilterTags method meets expectation: %s", green(expected))
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
@@ -13,6 +15,16 @@ func main() {
 	// prevent duplicate timestamp and incorrect log level setting
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
 
+	// the provider binary generates the configurations of the existing resources with the sub-command "generate"
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(runGenerate(os.Args[2:]))
+	}
+
+	serverFactory, err := huaweicloud.ProtoV5ProviderServerFactory(context.Background())
+	if err != nil {
+		log.Fatalf("[ERROR] Error creating the provider server: %s", err)
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: huaweicloud.Provider})
+		GRPCProviderFunc: serverFactory})
 }